@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/client/pkg/crypto"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// BindCertificate binds the client certificate of the connection to the user account on the server,
+// after that the user can log in only with this certificate. It returns the SHA-256 fingerprint
+// of the bound certificate.
+//
+// Warning(!): method wipes the given password slice to prevent long-term storage in memory.
+func (c *Client) BindCertificate(ctx context.Context, password []byte) (string, error) {
+	const op = "bind certificate"
+
+	defer crypto.Wipe(password) // prevent long-term storage of the password in memory
+
+	if !c.HasLocalCredintials() {
+		return "", ErrUserNeedAuthentication
+	}
+
+	hash, _, err := buildPasswordHashes(ctx, c.credentials.Email, password)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+
+	ctx, err = c.newContextWithAuthData(ctx)
+	if err != nil {
+		return "", ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.BindCertificate(ctx, &pb.BindCertificateRequest{
+		Hash: hash,
+	})
+	if err != nil {
+		return "", c.convertCertificateError(ctx, op, err)
+	}
+
+	return resp.Fingerprint, nil
+}
+
+// UnbindCertificate removes the client certificate binding from the user account on the server.
+//
+// Warning(!): method wipes the given password slice to prevent long-term storage in memory.
+func (c *Client) UnbindCertificate(ctx context.Context, password []byte) error {
+	const op = "unbind certificate"
+
+	defer crypto.Wipe(password) // prevent long-term storage of the password in memory
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+
+	hash, _, err := buildPasswordHashes(ctx, c.credentials.Email, password)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	ctx, err = c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	_, err = c.grpcClient.UnbindCertificate(ctx, &pb.UnbindCertificateRequest{
+		Hash: hash,
+	})
+	if err != nil {
+		return c.convertCertificateError(ctx, op, err)
+	}
+
+	return nil
+}
+
+// convertCertificateError converts server error received on the client certificate binding to client error.
+func (c *Client) convertCertificateError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication),
+		errors.Is(err, pb.ErrUserNotExists):
+		c.logger.Debug(op, sl.Error(err))
+		_ = c.clearToken(ctx)
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrUserInvalidHash):
+		return ErrUserInvalidPassword
+	case errors.Is(err, pb.ErrClientCertificateRequired):
+		return ErrClientCertificateRequired
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"log/slog"
@@ -17,6 +18,7 @@ import (
 	"github.com/Karzoug/goph_keeper/client/internal/model"
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/client/internal/repository/storage/file"
 	"github.com/Karzoug/goph_keeper/client/internal/repository/storage/native"
 	sqlite "github.com/Karzoug/goph_keeper/client/internal/repository/storage/sqllite"
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
@@ -27,6 +29,13 @@ const (
 	createClientTimeout = 5 * time.Second
 	syncTimeout         = 5 * time.Second
 	syncInterval        = 5 * time.Minute
+	watchRetryInterval  = 30 * time.Second
+	// tokenRefreshMargin is a time before the token expiration
+	// when the token is refreshed
+	tokenRefreshMargin = time.Minute
+	// listVaultItemsPageSize is a number of items requested from server at once,
+	// server can return less if the items are big
+	listVaultItemsPageSize = 100
 )
 
 type clientCredentialsStorage interface {
@@ -42,6 +51,11 @@ type clientCredentialsStorage interface {
 type clientStorage interface {
 	GetOwner(ctx context.Context) (string, error)
 	SetOwner(ctx context.Context, email string) error
+	// GetWrappedVaultKey returns the vault key wrapped by the password-derived key,
+	// it is needed to work offline.
+	GetWrappedVaultKey(ctx context.Context) ([]byte, error)
+	SetWrappedVaultKey(ctx context.Context, key []byte) error
+	// ClearVault deletes all vault items and the wrapped vault key.
 	ClearVault(ctx context.Context) error
 
 	ListVaultItems(context.Context) ([]vault.Item, error)
@@ -56,15 +70,33 @@ type clientStorage interface {
 	Close() error
 }
 
+type clientBlobStorage interface {
+	// Create creates (or truncates) the file for the large vault item value.
+	Create(id string) (io.WriteCloser, error)
+	// Open opens the file of the large vault item value.
+	Open(id string) (io.ReadCloser, error)
+	// Delete deletes the file of the large vault item value if it exists.
+	Delete(id string) error
+	// Clear deletes all files.
+	Clear() error
+}
+
 type Client struct {
 	cfg    *config.Config
 	logger *slog.Logger
 
 	storage            clientStorage
+	blobStorage        clientBlobStorage
 	credentialsStorage clientCredentialsStorage
 	credentials        credentials
 	conn               *grpc.ClientConn
 	grpcClient         pb.GophKeeperServiceClient
+	// syncMu prevents simultaneous synchronizations:
+	// by ticker, by server change feed and by user
+	syncMu sync.Mutex
+	// tokenMu prevents simultaneous token refreshes:
+	// the refresh token is one-time, so the second refresh would fail
+	tokenMu sync.Mutex
 }
 
 func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Client, error) {
@@ -84,6 +116,12 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Client,
 	}
 	c.storage = ss
 
+	bs, err := file.New()
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	c.blobStorage = bs
+
 	c.credentialsStorage = ss
 	if cfg.CredentialsStorageType != storage.Database {
 		ns, err := native.New(cfg.CredentialsStorageType)
@@ -97,12 +135,14 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Client,
 		c.logger.Error(op, err)
 	}
 
-	cs, err := loadTLSCredentials(cfg.Host, cfg.CertFilename)
+	cs, err := loadTLSCredentials(cfg)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
 	addr := cfg.Host + ":" + cfg.Port
-	c.conn, err = grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(cs))
+	c.conn, err = grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(cs),
+		grpc.WithUnaryInterceptor(c.requestIDUnaryClientInterceptor))
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
@@ -118,6 +158,8 @@ func (c *Client) Run(ctx context.Context) error {
 	ticker := time.NewTicker(syncInterval)
 	defer ticker.Stop()
 
+	go c.watchVaultItems(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -140,20 +182,28 @@ func (c *Client) RootPath() string {
 	return c.cfg.RootPath
 }
 
-func loadTLSCredentials(host, certFilename string) (gcreds.TransportCredentials, error) {
+func loadTLSCredentials(cfg *config.Config) (gcreds.TransportCredentials, error) {
 	const op = "load TLS credentials"
 
 	config := &tls.Config{
-		ServerName: host,
+		ServerName: cfg.Host,
 		MinVersion: tls.VersionTLS13,
 	}
 
-	if len(certFilename) == 0 {
+	if len(cfg.ClientCertFilename) != 0 {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFilename, cfg.ClientKeyFilename)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(cfg.CertFilename) == 0 {
 		return gcreds.NewTLS(config), nil
 	}
 
 	certPool := x509.NewCertPool()
-	f, err := os.Open(certFilename)
+	f, err := os.Open(cfg.CertFilename)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
@@ -3,13 +3,16 @@ package client
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Karzoug/goph_keeper/client/internal/model"
 	"github.com/Karzoug/goph_keeper/client/internal/model/auth"
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/repository/storage"
 	"github.com/Karzoug/goph_keeper/client/pkg/crypto"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
 )
 
 type credentials struct {
@@ -60,7 +63,7 @@ func (c *Client) setCredentialsForOwnerOnly(ctx context.Context, email string, h
 		}
 
 		// case: owner db is not set
-		err := c.storage.ClearVault(ctx)
+		err := c.clearVault(ctx)
 		if err != nil {
 			return e.Wrap(op, err)
 		}
@@ -75,10 +78,24 @@ func (c *Client) setCredentialsForOwnerOnly(ctx context.Context, email string, h
 		return ErrUserNeedAuthentication
 	}
 
+	// the vault key saved on the last online login is unwrapped,
+	// it also checks the password
+	vaultKey := encrKey
+	wrapped, err := c.storage.GetWrappedVaultKey(ctx)
+	if err != nil && !errors.Is(err, storage.ErrRecordNotFound) {
+		return e.Wrap(op, err)
+	}
+	if err == nil {
+		vaultKey, err = vault.UnwrapVaultKey(wrapped, encrKey)
+		if err != nil {
+			return ErrUserInvalidPassword
+		}
+	}
+
 	c.credentials = credentials{
 		Credentials: model.Credentials{
 			Email:   email,
-			EncrKey: encrKey,
+			EncrKey: vaultKey,
 		},
 		AuthHash: hash,
 	}
@@ -106,7 +123,7 @@ func (c *Client) setCredentialsForced(ctx context.Context, email string, hash au
 	}
 
 	if owner != email {
-		err := c.storage.ClearVault(ctx)
+		err := c.clearVault(ctx)
 		if err != nil {
 			return e.Wrap(op, err)
 		}
@@ -131,11 +148,50 @@ func (c *Client) setCredentialsForced(ctx context.Context, email string, hash au
 	return nil
 }
 
-func (c *Client) setToken(ctx context.Context, token string) error {
+// setVaultKey unwraps the vault key received from server by the password-derived key
+// and sets it as the key to encrypt the vault items. The password-derived key itself
+// is used if the user has no vault key yet (the user is registered before it was introduced).
+func (c *Client) setVaultKey(ctx context.Context, wrapped []byte, encrKey vault.EncryptionKey) error {
+	const op = "set vault key"
+
+	vaultKey := encrKey
+	if len(wrapped) != 0 {
+		var err error
+		vaultKey, err = vault.UnwrapVaultKey(wrapped, encrKey)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		// save the wrapped key to work offline
+		if err := c.storage.SetWrappedVaultKey(ctx, wrapped); err != nil {
+			return e.Wrap(op, err)
+		}
+	}
+
+	c.credentials.EncrKey = vaultKey
+
+	return e.Wrap(op, c.credentialsStorage.SetCredentials(ctx, c.credentials.Credentials))
+}
+
+// clearVault deletes all vault items and files of large vault items.
+func (c *Client) clearVault(ctx context.Context) error {
+	if err := c.storage.ClearVault(ctx); err != nil {
+		return err
+	}
+	return c.blobStorage.Clear()
+}
+
+// setToken saves the tokens received from server,
+// lifetime is the token lifetime in seconds (zero if it is unknown).
+func (c *Client) setToken(ctx context.Context, token, refreshToken string, lifetime int64) error {
 	const op = "set token"
 
 	c.credentials.AuthHash = nil
 	c.credentials.Token = token
+	c.credentials.RefreshToken = refreshToken
+	c.credentials.TokenExpiresAt = 0
+	if lifetime > 0 {
+		c.credentials.TokenExpiresAt = time.Now().Unix() + lifetime
+	}
 
 	if !c.HasLocalCredintials() {
 		return e.Wrap(op, ErrUserNeedAuthentication)
@@ -148,11 +204,51 @@ func (c *Client) clearToken(ctx context.Context) error {
 	const op = "clear token"
 
 	c.credentials.Token = ""
+	c.credentials.RefreshToken = ""
+	c.credentials.TokenExpiresAt = 0
 
 	return e.Wrap(op,
 		c.credentialsStorage.SetCredentials(ctx, c.credentials.Credentials))
 }
 
+// refreshTokenIfExpired gets a new pair of tokens from server
+// if the token expires in less than tokenRefreshMargin.
+// It returns ErrUserNeedAuthentication only if the refresh token is rejected by server,
+// on other errors the current token is kept.
+func (c *Client) refreshTokenIfExpired(ctx context.Context) error {
+	const op = "refresh token"
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if len(c.credentials.RefreshToken) == 0 ||
+		c.credentials.TokenExpiresAt == 0 ||
+		time.Until(time.Unix(c.credentials.TokenExpiresAt, 0)) > tokenRefreshMargin {
+		return nil
+	}
+
+	resp, err := c.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+		RefreshToken: c.credentials.RefreshToken,
+	})
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		switch {
+		case errors.Is(err, pb.ErrInvalidTokenFormat),
+			errors.Is(err, pb.ErrUserNeedAuthentication):
+			_ = c.clearToken(ctx)
+			return ErrUserNeedAuthentication
+		default:
+			return nil
+		}
+	}
+
+	if err := c.setToken(ctx, resp.Token, resp.RefreshToken, resp.TokenLifetime); err != nil {
+		c.logger.Debug(op, sl.Error(err))
+	}
+
+	return nil
+}
+
 func (c *Client) clearCredentials(ctx context.Context) error {
 	const op = "clear credentials"
 
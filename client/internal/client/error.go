@@ -12,10 +12,25 @@ var (
 	ErrUserInvalidPassword          = errors.New("invalid password")
 	ErrUserEmailNotVerified         = errors.New("email not verified")
 	ErrInvalidEmailVerificationCode = errors.New("invalid email verification code")
+	ErrUserEmailAlreadyVerified     = errors.New("email already verified: please login")
 	ErrUserNotExists                = errors.New("user not exists")
+	ErrUserDisabled                 = errors.New("account disabled: contact the server administrator")
 	ErrAppInternal                  = errors.New("app internal error")
 	ErrServerInternal               = errors.New("server internal error")
 	ErrServerUnavailable            = errors.New("no connection to server")
 	ErrUserNeedAuthentication       = errors.New("need authentication: please login")
 	ErrConflictVersion              = errors.New("conflict data version on server and client")
+	ErrVaultItemValueTooBig         = errors.New("value too big to store on server")
+	ErrVaultItemNotExists           = errors.New("vault item not exists on server")
+	ErrQuotaExceeded                = errors.New("vault quota on server exceeded")
+	ErrRevisionNotExists            = errors.New("item version not exists on server")
+	ErrSessionNotExists             = errors.New("session not exists on server")
+	ErrInvalidRecoveryKey           = errors.New("invalid email or recovery key")
+	ErrTOTPRequired                 = errors.New("two-factor authentication code required")
+	ErrInvalidTOTPCode              = errors.New("invalid two-factor authentication code")
+	ErrTOTPAlreadyEnabled           = errors.New("two-factor authentication already enabled")
+	ErrTooManyAttempts              = errors.New("too many failed attempts, try again later")
+	ErrVerificationCodeResent       = errors.New("verification code was sent recently, try again later")
+	ErrClientCertificateRequired    = errors.New("client certificate required: set it in the config")
+	ErrClientCertificateMismatch    = errors.New("client certificate not bound to the account")
 )
@@ -0,0 +1,417 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/client/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
+	"github.com/Karzoug/goph_keeper/client/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/client/pkg/crypto"
+	"github.com/Karzoug/goph_keeper/client/pkg/crypto/chacha20poly1305"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// ChangePassword changes the user password. If the user has the vault key,
+// it is just rewrapped by the new password-derived key. Otherwise (the user is registered
+// before the vault key was introduced) the new random vault key is created and all vault items
+// are re-encrypted with it and replaced on the server in one transaction together with the auth hash.
+// Sessions on other devices are revoked by the server.
+//
+// Before the re-encryption the local vault is synchronized with the server,
+// method returns ErrConflictVersion if some local changes could not be sent to the server.
+//
+// Warning(!): method wipes the given password slices to prevent long-term storage in memory.
+func (c *Client) ChangePassword(ctx context.Context, oldPassword, newPassword []byte) error {
+	const op = "change password"
+
+	defer crypto.Wipe(oldPassword) // prevent long-term storage of the passwords in memory
+	defer crypto.Wipe(newPassword)
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+	if utf8.RuneCount(newPassword) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	hash, encrKey, err := buildPasswordHashes(ctx, c.credentials.Email, oldPassword)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	wrapped, err := c.storage.GetWrappedVaultKey(ctx)
+	if err != nil && !errors.Is(err, storage.ErrRecordNotFound) {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	hasVaultKey := err == nil
+	if hasVaultKey {
+		if _, err := vault.UnwrapVaultKey(wrapped, encrKey); err != nil {
+			return ErrUserInvalidPassword
+		}
+	} else if !bytes.Equal(encrKey.Hash, c.credentials.EncrKey.Hash) {
+		return ErrUserInvalidPassword
+	}
+	newHash, newEncrKey, err := buildPasswordHashes(ctx, c.credentials.Email, newPassword)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	vaultKey := c.credentials.EncrKey
+	if !hasVaultKey {
+		vaultKey, err = vault.NewVaultKey()
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+	}
+	newWrapped, err := vault.WrapVaultKey(vaultKey, newEncrKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	ctx, err = c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	// case: the vault key is just rewrapped, the vault items stay untouched
+	if hasVaultKey {
+		if _, _, err := c.sendChangePassword(ctx, hash, newHash, newWrapped, nil, vaultKey, vaultKey); err != nil {
+			return err
+		}
+		if err := c.storage.SetWrappedVaultKey(ctx, newWrapped); err != nil {
+			c.logger.Error(op, sl.Error(err))
+			return ErrAppInternal
+		}
+		return nil
+	}
+
+	// the whole vault is replaced on server, so no other synchronization is allowed till the end
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	if err := c.updateVaultItemsFromServer(ctx); err != nil {
+		return err
+	}
+	if err := c.sendModifiedVaultItemsToServer(ctx); err != nil {
+		return err
+	}
+	if !c.HasToken() {
+		return ErrUserNeedAuthentication
+	}
+	modifiedItems, err := c.storage.ListModifiedVaultItems(ctx)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	if len(modifiedItems) != 0 {
+		return ErrConflictVersion
+	}
+
+	items, err := c.storage.ListVaultItems(ctx)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	// the values of large binary items are re-encrypted from the local files
+	for i := 0; i < len(items); i++ {
+		if items[i].Type != cvault.BinaryLarge || items[i].IsDeleted {
+			continue
+		}
+		f, err := c.blobStorage.Open(items[i].ID)
+		if err == nil {
+			f.Close()
+			continue
+		}
+		if !errors.Is(err, storage.ErrRecordNotFound) {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+		if err := c.downloadLargeVaultItem(ctx, items[i].ID); err != nil {
+			return err
+		}
+	}
+
+	t, items, err := c.sendChangePassword(ctx, hash, newHash, newWrapped, items, c.credentials.EncrKey, vaultKey)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(items); i++ {
+		items[i].ServerUpdatedAt = t
+		items[i].ClientUpdatedAt = t
+		if err := c.storage.SetVaultItem(ctx, items[i]); err != nil {
+			c.logger.Error(op, sl.Error(err))
+			return ErrAppInternal
+		}
+		// local file is encrypted with the old key, the new one is downloaded on demand
+		if items[i].Type == cvault.BinaryLarge {
+			if err := c.blobStorage.Delete(items[i].ID); err != nil {
+				c.logger.Debug(op, sl.Error(err))
+			}
+		}
+	}
+
+	if err := c.storage.SetWrappedVaultKey(ctx, newWrapped); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	c.credentials.EncrKey = vaultKey
+	if err := c.credentialsStorage.SetCredentials(ctx, c.credentials.Credentials); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	return nil
+}
+
+// RecoverAccount resets the lost password of the user by the recovery key shown on registration.
+// The vault key wrapped by the recovery key is received from the server and rewrapped
+// by the new password-derived key, so the vault items stay untouched.
+// All user sessions are revoked by the server, the user must log in with the new password.
+//
+// Warning(!): method wipes the given password slice to prevent long-term storage in memory.
+func (c *Client) RecoverAccount(ctx context.Context, email, recoveryKey string, newPassword []byte) error {
+	const op = "recover account"
+
+	defer crypto.Wipe(newPassword) // prevent long-term storage of the password in memory
+
+	if !isValidEmail(email) {
+		return ErrInvalidEmail
+	}
+	if utf8.RuneCount(newPassword) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	normalizedRecoveryKey, err := auth.NormalizeRecoveryKey(recoveryKey)
+	if err != nil {
+		return ErrInvalidRecoveryKey
+	}
+
+	recoveryHash, recoveryEncrKey, err := buildPasswordHashes(ctx, email, normalizedRecoveryKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	resp, err := c.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+		Email:        email,
+		RecoveryHash: recoveryHash,
+	})
+	if err != nil {
+		return c.convertRecoverAccountError(op, err)
+	}
+	vaultKey, err := vault.UnwrapVaultKey(resp.RecoveryWrappedVaultKey, recoveryEncrKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrInvalidRecoveryKey
+	}
+
+	newHash, newEncrKey, err := buildPasswordHashes(ctx, email, newPassword)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	newWrapped, err := vault.WrapVaultKey(vaultKey, newEncrKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	_, err = c.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+		Email:              email,
+		RecoveryHash:       recoveryHash,
+		NewHash:            newHash,
+		NewWrappedVaultKey: newWrapped,
+	})
+	if err != nil {
+		return c.convertRecoverAccountError(op, err)
+	}
+
+	// the local vault key wrapped by the lost password is not valid anymore
+	if owner, err := c.storage.GetOwner(ctx); err == nil && owner == email {
+		if err := c.storage.SetWrappedVaultKey(ctx, newWrapped); err != nil {
+			c.logger.Error(op, sl.Error(err))
+			return ErrAppInternal
+		}
+	}
+
+	return nil
+}
+
+// convertRecoverAccountError converts server error received on account recovery to client error.
+func (c *Client) convertRecoverAccountError(op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrUserInvalidHash):
+		return ErrInvalidRecoveryKey
+	case errors.Is(err, pb.ErrUserEmailNotVerified):
+		return ErrUserEmailNotVerified
+	case errors.Is(err, pb.ErrUserDisabled):
+		return ErrUserDisabled
+	case errors.Is(err, pb.ErrTooManyAttempts):
+		return ErrTooManyAttempts
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
+
+// sendChangePassword sends the hashes, the wrapped vault key and all not deleted vault items
+// re-encrypted with the new key to the server.
+// It returns the new server update time and the sent items with the new values.
+func (c *Client) sendChangePassword(ctx context.Context,
+	hash, newHash, newWrappedVaultKey []byte,
+	items []vault.Item,
+	encrKey, newEncrKey vault.EncryptionKey,
+) (int64, []vault.Item, error) {
+	const op = "send change password to server"
+
+	stream, err := c.grpcClient.ChangePassword(ctx)
+	if err != nil {
+		return 0, nil, c.convertChangePasswordError(op, err)
+	}
+
+	err = stream.Send(&pb.ChangePasswordRequest{
+		Data: &pb.ChangePasswordRequest_Hashes{
+			Hashes: &pb.ChangePasswordHashes{
+				Hash:               hash,
+				NewHash:            newHash,
+				NewWrappedVaultKey: newWrappedVaultKey,
+			},
+		},
+	})
+
+	sentItems := make([]vault.Item, 0, len(items))
+	for i := 0; i < len(items) && err == nil; i++ {
+		item := items[i]
+		if item.IsDeleted {
+			continue
+		}
+
+		if item.Type != cvault.BinaryLarge {
+			value, derr := item.DecryptAnGetValue(encrKey)
+			if derr != nil {
+				c.logger.Debug(op, sl.Error(derr))
+				return 0, nil, ErrAppInternal
+			}
+			if derr := item.EncryptAndSetValue(value, newEncrKey); derr != nil {
+				c.logger.Debug(op, sl.Error(derr))
+				return 0, nil, ErrAppInternal
+			}
+		}
+
+		err = stream.Send(&pb.ChangePasswordRequest{
+			Data: &pb.ChangePasswordRequest_Item{
+				Item: &pb.VaultItem{
+					Id:              item.ID,
+					Name:            item.Name,
+					Itype:           pb.IType(item.Type),
+					Value:           item.Value,
+					ServerUpdatedAt: item.ServerUpdatedAt,
+				},
+			},
+		})
+		if err == nil && item.Type == cvault.BinaryLarge {
+			err = c.sendReencryptedLargeValue(stream, item.ID, encrKey, newEncrKey)
+			if errors.Is(err, ErrAppInternal) {
+				return 0, nil, err
+			}
+		}
+
+		sentItems = append(sentItems, item)
+	}
+
+	// if sending failed, the server status is returned here
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, nil, c.convertChangePasswordError(op, err)
+	}
+
+	return resp.ServerUpdatedAt, sentItems, nil
+}
+
+// sendReencryptedLargeValue sends the value of the large binary item from the local file
+// re-encrypted with the new key by the same chunks it is encrypted.
+// It returns ErrAppInternal on local failure or the stream error.
+func (c *Client) sendReencryptedLargeValue(stream pb.GophKeeperService_ChangePasswordClient,
+	id string,
+	encrKey, newEncrKey vault.EncryptionKey,
+) error {
+	const op = "send re-encrypted large value"
+
+	f, err := c.blobStorage.Open(id)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	defer f.Close()
+
+	// local file -> decrypt with the old key -> encrypt with the new key -> stream
+	dr, dw := io.Pipe()
+	er, ew := io.Pipe()
+	defer dr.Close()
+	defer er.Close()
+	go func() {
+		dw.CloseWithError(vault.DecryptLargeValue(f, dw, encrKey))
+	}()
+	go func() {
+		ew.CloseWithError(vault.EncryptLargeValue(dr, ew, newEncrKey))
+	}()
+
+	buf := make([]byte, chacha20poly1305.GetEncryptedChunkSize())
+	for {
+		n, err := io.ReadFull(er, buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ChangePasswordRequest{
+				Data: &pb.ChangePasswordRequest_Chunk{
+					Chunk: buf[:n],
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+	}
+}
+
+// convertChangePasswordError converts server error received on changing password to client error.
+func (c *Client) convertChangePasswordError(op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrUserInvalidHash):
+		return ErrUserInvalidPassword
+	case errors.Is(err, pb.ErrVaultItemConflictVersion):
+		return ErrConflictVersion
+	case errors.Is(err, pb.ErrVaultItemValueTooBig):
+		return ErrVaultItemValueTooBig
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey is the metadata key of the request ID the server returns with every response.
+const requestIDMetadataKey = "x-request-id"
+
+// requestIDUnaryClientInterceptor logs the request ID of the failed call,
+// so the error shown to the user can be found in the server logs.
+func (c *Client) requestIDUnaryClientInterceptor(ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	var trailer metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+	if err == nil {
+		return nil
+	}
+
+	ids := trailer.Get(requestIDMetadataKey)
+	if len(ids) == 0 {
+		return err
+	}
+
+	code := status.Code(err)
+	level := slog.LevelDebug
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	c.logger.Log(ctx, level, "request failed",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.String("request_id", ids[0]))
+
+	return err
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// ListItemRevisions returns the decrypted previous versions of the vault item kept on the server, newest first.
+func (c *Client) ListItemRevisions(ctx context.Context, id string) ([]vault.Revision, error) {
+	const op = "list item revisions"
+
+	if !c.HasLocalCredintials() {
+		return nil, ErrUserNeedAuthentication
+	}
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return nil, ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.ListItemRevisions(ctx, &pb.ListItemRevisionsRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, c.convertRevisionError(ctx, op, err)
+	}
+
+	revisions := make([]vault.Revision, len(resp.Revisions))
+	for i, rev := range resp.Revisions {
+		item := vault.Item{
+			ID:    rev.Id,
+			Name:  rev.Name,
+			Type:  cvault.ItemType(rev.Itype),
+			Value: rev.Value,
+		}
+		value, err := item.DecryptAnGetValue(c.credentials.EncrKey)
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return nil, ErrAppInternal
+		}
+		revisions[i] = vault.Revision{
+			Name:      rev.Name,
+			Version:   rev.ServerUpdatedAt,
+			UpdatedAt: time.UnixMicro(rev.ServerUpdatedAt),
+			Value:     value,
+		}
+	}
+
+	return revisions, nil
+}
+
+// RestoreItemRevision replaces the vault item on the server with its previous version
+// and synchronizes the local vault to get it.
+func (c *Client) RestoreItemRevision(ctx context.Context, id string, version int64) error {
+	const op = "restore item revision"
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+	authCtx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	if _, err := c.grpcClient.RestoreItemRevision(authCtx, &pb.RestoreItemRevisionRequest{
+		Id:       id,
+		Revision: version,
+	}); err != nil {
+		return c.convertRevisionError(ctx, op, err)
+	}
+
+	return c.SyncVaultItems(ctx)
+}
+
+// convertRevisionError converts server error received on item revisions requests to client error.
+func (c *Client) convertRevisionError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		c.logger.Debug(op, sl.Error(err))
+		_ = c.clearToken(ctx)
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrVaultItemRevisionNotExists):
+		return ErrRevisionNotExists
+	case errors.Is(err, pb.ErrQuotaExceeded):
+		return ErrQuotaExceeded
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/client/internal/model"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// ListSessions returns user sessions (devices that logged in to the server) sorted by creation time.
+func (c *Client) ListSessions(ctx context.Context) ([]model.Session, error) {
+	const op = "list sessions"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return nil, ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.ListSessions(ctx, &pb.ListSessionsRequest{})
+	if err != nil {
+		return nil, c.convertSessionError(ctx, op, err)
+	}
+
+	sessions := make([]model.Session, len(resp.Sessions))
+	for i := 0; i < len(resp.Sessions); i++ {
+		sessions[i] = model.Session{
+			ID:            resp.Sessions[i].Id,
+			DeviceName:    resp.Sessions[i].DeviceName,
+			ClientVersion: resp.Sessions[i].ClientVersion,
+			IP:            resp.Sessions[i].Ip,
+			CreatedAt:     time.UnixMicro(resp.Sessions[i].CreatedAt),
+			IsCurrent:     resp.Sessions[i].Current,
+		}
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes user session by ID, the device of the session has to login again.
+// To revoke the session of this client use Logout.
+func (c *Client) RevokeSession(ctx context.Context, id string) error {
+	const op = "revoke session"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	if _, err := c.grpcClient.RevokeSession(ctx, &pb.RevokeSessionRequest{
+		Id: id,
+	}); err != nil {
+		return c.convertSessionError(ctx, op, err)
+	}
+
+	return nil
+}
+
+// convertSessionError converts server error received on sessions management to client error.
+func (c *Client) convertSessionError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		c.logger.Debug(op, sl.Error(err))
+		_ = c.clearToken(ctx)
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrSessionNotExists):
+		return ErrSessionNotExists
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
+
+// newContextWithDeviceData adds description of this client to the login request,
+// so the user can recognize the session in the sessions list.
+func (c *Client) newContextWithDeviceData(ctx context.Context) context.Context {
+	name, err := os.Hostname()
+	if err != nil {
+		name = "unknown"
+	}
+	md := metadata.New(map[string]string{
+		"device-name":    name,
+		"client-version": c.Version(),
+	})
+	return metadata.NewOutgoingContext(ctx, md)
+}
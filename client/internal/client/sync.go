@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"errors"
+	"io"
 	"sort"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/client/pkg/crypto/chacha20poly1305"
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
 	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
@@ -26,6 +29,9 @@ func (c *Client) SyncVaultItems(ctx context.Context) error {
 		return ErrUserNeedAuthentication
 	}
 
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
 	if err := c.updateVaultItemsFromServer(ctx); err != nil {
 		return err
 	}
@@ -36,6 +42,72 @@ func (c *Client) SyncVaultItems(ctx context.Context) error {
 	return nil
 }
 
+// watchVaultItems listens to the server change feed and updates local vault
+// as soon as the vault is changed on server (by another device for example).
+// It works until ctx is done, the broken feed is reopened after watchRetryInterval.
+func (c *Client) watchVaultItems(ctx context.Context) {
+	const op = "watch vault items"
+
+	for {
+		err := c.watchVaultItemsOnce(ctx)
+		if status.Code(err) == codes.Unimplemented {
+			// old server: synchronization by ticker only
+			c.logger.Debug(op, sl.Error(err))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+func (c *Client) watchVaultItemsOnce(ctx context.Context) error {
+	const op = "watch vault items once"
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	stream, err := c.grpcClient.WatchVaultItems(ctx, &pb.WatchVaultItemsRequest{})
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return err
+		}
+
+		func() {
+			ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+			defer cancel()
+
+			c.syncMu.Lock()
+			defer c.syncMu.Unlock()
+
+			// skip the own changes: they are already in local storage
+			since, err := c.storage.GetLastServerUpdatedAt(ctx)
+			if err == nil && since >= resp.ServerUpdatedAt {
+				return
+			}
+
+			if err := c.updateVaultItemsFromServer(ctx); err != nil {
+				c.logger.Debug(op, sl.Error(err))
+			}
+		}()
+	}
+}
+
 func (c *Client) updateVaultItemsFromServer(ctx context.Context) error {
 	const op = "update vault items from server"
 
@@ -47,42 +119,111 @@ func (c *Client) updateVaultItemsFromServer(ctx context.Context) error {
 		}
 		since = 0
 	}
+	// ask the server if there have been updates since then, page by page
+	var (
+		pageToken string
+		// listed contains IDs of all the server items on full resync, nil otherwise
+		listed map[string]struct{}
+	)
+	for {
+		resp, err := c.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since:     since,
+			PageSize:  listVaultItemsPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, pb.ErrFullResyncRequired) && listed == nil:
+				// the deleted items could be purged on server already,
+				// so list the whole vault and drop the local items not listed
+				since, pageToken = 0, ""
+				listed = make(map[string]struct{})
+				continue
+			case errors.Is(err, pb.ErrEmptyAuthData),
+				errors.Is(err, pb.ErrInvalidTokenFormat),
+				errors.Is(err, pb.ErrUserNeedAuthentication):
+				c.logger.Debug(op, sl.Error(err))
+				_ = c.clearToken(ctx)
+				return ErrUserNeedAuthentication
+			default:
+				c.logger.Debug(op, sl.Error(err))
+				if status.Code(err) == codes.Unavailable {
+					return ErrServerUnavailable
+				}
+				return ErrServerInternal
+			}
+		}
 
-	// ask the server if there have been updates since then
-	resp, err := c.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
-		Since: since,
-	})
+		if err := c.updateVaultItemsPage(ctx, resp.Items); err != nil {
+			return err
+		}
+		if listed != nil {
+			for i := 0; i < len(resp.Items); i++ {
+				listed[resp.Items[i].Id] = struct{}{}
+			}
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	if listed != nil {
+		return c.deletePurgedVaultItems(ctx, listed)
+	}
+	return nil
+}
+
+// deletePurgedVaultItems deletes the synchronized local items not listed on full resync:
+// they were deleted on server and purged after the retention.
+// The items modified locally are kept to be sent to server.
+func (c *Client) deletePurgedVaultItems(ctx context.Context, listed map[string]struct{}) error {
+	const op = "delete purged vault items"
+
+	items, err := c.storage.ListVaultItems(ctx)
 	if err != nil {
-		switch {
-		case errors.Is(err, pb.ErrEmptyAuthData),
-			errors.Is(err, pb.ErrInvalidTokenFormat),
-			errors.Is(err, pb.ErrUserNeedAuthentication):
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	for i := 0; i < len(items); i++ {
+		if _, ok := listed[items[i].ID]; ok ||
+			items[i].ServerUpdatedAt == 0 ||
+			items[i].ServerUpdatedAt < items[i].ClientUpdatedAt {
+			continue
+		}
+		if err := c.storage.DeleteVaultItem(ctx, items[i].ID); err != nil {
 			c.logger.Debug(op, sl.Error(err))
-			_ = c.clearToken(ctx)
-			return ErrUserNeedAuthentication
-		default:
-			c.logger.Debug(op, err)
-			if status.Code(err) == codes.Unavailable {
-				return ErrServerUnavailable
-			}
-			return ErrServerInternal
+			return ErrAppInternal
+		}
+		if err := c.blobStorage.Delete(items[i].ID); err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
 		}
 	}
 
+	return nil
+}
+
+// updateVaultItemsPage saves items received from server to local storage.
+func (c *Client) updateVaultItemsPage(ctx context.Context, items []*pb.VaultItem) error {
+	const op = "update vault items page"
+
 	// process items in chronological order -
 	// this will allow us to return to the process later in case of an error and not get conflicts
-	sort.Slice(resp.Items, func(i, j int) bool {
-		return resp.Items[i].ServerUpdatedAt < resp.Items[j].ServerUpdatedAt
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ServerUpdatedAt < items[j].ServerUpdatedAt
 	})
-	for i := 0; i < len(resp.Items); i++ {
+	for i := 0; i < len(items); i++ {
 		item := vault.Item{
-			ID:              resp.Items[i].Id,
-			Name:            resp.Items[i].Name,
-			Type:            cvault.ItemType(resp.Items[i].Itype),
-			Value:           resp.Items[i].Value,
-			ServerUpdatedAt: resp.Items[i].ServerUpdatedAt,
-			ClientUpdatedAt: resp.Items[i].ServerUpdatedAt,
-			IsDeleted:       resp.Items[i].IsDeleted,
+			ID:              items[i].Id,
+			Name:            items[i].Name,
+			Type:            cvault.ItemType(items[i].Itype),
+			Value:           items[i].Value,
+			ServerUpdatedAt: items[i].ServerUpdatedAt,
+			ClientUpdatedAt: items[i].ServerUpdatedAt,
+			IsDeleted:       items[i].IsDeleted,
 		}
 		dbItem, err := c.storage.GetVaultItem(ctx, item.ID)
 		if err != nil {
@@ -91,6 +232,10 @@ func (c *Client) updateVaultItemsFromServer(ctx context.Context) error {
 				return ErrAppInternal
 			}
 		}
+		// case: this version is already received
+		if dbItem.ServerUpdatedAt == item.ServerUpdatedAt {
+			continue
+		}
 		// case: conflict version on server and client,
 		// move client item version to conflict db table and
 		// save server item version to main vault table
@@ -107,6 +252,14 @@ func (c *Client) updateVaultItemsFromServer(ctx context.Context) error {
 			c.logger.Debug(op, err)
 			return ErrAppInternal
 		}
+		// the value of the large binary item is not listed,
+		// so the local file is outdated now, it will be downloaded again on demand
+		if item.Type == cvault.BinaryLarge {
+			if err := c.blobStorage.Delete(item.ID); err != nil {
+				c.logger.Debug(op, sl.Error(err))
+				return ErrAppInternal
+			}
+		}
 	}
 
 	return nil
@@ -118,32 +271,73 @@ func (c *Client) sendModifiedVaultItemsToServer(ctx context.Context) error {
 	// get all modified items from storage
 	modifiedItems, err := c.storage.ListModifiedVaultItems(ctx)
 	if err != nil {
-		c.logger.Debug(op, err)
+		c.logger.Debug(op, sl.Error(err))
 		return ErrAppInternal
 	}
 
-	// process items in chronological order -
-	// this will allow us to return to the process later in case of an error and not get conflicts
-	sort.Slice(modifiedItems, func(i, j int) bool {
-		return modifiedItems[i].ServerUpdatedAt < modifiedItems[j].ServerUpdatedAt
-	})
+	// large binary items are uploaded one by one by stream, all the rest are sent in one batch
+	items := make([]vault.Item, 0, len(modifiedItems))
+	largeItems := make([]vault.Item, 0)
 	for i := 0; i < len(modifiedItems); i++ {
-		var (
-			serverTime int64
-			err        error
-		)
-		if modifiedItems[i].Type == cvault.BinaryLarge {
-			serverTime, err = c.sendLargeVaultItem(ctx, modifiedItems[i])
+		if modifiedItems[i].Type == cvault.BinaryLarge && !modifiedItems[i].IsDeleted {
+			largeItems = append(largeItems, modifiedItems[i])
 		} else {
-			serverTime, err = c.sendVaultItem(ctx, modifiedItems[i])
+			items = append(items, modifiedItems[i])
 		}
+	}
+
+	var hasTooBigItems, hasQuotaExceeded bool
+
+	if len(items) != 0 {
+		results, err := c.sendVaultItems(ctx, items)
+		if err != nil {
+			if errors.Is(err, ErrUserNeedAuthentication) {
+				_ = c.clearToken(ctx)
+				return nil
+			}
+			return err
+		}
+
+		for i := 0; i < len(results); i++ {
+			switch results[i].Status {
+			case pb.SetVaultItemStatus_ACCEPTED:
+				// if synchronization for this item was successful,
+				// update item server time
+				items[i].ServerUpdatedAt = results[i].ServerUpdatedAt
+				if err := c.storage.SetVaultItem(ctx, items[i]); err != nil {
+					c.logger.Debug(op, sl.Error(err))
+					return ErrAppInternal
+				}
+			case pb.SetVaultItemStatus_CONFLICT_VERSION:
+				// usually this is not happened,
+				// but if so, next method iteration hadle this conflict
+			case pb.SetVaultItemStatus_VALUE_TOO_BIG:
+				hasTooBigItems = true
+			case pb.SetVaultItemStatus_QUOTA_EXCEEDED:
+				hasQuotaExceeded = true
+			}
+		}
+	}
+
+	// process large items in chronological order -
+	// this will allow us to return to the process later in case of an error and not get conflicts
+	sort.Slice(largeItems, func(i, j int) bool {
+		return largeItems[i].ServerUpdatedAt < largeItems[j].ServerUpdatedAt
+	})
+	for i := 0; i < len(largeItems); i++ {
+		serverTime, err := c.sendLargeVaultItem(ctx, largeItems[i])
 		if err != nil {
 			switch {
 			case errors.Is(err, ErrConflictVersion):
 				// usually this is not happened,
-				// but if so, we need to exit here,
-				// next method iteration hadle this conflict
-				return nil
+				// but if so, next method iteration hadle this conflict
+				continue
+			case errors.Is(err, ErrVaultItemValueTooBig):
+				hasTooBigItems = true
+				continue
+			case errors.Is(err, ErrQuotaExceeded):
+				hasQuotaExceeded = true
+				continue
 			case errors.Is(err, ErrUserNeedAuthentication):
 				_ = c.clearToken(ctx)
 				return nil
@@ -153,18 +347,174 @@ func (c *Client) sendModifiedVaultItemsToServer(ctx context.Context) error {
 
 		// if synchronization for this item was successful,
 		// update item server time
-		modifiedItems[i].ServerUpdatedAt = serverTime
-		if err := c.storage.SetVaultItem(ctx, modifiedItems[i]); err != nil {
-			c.logger.Debug(op, err)
-			return ErrServerInternal
+		largeItems[i].ServerUpdatedAt = serverTime
+		if err := c.storage.SetVaultItem(ctx, largeItems[i]); err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
 		}
 	}
+
+	if hasTooBigItems {
+		return ErrVaultItemValueTooBig
+	}
+	if hasQuotaExceeded {
+		return ErrQuotaExceeded
+	}
+
 	return nil
 }
 
+// sendVaultItems sends items to server in one batch and returns results in the same order as items.
+func (c *Client) sendVaultItems(ctx context.Context, items []vault.Item) ([]*pb.SetVaultItemResult, error) {
+	const op = "send modified vault items batch to server"
+
+	pbItems := make([]*pb.VaultItem, len(items))
+	for i := 0; i < len(items); i++ {
+		pbItems[i] = &pb.VaultItem{
+			Id:              items[i].ID,
+			Name:            items[i].Name,
+			Itype:           pb.IType(items[i].Type),
+			Value:           items[i].Value,
+			ServerUpdatedAt: items[i].ServerUpdatedAt,
+			IsDeleted:       items[i].IsDeleted,
+		}
+	}
+
+	resp, err := c.grpcClient.SetVaultItems(ctx, &pb.SetVaultItemsRequest{
+		Items: pbItems,
+	})
+	if err != nil {
+		return nil, c.convertSendVaultItemError(op, err)
+	}
+
+	if len(resp.Results) != len(items) {
+		c.logger.Debug(op + ": number of results does not match number of items")
+		return nil, ErrServerInternal
+	}
+	for i := 0; i < len(items); i++ {
+		if resp.Results[i].Id != items[i].ID {
+			c.logger.Debug(op + ": order of results does not match order of items")
+			return nil, ErrServerInternal
+		}
+	}
+
+	return resp.Results, nil
+}
+
 func (c *Client) sendLargeVaultItem(ctx context.Context, item vault.Item) (int64, error) {
-	// TODO: implement me
-	panic("not implemented")
+	const op = "send modified large vault item to server"
+
+	f, err := c.blobStorage.Open(item.ID)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return 0, ErrAppInternal
+	}
+	defer f.Close()
+
+	stream, err := c.grpcClient.UploadVaultItem(ctx)
+	if err != nil {
+		return 0, c.convertSendVaultItemError(op, err)
+	}
+
+	err = stream.Send(&pb.UploadVaultItemRequest{
+		Data: &pb.UploadVaultItemRequest_Item{
+			Item: &pb.VaultItem{
+				Id:              item.ID,
+				Name:            item.Name,
+				Itype:           pb.IType(item.Type),
+				ServerUpdatedAt: item.ServerUpdatedAt,
+			},
+		},
+	})
+
+	// send the encrypted value by the same chunks it was encrypted
+	buf := make([]byte, chacha20poly1305.GetEncryptedChunkSize())
+	for err == nil {
+		var n int
+		n, err = io.ReadFull(f, buf)
+		if n > 0 {
+			if err := stream.Send(&pb.UploadVaultItemRequest{
+				Data: &pb.UploadVaultItemRequest_Chunk{
+					Chunk: buf[:n],
+				},
+			}); err != nil {
+				break
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return 0, ErrAppInternal
+		}
+	}
+
+	// if sending failed, the server status is returned here
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, c.convertSendVaultItemError(op, err)
+	}
+
+	return resp.ServerUpdatedAt, nil
+}
+
+// downloadLargeVaultItem downloads the encrypted value of the large binary item from server
+// and saves it to the local file.
+func (c *Client) downloadLargeVaultItem(ctx context.Context, id string) error {
+	const op = "download large vault item from server"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	stream, err := c.grpcClient.DownloadVaultItem(ctx, &pb.DownloadVaultItemRequest{
+		Id: id,
+	})
+	if err != nil {
+		return c.convertDownloadVaultItemError(ctx, op, err)
+	}
+
+	// the first message contains the item itself
+	resp, err := stream.Recv()
+	if err != nil {
+		return c.convertDownloadVaultItemError(ctx, op, err)
+	}
+	if resp.GetItem() == nil {
+		c.logger.Debug(op + ": first message does not contain item")
+		return ErrServerInternal
+	}
+
+	w, err := c.blobStorage.Create(id)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	err = func() error {
+		defer w.Close()
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return c.convertDownloadVaultItemError(ctx, op, err)
+			}
+			if _, err := w.Write(resp.GetChunk()); err != nil {
+				c.logger.Debug(op, sl.Error(err))
+				return ErrAppInternal
+			}
+		}
+	}()
+	if err != nil {
+		_ = c.blobStorage.Delete(id)
+		return err
+	}
+
+	return nil
 }
 
 func (c *Client) sendVaultItem(ctx context.Context, item vault.Item) (int64, error) {
@@ -181,30 +531,61 @@ func (c *Client) sendVaultItem(ctx context.Context, item vault.Item) (int64, err
 		},
 	})
 	if err != nil {
-		switch {
-		case errors.Is(err, pb.ErrEmptyAuthData),
-			errors.Is(err, pb.ErrEmptyAuthData),
-			errors.Is(err, pb.ErrInvalidTokenFormat),
-			errors.Is(err, pb.ErrUserNeedAuthentication):
-			return 0, ErrUserNeedAuthentication
-		case errors.Is(err, pb.ErrVaultItemConflictVersion):
-			return 0, ErrConflictVersion
-		default:
-			c.logger.Debug(op, err)
-			if status.Code(err) == codes.Unavailable {
-				return 0, ErrServerUnavailable
-			}
-			return 0, ErrServerInternal
-		}
+		return 0, c.convertSendVaultItemError(op, err)
 	}
 
 	return resp.ServerUpdatedAt, nil
 }
 
+// convertSendVaultItemError converts server error received on sending vault item to client error.
+func (c *Client) convertSendVaultItemError(op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrVaultItemConflictVersion):
+		return ErrConflictVersion
+	case errors.Is(err, pb.ErrVaultItemValueTooBig):
+		return ErrVaultItemValueTooBig
+	case errors.Is(err, pb.ErrQuotaExceeded):
+		return ErrQuotaExceeded
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
+
+// convertDownloadVaultItemError converts server error received on downloading vault item to client error.
+func (c *Client) convertDownloadVaultItemError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		c.logger.Debug(op, sl.Error(err))
+		_ = c.clearToken(ctx)
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrVaultItemNotExists):
+		return ErrVaultItemNotExists
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
+
 func (c *Client) newContextWithAuthData(ctx context.Context) (context.Context, error) {
 	if !c.HasToken() {
 		return ctx, pb.ErrEmptyAuthData
 	}
+	if err := c.refreshTokenIfExpired(ctx); err != nil {
+		return ctx, err
+	}
 	md := metadata.New(map[string]string{"token": c.credentials.Token})
 	return metadata.NewOutgoingContext(ctx, md), nil
 }
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// EnrollTOTP starts enrollment of the two-factor authentication. It returns the secret
+// to add to the authenticator app (as is or by the key URI), the second factor is enabled
+// only after the enrollment is confirmed by ConfirmTOTP.
+func (c *Client) EnrollTOTP(ctx context.Context) (string, string, error) {
+	const op = "enroll totp"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return "", "", ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+	if err != nil {
+		return "", "", c.convertTOTPError(ctx, op, err)
+	}
+
+	return resp.Secret, resp.Uri, nil
+}
+
+// ConfirmTOTP confirms the enrollment by the code of the authenticator app and enables
+// the two-factor authentication. It returns single-use recovery codes: they must be shown
+// to the user once, the server keeps only their hashes.
+func (c *Client) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	const op = "confirm totp"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return nil, ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{
+		Code: code,
+	})
+	if err != nil {
+		return nil, c.convertTOTPError(ctx, op, err)
+	}
+
+	return resp.RecoveryCodes, nil
+}
+
+// convertTOTPError converts server error received on two-factor authentication enrollment to client error.
+func (c *Client) convertTOTPError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(err, pb.ErrEmptyAuthData),
+		errors.Is(err, pb.ErrInvalidTokenFormat),
+		errors.Is(err, pb.ErrUserNeedAuthentication):
+		c.logger.Debug(op, sl.Error(err))
+		_ = c.clearToken(ctx)
+		return ErrUserNeedAuthentication
+	case errors.Is(err, pb.ErrTOTPAlreadyEnabled):
+		return ErrTOTPAlreadyEnabled
+	case errors.Is(err, pb.ErrInvalidTOTPCode),
+		errors.Is(err, pb.ErrTOTPNotEnrolled):
+		return ErrInvalidTOTPCode
+	default:
+		c.logger.Debug(op, sl.Error(err))
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return ErrServerInternal
+	}
+}
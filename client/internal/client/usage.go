@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/client/internal/model"
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// GetAccountUsage returns the storage usage of the user vault on the server and the user quota.
+func (c *Client) GetAccountUsage(ctx context.Context) (model.Usage, error) {
+	const op = "get account usage"
+
+	ctx, err := c.newContextWithAuthData(ctx)
+	if err != nil {
+		return model.Usage{}, ErrUserNeedAuthentication
+	}
+
+	resp, err := c.grpcClient.GetAccountUsage(ctx, &pb.GetAccountUsageRequest{})
+	if err != nil {
+		switch {
+		case errors.Is(err, pb.ErrEmptyAuthData),
+			errors.Is(err, pb.ErrInvalidTokenFormat),
+			errors.Is(err, pb.ErrUserNeedAuthentication):
+			c.logger.Debug(op, sl.Error(err))
+			_ = c.clearToken(ctx)
+			return model.Usage{}, ErrUserNeedAuthentication
+		default:
+			c.logger.Debug(op, sl.Error(err))
+			if status.Code(err) == codes.Unavailable {
+				return model.Usage{}, ErrServerUnavailable
+			}
+			return model.Usage{}, ErrServerInternal
+		}
+	}
+
+	return model.Usage{
+		Items:    resp.Items,
+		Size:     resp.Size,
+		MaxItems: resp.MaxItems,
+		MaxSize:  resp.MaxSize,
+	}, nil
+}
@@ -10,58 +10,101 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/Karzoug/goph_keeper/client/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/pkg/crypto"
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
 )
 
 const MinPasswordLength = 8
 
 // Register registers a new user on the server with the gieven email and password.
 // Method returns an error if the email or password is not valid.
+// On success it returns the recovery key: it must be shown to the user once,
+// it is the only way to reset the lost password without losing the vault.
 //
 // Warning(!): method wipes the given password slice to prevent long-term storage in memory.
-func (c *Client) Register(ctx context.Context, email string, password []byte) error {
+func (c *Client) Register(ctx context.Context, email string, password []byte) (string, error) {
 	const op = "register user"
 
 	defer crypto.Wipe(password) // prevent long-term storage of the password in memory
 
 	if !isValidEmail(email) {
-		return ErrInvalidEmail
+		return "", ErrInvalidEmail
 	}
 
 	if utf8.RuneCount(password) < MinPasswordLength {
-		return ErrPasswordTooShort
+		return "", ErrPasswordTooShort
 	}
 
-	hash, err := auth.NewHash([]byte(email), password)
+	hash, encrKey, err := buildPasswordHashes(ctx, email, password)
 	if err != nil {
 		if errors.Is(err, auth.ErrEmptyPassword) {
-			return ErrPasswordTooShort
+			return "", ErrPasswordTooShort
 		}
-		c.logger.Debug(op, err)
-		return ErrAppInternal
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+
+	// the vault items are encrypted with the random vault key,
+	// the server keeps it only wrapped by the password-derived key
+	vaultKey, err := vault.NewVaultKey()
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+	wrappedVaultKey, err := vault.WrapVaultKey(vaultKey, encrKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+
+	// the vault key is also wrapped by the key derived from the recovery key
+	// as from the password, so it can be unlocked independently
+	recoveryKey, err := auth.NewRecoveryKey()
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+	normalizedRecoveryKey, err := auth.NormalizeRecoveryKey(recoveryKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+	recoveryHash, recoveryEncrKey, err := buildPasswordHashes(ctx, email, normalizedRecoveryKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
+	}
+	recoveryWrappedVaultKey, err := vault.WrapVaultKey(vaultKey, recoveryEncrKey)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return "", ErrAppInternal
 	}
 
 	_, err = c.grpcClient.Register(ctx, &pb.RegisterRequest{
-		Email: email,
-		Hash:  hash,
+		Email:                   email,
+		Hash:                    hash,
+		WrappedVaultKey:         wrappedVaultKey,
+		RecoveryHash:            recoveryHash,
+		RecoveryWrappedVaultKey: recoveryWrappedVaultKey,
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, pb.ErrInvalidEmailFormat):
-			return ErrInvalidEmail
+			return "", ErrInvalidEmail
 		case errors.Is(err, pb.ErrUserAlreadyExists):
-			return ErrUserAlreadyExists
+			return "", ErrUserAlreadyExists
 		default:
 			c.logger.Debug(op, err)
 			if status.Code(err) == codes.Unavailable {
-				return ErrServerUnavailable
+				return "", ErrServerUnavailable
 			}
-			return ErrServerInternal
+			return "", ErrServerInternal
 		}
 	}
 
-	return nil
+	return recoveryKey, nil
 }
 
 // Login builds local credentials. Then connects to the server:
@@ -75,10 +118,16 @@ func (c *Client) Register(ctx context.Context, email string, password []byte) er
 //
 // 3. case of unverified mail: returns ErrUserEmailNotVerified;
 //
-// 4. on success: saves the data and the received token,
+// 4. case of enabled second factor: returns ErrTOTPRequired if totpCode is empty
+// or ErrInvalidTOTPCode if it is not valid, totpCode is the code of the authenticator app
+// or a recovery code;
+//
+// 5. case of too many failed attempts: returns ErrTooManyAttempts, the user has to wait;
+//
+// 6. on success: saves the data and the received token,
 // if local vault owner email is not equal to the given email,
 // then the local vault will be cleared.
-func (c *Client) Login(ctx context.Context, email string, password []byte) error {
+func (c *Client) Login(ctx context.Context, email string, password []byte, totpCode string) error {
 	const op = "login user"
 
 	if !isValidEmail(email) {
@@ -95,9 +144,10 @@ func (c *Client) Login(ctx context.Context, email string, password []byte) error
 		return ErrAppInternal
 	}
 
-	resp, err := c.grpcClient.Login(ctx, &pb.LoginRequest{
-		Email: email,
-		Hash:  []byte(hash),
+	resp, err := c.grpcClient.Login(c.newContextWithDeviceData(ctx), &pb.LoginRequest{
+		Email:    email,
+		Hash:     []byte(hash),
+		TotpCode: totpCode,
 	})
 	if err != nil {
 		switch {
@@ -113,6 +163,16 @@ func (c *Client) Login(ctx context.Context, email string, password []byte) error
 		case errors.Is(err, pb.ErrUserNotExists):
 			_ = c.clearCredentials(ctx)
 			return ErrUserNotExists
+		case errors.Is(err, pb.ErrTOTPRequired):
+			return ErrTOTPRequired
+		case errors.Is(err, pb.ErrInvalidTOTPCode):
+			return ErrInvalidTOTPCode
+		case errors.Is(err, pb.ErrTooManyAttempts):
+			return ErrTooManyAttempts
+		case errors.Is(err, pb.ErrUserDisabled):
+			return ErrUserDisabled
+		case errors.Is(err, pb.ErrClientCertificateMismatch):
+			return ErrClientCertificateMismatch
 		default:
 			// problems with grpc,
 			// but if this is the owner of the vault, then they can try to work offline
@@ -120,6 +180,8 @@ func (c *Client) Login(ctx context.Context, email string, password []byte) error
 				if errors.Is(err, ErrUserNeedAuthentication) {
 					c.logger.Debug(op, err)
 					return ErrUserNeedAuthentication
+				} else if errors.Is(err, ErrUserInvalidPassword) {
+					return ErrUserInvalidPassword
 				} else {
 					c.logger.Error(op, err)
 				}
@@ -137,7 +199,11 @@ func (c *Client) Login(ctx context.Context, email string, password []byte) error
 		c.logger.Error(op, err)
 		return ErrAppInternal
 	}
-	if err := c.setToken(ctx, resp.Token); err != nil {
+	if err := c.setVaultKey(ctx, resp.WrappedVaultKey, encrKey); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	if err := c.setToken(ctx, resp.Token, resp.RefreshToken, resp.TokenLifetime); err != nil {
 		c.logger.Error(op, err)
 		return ErrAppInternal
 	}
@@ -154,7 +220,7 @@ func (c *Client) VerifyEmail(ctx context.Context, code string) error {
 		return ErrUserNeedAuthentication
 	}
 
-	resp, err := c.grpcClient.Login(ctx, &pb.LoginRequest{
+	resp, err := c.grpcClient.Login(c.newContextWithDeviceData(ctx), &pb.LoginRequest{
 		Email:     c.credentials.Email,
 		Hash:      c.credentials.AuthHash,
 		EmailCode: code,
@@ -169,6 +235,10 @@ func (c *Client) VerifyEmail(ctx context.Context, code string) error {
 		case errors.Is(err, pb.ErrUserNotExists):
 			_ = c.clearCredentials(ctx)
 			return ErrUserNotExists
+		case errors.Is(err, pb.ErrTooManyAttempts):
+			return ErrTooManyAttempts
+		case errors.Is(err, pb.ErrUserDisabled):
+			return ErrUserDisabled
 		default:
 			c.logger.Debug(op, err)
 			if status.Code(err) == codes.Unavailable {
@@ -178,16 +248,75 @@ func (c *Client) VerifyEmail(ctx context.Context, code string) error {
 		}
 	}
 
-	if err := c.setToken(ctx, resp.Token); err != nil {
+	// the password-derived key is saved on login until the email is verified
+	if err := c.setVaultKey(ctx, resp.WrappedVaultKey, c.credentials.EncrKey); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	if err := c.setToken(ctx, resp.Token, resp.RefreshToken, resp.TokenLifetime); err != nil {
 		c.logger.Error(op, err)
 		return ErrAppInternal
 	}
 	return nil
 }
 
+// ResendVerificationCode asks the server to send a new email verification code,
+// the previous code is no longer valid.
+func (c *Client) ResendVerificationCode(ctx context.Context) error {
+	const op = "resend verification code"
+
+	if len(c.credentials.Email) == 0 ||
+		c.credentials.AuthHash == nil {
+		return ErrUserNeedAuthentication
+	}
+
+	_, err := c.grpcClient.ResendVerificationCode(ctx, &pb.ResendVerificationCodeRequest{
+		Email: c.credentials.Email,
+		Hash:  c.credentials.AuthHash,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pb.ErrUserInvalidHash):
+			_ = c.clearCredentials(ctx)
+			return ErrUserInvalidPassword
+		case errors.Is(err, pb.ErrUserNotExists):
+			_ = c.clearCredentials(ctx)
+			return ErrUserNotExists
+		case errors.Is(err, pb.ErrUserEmailAlreadyVerified):
+			return ErrUserEmailAlreadyVerified
+		case errors.Is(err, pb.ErrTooManyAttempts):
+			return ErrTooManyAttempts
+		case errors.Is(err, pb.ErrVerificationCodeResent):
+			return ErrVerificationCodeResent
+		case errors.Is(err, pb.ErrUserDisabled):
+			return ErrUserDisabled
+		default:
+			c.logger.Debug(op, sl.Error(err))
+			if status.Code(err) == codes.Unavailable {
+				return ErrServerUnavailable
+			}
+			return ErrServerInternal
+		}
+	}
+
+	return nil
+}
+
+// Logout revokes the token on the server (if it is possible) and clears local credentials.
 func (c *Client) Logout(ctx context.Context) error {
 	const op = "logout user"
 
+	if c.HasToken() {
+		if ctx, err := c.newContextWithAuthData(ctx); err == nil {
+			// local credentials are cleared anyway: the token will expire soon
+			if _, err := c.grpcClient.Logout(ctx, &pb.LogoutRequest{
+				RefreshToken: c.credentials.RefreshToken,
+			}); err != nil {
+				c.logger.Debug(op, sl.Error(err))
+			}
+		}
+	}
+
 	if err := c.clearCredentials(ctx); err != nil {
 		c.logger.Error(op, err)
 		return ErrAppInternal
@@ -196,6 +325,72 @@ func (c *Client) Logout(ctx context.Context) error {
 	return nil
 }
 
+// DeleteAccount deletes the user account with all the vault items on the server,
+// then clears the local vault and credentials.
+//
+// Warning(!): method wipes the given password slice to prevent long-term storage in memory.
+func (c *Client) DeleteAccount(ctx context.Context, password []byte) error {
+	const op = "delete account"
+
+	defer crypto.Wipe(password) // prevent long-term storage of the password in memory
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+
+	hash, _, err := buildPasswordHashes(ctx, c.credentials.Email, password)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	ctx, err = c.newContextWithAuthData(ctx)
+	if err != nil {
+		return ErrUserNeedAuthentication
+	}
+
+	// no synchronization is allowed while the local vault is cleared
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	_, err = c.grpcClient.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+		Hash: hash,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pb.ErrEmptyAuthData),
+			errors.Is(err, pb.ErrInvalidTokenFormat),
+			errors.Is(err, pb.ErrUserNeedAuthentication),
+			errors.Is(err, pb.ErrUserNotExists):
+			return ErrUserNeedAuthentication
+		case errors.Is(err, pb.ErrUserInvalidHash):
+			return ErrUserInvalidPassword
+		default:
+			c.logger.Debug(op, sl.Error(err))
+			if status.Code(err) == codes.Unavailable {
+				return ErrServerUnavailable
+			}
+			return ErrServerInternal
+		}
+	}
+
+	if err := c.clearVault(ctx); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	// the vault has no owner anymore: offline login to the deleted account is not possible
+	if err := c.storage.SetOwner(ctx, ""); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	if err := c.clearCredentials(ctx); err != nil {
+		c.logger.Error(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	return nil
+}
+
 func isValidEmail(email string) bool {
 	if len(email) == 0 {
 		return false
@@ -3,12 +3,15 @@ package client
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/rs/xid"
 
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/repository/storage"
+	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
 )
 
 func (c *Client) ListVaultItemsIDName(ctx context.Context) ([]vault.IDName, error) {
@@ -51,6 +54,10 @@ func (c *Client) DeleteVaultItem(ctx context.Context, id string) error {
 			c.logger.Debug(op, err)
 			return ErrAppInternal
 		}
+		if err := c.blobStorage.Delete(id); err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
 		return nil
 	}
 
@@ -63,6 +70,17 @@ func (c *Client) DeleteVaultItem(ctx context.Context, id string) error {
 		c.logger.Debug(op, err)
 		return ErrAppInternal
 	}
+	if item.Type == cvault.BinaryLarge {
+		if err := c.blobStorage.Delete(id); err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+	}
+
+	// the server notifies about this change too,
+	// so hold the lock until the new server time is saved to not take it for a foreign change
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
 
 	t, err := c.sendVaultItem(ctx, item)
 	if err != nil {
@@ -106,6 +124,10 @@ func (c *Client) EncryptAndSetVaultItem(ctx context.Context, item vault.Item, va
 		return nil
 	}
 
+	// hold the lock until the new server time is saved, see DeleteVaultItem
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
 	t, err := c.sendVaultItem(ctx, item)
 	if err != nil {
 		c.logger.Debug(op, err)
@@ -134,6 +156,12 @@ func (c *Client) DecryptAndGetVaultItem(ctx context.Context, id string) (vault.I
 		return vault.Item{}, nil, ErrAppInternal
 	}
 
+	// the value of the large binary item is stored separately,
+	// see DecryptAndSaveLargeVaultItem
+	if item.Type == cvault.BinaryLarge {
+		return item, vault.BinaryLarge{}, nil
+	}
+
 	value, err := item.DecryptAnGetValue(c.credentials.EncrKey)
 	if err != nil {
 		c.logger.Debug(op, err)
@@ -143,3 +171,97 @@ func (c *Client) DecryptAndGetVaultItem(ctx context.Context, id string) (vault.I
 
 	return item, value, nil
 }
+
+// EncryptAndSetLargeVaultItem encrypts the value of the large binary item read from r,
+// saves it locally and then tries to upload it to the server.
+func (c *Client) EncryptAndSetLargeVaultItem(ctx context.Context, item vault.Item, r io.Reader) error {
+	const op = "client: encrypt and set large vault item"
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+
+	if len(item.ID) == 0 {
+		item.ID = xid.New().String()
+	}
+	item.Type = cvault.BinaryLarge
+	item.Value = nil
+	item.ClientUpdatedAt = time.Now().UnixMicro()
+
+	w, err := c.blobStorage.Create(item.ID)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+	err = vault.EncryptLargeValue(r, w, c.credentials.EncrKey)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = c.blobStorage.Delete(item.ID)
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	if err := c.storage.SetVaultItem(ctx, item); err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	ctx, err = c.newContextWithAuthData(ctx)
+	if err != nil {
+		return nil
+	}
+
+	// hold the lock until the new server time is saved, see DeleteVaultItem
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	t, err := c.sendLargeVaultItem(ctx, item)
+	if err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return err
+	}
+
+	item.ServerUpdatedAt = t
+	if err := c.storage.SetVaultItem(ctx, item); err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	return nil
+}
+
+// DecryptAndSaveLargeVaultItem decrypts the value of the large binary item and writes it to w.
+// If the value is not stored locally yet, it is downloaded from the server first.
+func (c *Client) DecryptAndSaveLargeVaultItem(ctx context.Context, id string, w io.Writer) error {
+	const op = "client: decrypt and save large vault item"
+
+	if !c.HasLocalCredintials() {
+		return ErrUserNeedAuthentication
+	}
+
+	r, err := c.blobStorage.Open(id)
+	if err != nil {
+		if !errors.Is(err, storage.ErrRecordNotFound) {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+		if err := c.downloadLargeVaultItem(ctx, id); err != nil {
+			return err
+		}
+		r, err = c.blobStorage.Open(id)
+		if err != nil {
+			c.logger.Debug(op, sl.Error(err))
+			return ErrAppInternal
+		}
+	}
+	defer r.Close()
+
+	if err := vault.DecryptLargeValue(r, w, c.credentials.EncrKey); err != nil {
+		c.logger.Debug(op, sl.Error(err))
+		return ErrAppInternal
+	}
+
+	return nil
+}
@@ -12,4 +12,8 @@ type Config struct {
 	Port                   string `yaml:"port" env:"GOPH_KEEPER_PORT" env-default:"8080"`
 	CertFilename           string `yaml:"cert_filename" env:"GOPH_KEEPER_CERT_FILENAME"`
 	RootPath               string `yaml:"root_path" env:"GOPH_KEEPER_ROOT_PATH"`
+	// ClientCertFilename and ClientKeyFilename are the client certificate and key
+	// for servers requiring mutual TLS, the certificate is not sent if they are empty.
+	ClientCertFilename string `yaml:"client_cert_filename" env:"GOPH_KEEPER_CLIENT_CERT_FILENAME"`
+	ClientKeyFilename  string `yaml:"client_key_filename" env:"GOPH_KEEPER_CLIENT_KEY_FILENAME"`
 }
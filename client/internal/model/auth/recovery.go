@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+)
+
+const (
+	// recoveryKeySize is the size of the random recovery key: 160 bits of entropy.
+	recoveryKeySize = 20
+	// recoveryKeyGroupSize is the number of characters in a group of the printed key.
+	recoveryKeyGroupSize = 4
+)
+
+var (
+	ErrInvalidRecoveryKey = errors.New("invalid recovery key")
+
+	recoveryKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// NewRecoveryKey returns a random recovery key to write down by the user,
+// the key is printed in groups of characters separated by dashes.
+// The key is used as a password: auth hash and encryption key are derived from it.
+func NewRecoveryKey() (string, error) {
+	const op = "model: create recovery key"
+
+	key := make([]byte, recoveryKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", e.Wrap(op, err)
+	}
+	encoded := recoveryKeyEncoding.EncodeToString(key)
+
+	var sb strings.Builder
+	for i := 0; i < len(encoded); i += recoveryKeyGroupSize {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(encoded[i:min(i+recoveryKeyGroupSize, len(encoded))])
+	}
+
+	return sb.String(), nil
+}
+
+// NormalizeRecoveryKey returns the recovery key typed by the user in the canonical form
+// (without separators in upper case) or ErrInvalidRecoveryKey if it is not valid.
+func NormalizeRecoveryKey(key string) ([]byte, error) {
+	key = strings.ToUpper(key)
+	key = strings.NewReplacer("-", "", " ", "").Replace(key)
+
+	decoded, err := recoveryKeyEncoding.DecodeString(key)
+	if err != nil || len(decoded) != recoveryKeySize {
+		return nil, ErrInvalidRecoveryKey
+	}
+
+	return []byte(key), nil
+}
@@ -5,7 +5,11 @@ import (
 )
 
 type Credentials struct {
-	Email   string
-	Token   string
-	EncrKey vault.EncryptionKey
+	Email        string
+	Token        string
+	RefreshToken string
+	// TokenExpiresAt is the time (unix seconds) when the token expires,
+	// zero if it is unknown.
+	TokenExpiresAt int64
+	EncrKey        vault.EncryptionKey
 }
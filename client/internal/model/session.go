@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Session is a user login on a device.
+type Session struct {
+	ID            string
+	DeviceName    string
+	ClientVersion string
+	IP            string
+	CreatedAt     time.Time
+	// IsCurrent is true for the session of this client.
+	IsCurrent bool
+}
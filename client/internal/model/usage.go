@@ -0,0 +1,11 @@
+package model
+
+// Usage is the storage usage of the user vault on the server.
+type Usage struct {
+	Items int64
+	// Size is the total size of item values in bytes.
+	Size int64
+	// MaxItems and MaxSize are the user quota, zero means no limit.
+	MaxItems int64
+	MaxSize  int64
+}
@@ -1,16 +1,23 @@
 package vault
 
 import (
+	"bytes"
+	"crypto/rand"
 	"errors"
 
 	"github.com/matthewhartstonge/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
 
+	pchacha20poly1305 "github.com/Karzoug/goph_keeper/client/pkg/crypto/chacha20poly1305"
 	"github.com/Karzoug/goph_keeper/pkg/e"
 )
 
+const saltSize = 16
+
 var (
-	ErrEmptyEmail    = errors.New("empty email")
-	ErrEmptyPassword = errors.New("empty password")
+	ErrEmptyEmail        = errors.New("empty email")
+	ErrEmptyPassword     = errors.New("empty password")
+	ErrInvalidWrappedKey = errors.New("invalid wrapped key")
 )
 
 type EncryptionKey struct {
@@ -50,3 +57,58 @@ func (k *EncryptionKey) UnmarshalBinary(data []byte) error {
 func (k EncryptionKey) MarshalBinary() (data []byte, err error) {
 	return k.Raw.Encode(), nil
 }
+
+// NewVaultKey returns a random key to encrypt the vault items.
+// The key is stored on the server only wrapped by the key derived from the password,
+// so the password change does not require re-encryption of the items.
+func NewVaultKey() (EncryptionKey, error) {
+	const op = "create vault key"
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return EncryptionKey{}, e.Wrap(op, err)
+	}
+
+	// config and salt are not used: the key is not derived,
+	// they are set only to keep the key encodable like the derived one
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return EncryptionKey{}, e.Wrap(op, err)
+	}
+
+	return EncryptionKey{Raw: argon2.Raw{
+		Config: argon2.DefaultConfig(),
+		Salt:   salt,
+		Hash:   key,
+	}}, nil
+}
+
+// WrapVaultKey encrypts the vault key by the encryption key derived from the password.
+func WrapVaultKey(vaultKey, encrKey EncryptionKey) ([]byte, error) {
+	const op = "wrap vault key"
+
+	b := bytes.NewBuffer(nil)
+	if err := pchacha20poly1305.Encrypt(bytes.NewReader(vaultKey.Encode()), b, encrKey.Hash); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnwrapVaultKey decrypts the vault key by the encryption key derived from the password.
+// It returns ErrInvalidWrappedKey if the key is wrapped by another key or corrupted.
+func UnwrapVaultKey(wrapped []byte, encrKey EncryptionKey) (EncryptionKey, error) {
+	const op = "unwrap vault key"
+
+	b := bytes.NewBuffer(nil)
+	if err := pchacha20poly1305.Decrypt(bytes.NewReader(wrapped), b, encrKey.Hash); err != nil {
+		return EncryptionKey{}, e.Wrap(op, ErrInvalidWrappedKey)
+	}
+
+	var vaultKey EncryptionKey
+	if err := vaultKey.UnmarshalBinary(b.Bytes()); err != nil {
+		return EncryptionKey{}, e.Wrap(op, ErrInvalidWrappedKey)
+	}
+
+	return vaultKey, nil
+}
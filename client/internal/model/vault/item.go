@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/gob"
 	"errors"
+	"io"
 
 	"github.com/Karzoug/goph_keeper/client/pkg/crypto/chacha20poly1305"
 	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
@@ -76,3 +77,17 @@ func (item Item) DecryptAnGetValue(encrKey EncryptionKey) (any, error) {
 		return nil, e.Wrap(op, ErrUnknownVaultType)
 	}
 }
+
+// EncryptLargeValue encrypts the value of large binary item read from r and writes it to w.
+func EncryptLargeValue(r io.Reader, w io.Writer, encrKey EncryptionKey) error {
+	const op = "vault: encrypt large value"
+
+	return e.Wrap(op, chacha20poly1305.Encrypt(r, w, encrKey.Hash))
+}
+
+// DecryptLargeValue decrypts the value of large binary item read from r and writes it to w.
+func DecryptLargeValue(r io.Reader, w io.Writer, encrKey EncryptionKey) error {
+	const op = "vault: decrypt large value"
+
+	return e.Wrap(op, chacha20poly1305.Decrypt(r, w, encrKey.Hash))
+}
@@ -0,0 +1,14 @@
+package vault
+
+import "time"
+
+// Revision is a previous version of the vault item kept on the server.
+type Revision struct {
+	Name string
+	// Version identifies the revision to restore it.
+	Version int64
+	// UpdatedAt is the time the version was saved on the server.
+	UpdatedAt time.Time
+	// Value is the decrypted value of the version, e.g. Password or Text.
+	Value any
+}
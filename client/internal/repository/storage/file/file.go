@@ -0,0 +1,94 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	serr "github.com/Karzoug/goph_keeper/client/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+)
+
+const dirname = "blobs"
+
+// storage keeps encrypted values of large vault items as files, one file per item.
+type storage struct {
+	dir string
+}
+
+func New() (*storage, error) {
+	const op = "create file storage"
+
+	if err := os.MkdirAll(dirname, 0o700); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return &storage{
+		dir: dirname,
+	}, nil
+}
+
+// Create creates (or truncates) the file for the vault item value.
+// The caller must close the returned writer.
+func (s *storage) Create(id string) (io.WriteCloser, error) {
+	const op = "file: create"
+
+	f, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return f, nil
+}
+
+// Open opens the file of the vault item value.
+// The caller must close the returned reader.
+func (s *storage) Open(id string) (io.ReadCloser, error) {
+	const op = "file: open"
+
+	f, err := os.Open(s.path(id))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return nil, e.Wrap(op, err)
+	}
+
+	return f, nil
+}
+
+// Delete deletes the file of the vault item value, it does nothing if there is no such file.
+func (s *storage) Delete(id string) error {
+	const op = "file: delete"
+
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// Clear deletes all files.
+func (s *storage) Clear() error {
+	const op = "file: clear"
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil {
+			return e.Wrap(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *storage) path(id string) string {
+	// id is generated by xid, but it's received from server too, so protect from path traversal
+	return filepath.Join(s.dir, filepath.Base(id))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
 
 	"github.com/Karzoug/goph_keeper/client/internal/model"
 	serr "github.com/Karzoug/goph_keeper/client/internal/repository/storage"
@@ -11,9 +12,11 @@ import (
 )
 
 const (
-	emailDBKey   = "CREDS_EMAIL"
-	tokenDBKey   = "CREDS_TOKEN"
-	encrKeyDBKey = "CREDS_ENCRKEY"
+	emailDBKey          = "CREDS_EMAIL"
+	tokenDBKey          = "CREDS_TOKEN"
+	refreshTokenDBKey   = "CREDS_REFRESH_TOKEN"
+	tokenExpiresAtDBKey = "CREDS_TOKEN_EXPIRES_AT"
+	encrKeyDBKey        = "CREDS_ENCRKEY"
 )
 
 func (s *storage) SetCredentials(ctx context.Context, creds model.Credentials) error {
@@ -42,11 +45,23 @@ func (s *storage) SetCredentials(ctx context.Context, creds model.Credentials) e
 		return err
 	}
 	if len(creds.Token) == 0 {
+		// the token is cleared: delete the stored one together with the refresh token
+		for _, key := range []string{tokenDBKey, refreshTokenDBKey, tokenExpiresAtDBKey} {
+			if _, err = tx.ExecContext(ctx, `DELETE FROM app WHERE key = ?;`, key); err != nil {
+				return e.Wrap(op, err)
+			}
+		}
 		return e.Wrap(op, tx.Commit())
 	}
 	if _, err = stmt.ExecContext(ctx, tokenDBKey, creds.Token); err != nil {
 		return err
 	}
+	if _, err = stmt.ExecContext(ctx, refreshTokenDBKey, creds.RefreshToken); err != nil {
+		return err
+	}
+	if _, err = stmt.ExecContext(ctx, tokenExpiresAtDBKey, strconv.FormatInt(creds.TokenExpiresAt, 10)); err != nil {
+		return err
+	}
 	return e.Wrap(op, tx.Commit())
 }
 
@@ -78,6 +93,22 @@ func (s *storage) GetCredentials(ctx context.Context) (model.Credentials, error)
 			return creds, e.Wrap(op, err)
 		}
 	}
+	if err := stmt.QueryRowContext(ctx, refreshTokenDBKey).Scan(&creds.RefreshToken); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return creds, e.Wrap(op, err)
+		}
+	}
+	var tokenExpiresAt string
+	if err := stmt.QueryRowContext(ctx, tokenExpiresAtDBKey).Scan(&tokenExpiresAt); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return creds, e.Wrap(op, err)
+		}
+	}
+	if len(tokenExpiresAt) != 0 {
+		if creds.TokenExpiresAt, err = strconv.ParseInt(tokenExpiresAt, 10, 64); err != nil {
+			return creds, e.Wrap(op, err)
+		}
+	}
 	var encrKeyBytes []byte
 	if err := stmt.QueryRowContext(ctx, encrKeyDBKey).Scan(&encrKeyBytes); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -112,5 +143,11 @@ func (s *storage) DeleteCredentials(ctx context.Context) error {
 	if _, err = stmt.ExecContext(ctx, tokenDBKey); err != nil {
 		return err
 	}
+	if _, err = stmt.ExecContext(ctx, refreshTokenDBKey); err != nil {
+		return err
+	}
+	if _, err = stmt.ExecContext(ctx, tokenExpiresAtDBKey); err != nil {
+		return err
+	}
 	return e.Wrap(op, tx.Commit())
 }
@@ -9,7 +9,10 @@ import (
 	"github.com/Karzoug/goph_keeper/pkg/e"
 )
 
-const ownerDBKey = "OWNER"
+const (
+	ownerDBKey           = "OWNER"
+	wrappedVaultKeyDBKey = "WRAPPED_VAULT_KEY"
+)
 
 func (s *storage) GetOwner(ctx context.Context) (string, error) {
 	const op = "sqlite: get owner"
@@ -46,6 +49,36 @@ func (s *storage) SetOwner(ctx context.Context, email string) error {
 	return nil
 }
 
+// GetWrappedVaultKey returns the vault key wrapped by the password-derived key.
+func (s *storage) GetWrappedVaultKey(ctx context.Context) ([]byte, error) {
+	const op = "sqlite: get wrapped vault key"
+
+	var key []byte
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM app WHERE key = ?;`, wrappedVaultKeyDBKey).Scan(&key)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, e.Wrap(op, err)
+		}
+		return nil, serr.ErrRecordNotFound
+	}
+	return key, nil
+}
+
+// SetWrappedVaultKey saves the vault key wrapped by the password-derived key.
+func (s *storage) SetWrappedVaultKey(ctx context.Context, key []byte) error {
+	const op = "sqlite: set wrapped vault key"
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO app(key,value) VALUES(?, ?) 
+	ON CONFLICT(key) 
+	DO UPDATE SET value = excluded.value;`, wrappedVaultKeyDBKey, key)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// ClearVault deletes all vault items and the wrapped vault key.
 func (s *storage) ClearVault(ctx context.Context) error {
 	const op = "sqlite: clear vault"
 
@@ -53,6 +86,10 @@ func (s *storage) ClearVault(ctx context.Context) error {
 	if err != nil {
 		return e.Wrap(op, err)
 	}
+	_, err = s.db.ExecContext(ctx, `DELETE FROM app WHERE key = ?;`, wrappedVaultKeyDBKey)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
 
 	return nil
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/Karzoug/goph_keeper/client/internal/view/common"
 )
 
+const totpCodeLabel = "2FA code"
+
 type View struct {
 	Frame *tview.Frame
 	form  *tview.Form
@@ -22,6 +24,7 @@ type View struct {
 
 	email    string
 	password string
+	totpCode string
 }
 
 func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
@@ -55,6 +58,13 @@ func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
 	form.AddButton("Register", func() {
 		go v.registerCmd()
 	})
+	form.AddButton("Recover", func() {
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.RecoverAccount,
+			}
+		}()
+	})
 	v.form = form
 	v.Frame.SetPrimitive(form)
 
@@ -65,8 +75,20 @@ func (v *View) loginCmd() {
 	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
 	defer cancel()
 
-	err := v.client.Login(ctx, v.email, []byte(v.password))
+	err := v.client.Login(ctx, v.email, []byte(v.password), v.totpCode)
 	if err != nil {
+		if errors.Is(err, client.ErrTOTPRequired) {
+			// second factor is enabled: ask for the code and let the user press login again
+			v.appUpdateFn(func() {
+				if v.form != nil && v.form.GetFormItemByLabel(totpCodeLabel) == nil {
+					v.form.AddInputField(totpCodeLabel, "", 35, nil, func(code string) {
+						v.totpCode = code
+					})
+				}
+			})
+			v.msgCh <- common.NewMsg("Enter the code from the authenticator app or a recovery code")
+			return
+		}
 		if errors.Is(err, client.ErrUserEmailNotVerified) {
 			// clear before go to list items
 			v.email = ""
@@ -87,6 +109,7 @@ func (v *View) loginCmd() {
 	// clear before go to list items
 	v.email = ""
 	v.password = ""
+	v.totpCode = ""
 	v.appUpdateFn(func() {
 		v.Frame.SetPrimitive(nil)
 		v.form = nil
@@ -102,7 +125,7 @@ func (v *View) registerCmd() {
 	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
 	defer cancel()
 
-	err := v.client.Register(ctx, v.email, []byte(v.password))
+	recoveryKey, err := v.client.Register(ctx, v.email, []byte(v.password))
 	if err != nil {
 		v.msgCh <- common.NewErrMsg(err)
 		return
@@ -110,8 +133,17 @@ func (v *View) registerCmd() {
 
 	v.email = ""
 	v.password = ""
+	// the recovery key is not stored anywhere, so it is shown only once
 	v.appUpdateFn(func() {
-		v.Init()
+		v.form = nil
+		modal := tview.NewModal().
+			SetText("Write down your recovery key, it is shown only once:\n\n" + recoveryKey +
+				"\n\nIt is the only way to reset the lost password without losing the vault.").
+			AddButtons([]string{"I have written it down"}).
+			SetDoneFunc(func(int, string) {
+				v.Init()
+			})
+		v.Frame.SetPrimitive(modal)
 	})
 
 	v.msgCh <- common.NewMsg("You are registered!")
@@ -0,0 +1,127 @@
+package cert
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+type View struct {
+	Frame *tview.Frame
+	form  *tview.Form
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	password string
+	// inProgress prevents the repeated request while the previous one is sent
+	inProgress *atomic.Bool
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		appUpdateFn: appUpdateFn,
+		inProgress:  new(atomic.Bool),
+	}
+	frame := tview.NewFrame(nil).
+		AddText("Client certificate: after binding login is allowed only with the certificate from the config", true, tview.AlignLeft, tcell.ColorWhite)
+	v.Frame = frame
+	return v
+}
+
+func (v *View) Update(ctx context.Context) {
+	v.baseContext = ctx
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	form := tview.NewForm()
+	form.SetBorderPadding(1, 1, 0, 1)
+	form.AddPasswordField("Password", "", 35, '*', func(password string) {
+		v.password = password
+	})
+	form.AddButton("Bind", func() {
+		if !v.inProgress.CompareAndSwap(false, true) {
+			return
+		}
+		go v.bindCmd()
+	})
+	form.AddButton("Unbind", func() {
+		if !v.inProgress.CompareAndSwap(false, true) {
+			return
+		}
+		go v.unbindCmd()
+	})
+	v.form = form
+	v.Frame.SetPrimitive(form)
+
+	return v.keyHandler, "esc back • "
+}
+
+func (v *View) bindCmd() {
+	defer v.inProgress.Store(false)
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	fingerprint, err := v.client.BindCertificate(ctx, []byte(v.password))
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.clear()
+
+	v.msgCh <- common.NewMsg("Certificate bound: " + fingerprint)
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.ListItems,
+	}
+}
+
+func (v *View) unbindCmd() {
+	defer v.inProgress.Store(false)
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	if err := v.client.UnbindCertificate(ctx, []byte(v.password)); err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.clear()
+
+	v.msgCh <- common.NewMsg("Certificate unbound!")
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.ListItems,
+	}
+}
+
+func (v *View) clear() {
+	v.password = ""
+	v.appUpdateFn(func() {
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+	})
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.clear()
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ListItems,
+			}
+		}()
+	}
+	return event
+}
@@ -0,0 +1,126 @@
+package changepass
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+// changePasswordTimeout is greater than standard one:
+// all vault items are re-encrypted and sent to server.
+const changePasswordTimeout = 5 * time.Minute
+
+type View struct {
+	Frame *tview.Frame
+	form  *tview.Form
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	oldPassword string
+	newPassword string
+	confirm     string
+	// inProgress prevents the repeated change while the vault is re-encrypted
+	inProgress *atomic.Bool
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		appUpdateFn: appUpdateFn,
+		inProgress:  new(atomic.Bool),
+	}
+	frame := tview.NewFrame(nil).
+		AddText("Change password: all other devices will be logged out", true, tview.AlignLeft, tcell.ColorWhite)
+	v.Frame = frame
+	return v
+}
+
+func (v *View) Update(ctx context.Context) {
+	v.baseContext = ctx
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	form := tview.NewForm()
+	form.SetBorderPadding(1, 1, 0, 1)
+	form.AddPasswordField("Current password", "", 35, '*', func(password string) {
+		v.oldPassword = password
+	})
+	form.AddPasswordField("New password", "", 35, '*', func(password string) {
+		v.newPassword = password
+	})
+	form.AddPasswordField("Confirm new password", "", 35, '*', func(password string) {
+		v.confirm = password
+	})
+	form.AddButton("Change", func() {
+		if v.newPassword != v.confirm {
+			go func() {
+				v.msgCh <- common.NewErrMsg(errors.New("passwords do not match"))
+			}()
+			return
+		}
+		if !v.inProgress.CompareAndSwap(false, true) {
+			return
+		}
+		go v.cmd()
+	})
+	v.form = form
+	v.Frame.SetPrimitive(form)
+
+	return v.keyHandler, "esc back • "
+}
+
+func (v *View) cmd() {
+	defer v.inProgress.Store(false)
+
+	ctx, cancel := context.WithTimeout(v.baseContext, changePasswordTimeout)
+	defer cancel()
+
+	v.msgCh <- common.NewMsg("Re-encrypting the vault...")
+
+	err := v.client.ChangePassword(ctx, []byte(v.oldPassword), []byte(v.newPassword))
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.clear()
+
+	v.msgCh <- common.NewMsg("Password changed!")
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.ListItems,
+	}
+}
+
+func (v *View) clear() {
+	v.oldPassword = ""
+	v.newPassword = ""
+	v.confirm = ""
+	v.appUpdateFn(func() {
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+	})
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.clear()
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ListItems,
+			}
+		}()
+	}
+	return event
+}
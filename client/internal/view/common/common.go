@@ -64,6 +64,13 @@ const (
 	Card              ViewType = "Card"
 	Text              ViewType = "Text"
 	Binary            ViewType = "Binary"
+	Sessions          ViewType = "Sessions"
+	ChangePassword    ViewType = "ChangePassword"
+	DeleteAccount     ViewType = "DeleteAccount"
+	RecoverAccount    ViewType = "RecoverAccount"
+	TOTP              ViewType = "TOTP"
+	Certificate       ViewType = "Certificate"
+	ItemHistory       ViewType = "ItemHistory"
 )
 
 const StandartTimeout = 3 * time.Second
@@ -0,0 +1,102 @@
+package deleteacc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+type View struct {
+	Frame *tview.Frame
+	form  *tview.Form
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	password string
+	// inProgress prevents the repeated deletion while the request is sent
+	inProgress *atomic.Bool
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		appUpdateFn: appUpdateFn,
+		inProgress:  new(atomic.Bool),
+	}
+	frame := tview.NewFrame(nil).
+		AddText("Delete account: all vault items will be deleted on the server and this device", true, tview.AlignLeft, tcell.ColorRed)
+	v.Frame = frame
+	return v
+}
+
+func (v *View) Update(ctx context.Context) {
+	v.baseContext = ctx
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	form := tview.NewForm()
+	form.SetBorderPadding(1, 1, 0, 1)
+	form.AddPasswordField("Password", "", 35, '*', func(password string) {
+		v.password = password
+	})
+	form.AddButton("Delete", func() {
+		if !v.inProgress.CompareAndSwap(false, true) {
+			return
+		}
+		go v.cmd()
+	})
+	v.form = form
+	v.Frame.SetPrimitive(form)
+
+	return v.keyHandler, "esc back • "
+}
+
+func (v *View) cmd() {
+	defer v.inProgress.Store(false)
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	err := v.client.DeleteAccount(ctx, []byte(v.password))
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.clear()
+
+	v.msgCh <- common.NewMsg("Account deleted!")
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.Auth,
+	}
+}
+
+func (v *View) clear() {
+	v.password = ""
+	v.appUpdateFn(func() {
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+	})
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.clear()
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ListItems,
+			}
+		}()
+	}
+	return event
+}
@@ -49,7 +49,14 @@ func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.App
 		go v.cmd()
 	})
 
-	frame := tview.NewFrame(input)
+	// the code can be lost or expired, so the user can ask for a new one
+	form := tview.NewForm().
+		AddFormItem(input).
+		AddButton("Resend code", func() {
+			go v.resendCmd()
+		})
+
+	frame := tview.NewFrame(form)
 
 	v.Frame = frame
 	v.input = input
@@ -86,6 +93,18 @@ func (v *View) cmd() {
 	}
 }
 
+func (v *View) resendCmd() {
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	if err := v.client.ResendVerificationCode(ctx); err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.msgCh <- common.NewMsg("New code sent, check your mail")
+}
+
 func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() { // nolint:exhaustive
 	case tcell.KeyEsc:
@@ -0,0 +1,142 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+// previewLength is the maximum length of the value preview of the revision.
+const previewLength = 40
+
+type View struct {
+	Frame *tview.Frame
+	list  *tview.List
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	id        string
+	revisions []vault.Revision
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	frame := tview.NewFrame(nil).
+		AddText("Previous versions of the item:", true, tview.AlignLeft, tcell.ColorWhite)
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		Frame:       frame,
+		appUpdateFn: appUpdateFn,
+	}
+	return v
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	list := tview.NewList()
+
+	for i := 0; i < len(v.revisions); i++ {
+		info := fmt.Sprintf("saved: %s, %s",
+			v.revisions[i].UpdatedAt.Format(time.DateTime),
+			preview(v.revisions[i].Value))
+		list = list.AddItem(v.revisions[i].Name, info, 0, nil)
+	}
+	if len(v.revisions) == 0 {
+		list = list.AddItem("no previous versions", "", 0, nil)
+	}
+
+	v.list = list
+	v.Frame.SetPrimitive(list)
+
+	return v.keyHandler, "enter restore • esc back • "
+}
+
+// Update gets the revisions of the item with the given ID from the server.
+func (v *View) Update(ctx context.Context, id string) error {
+	v.baseContext = ctx
+	v.id = id
+	v.revisions = nil
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	revisions, err := v.client.ListItemRevisions(ctx, id)
+	if err != nil {
+		return err
+	}
+	v.revisions = revisions
+	return nil
+}
+
+func (v *View) restore(rev vault.Revision) {
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	if err := v.client.RestoreItemRevision(ctx, v.id, rev.Version); err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.msgCh <- common.NewMsg("Item restored!")
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.ListItems,
+	}
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		id := v.id
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.Item,
+				Value:    id,
+			}
+		}()
+	case tcell.KeyEnter:
+		if len(v.revisions) == 0 {
+			return event
+		}
+		rev := v.revisions[v.list.GetCurrentItem()]
+		modal := tview.NewModal().
+			SetText(fmt.Sprintf("Restore the version saved at %s?", rev.UpdatedAt.Format(time.DateTime))).
+			AddButtons([]string{"Yes", "No"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				if buttonIndex == 0 {
+					go v.restore(rev)
+				}
+				v.Frame.SetPrimitive(v.list)
+			})
+		v.Frame.SetPrimitive(modal)
+	}
+	return event
+}
+
+// preview returns a short description of the revision value to distinguish the versions.
+func preview(value any) string {
+	var s string
+	switch value := value.(type) {
+	case vault.Password:
+		s = "login: " + value.Login
+	case vault.Card:
+		s = "holder: " + value.Holder
+	case vault.Text:
+		s = "text: " + strings.ReplaceAll(value.Text, "\n", " ")
+	case vault.Binary:
+		s = fmt.Sprintf("size: %d bytes", len(value.Value))
+	}
+	if r := []rune(s); len(r) > previewLength {
+		s = string(r[:previewLength]) + "..."
+	}
+	return s
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Karzoug/goph_keeper/client/internal/view/common"
 	"github.com/Karzoug/goph_keeper/client/internal/view/item"
 	"github.com/Karzoug/goph_keeper/client/pkg/filepicker"
+	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
 )
 
 type View struct {
@@ -103,6 +104,10 @@ func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
 	f.SetBorderPadding(1, 1, 0, 1)
 	v.Frame.SetPrimitive(f)
 
+	// large binary items have no previous versions on the server
+	if v.item.ID != "" && v.item.Type == cvault.Binary {
+		return v.keyHandler, "tab next • ctrl+r history • esc back • "
+	}
 	return v.keyHandler, "tab next • esc back • "
 }
 
@@ -113,11 +118,15 @@ func (v *View) Update(ctx context.Context, vitem vault.Item, value any) error {
 	if value == nil {
 		return nil
 	}
-	b, ok := value.(vault.Binary)
-	if !ok {
+	switch b := value.(type) {
+	case vault.Binary:
+		v.value = b
+	case vault.BinaryLarge:
+		// the value is decrypted directly to the file on saving
+		v.value = vault.Binary{Meta: b.Meta}
+	default:
 		return item.ErrWrongItemType
 	}
-	v.value = b
 
 	return nil
 }
@@ -181,6 +190,15 @@ func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
 				ViewType: common.ListItems,
 			}
 		}()
+	case tcell.KeyCtrlR:
+		if v.item.ID == "" || v.item.Type != cvault.Binary {
+			return event
+		}
+		id := v.item.ID
+		v.value = vault.Binary{}
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+		item.ToHistory(v.msgCh, id)
 	}
 
 	return event
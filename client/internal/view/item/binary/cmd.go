@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	vc "github.com/Karzoug/goph_keeper/client/internal/view/common"
@@ -13,23 +14,23 @@ import (
 	"github.com/Karzoug/goph_keeper/pkg/e"
 )
 
-const maxValueSizeInDB = 1024 * 1024 // in bytes
+const (
+	maxValueSizeInDB = 1024 * 1024 // in bytes
+	largeItemTimeout = 10 * time.Minute
+)
 
 func (v *View) createCmd(path string) error {
 	fi, err := os.Stat(path)
 	if err != nil {
 		return e.Wrap("open file problem", err)
 	}
-	if fi.Size() > maxValueSizeInDB {
-		v.item.Type = cvault.BinaryLarge
-		// TODO: implement me
-		return errors.New("not implemented now, sorry")
-	} else {
-		v.item.Type = cvault.Binary
-	}
 	if fi.IsDir() {
 		return errors.New("you choose a directory, not a file")
 	}
+	if fi.Size() > maxValueSizeInDB {
+		return v.createLargeCmd(path)
+	}
+	v.item.Type = cvault.Binary
 
 	value, err := os.ReadFile(path)
 	if err != nil {
@@ -46,6 +47,21 @@ func (v *View) createCmd(path string) error {
 	return nil
 }
 
+func (v *View) createLargeCmd(path string) error {
+	v.item.Type = cvault.BinaryLarge
+
+	f, err := os.Open(path)
+	if err != nil {
+		return e.Wrap("open file problem", err)
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(v.baseContext, largeItemTimeout)
+	defer cancel()
+
+	return v.client.EncryptAndSetLargeVaultItem(ctx, v.item, bufio.NewReader(f))
+}
+
 func (v *View) saveOnDiskCmd(path, filename string) error {
 	fi, err := os.Stat(path)
 	if err != nil {
@@ -65,6 +81,17 @@ func (v *View) saveOnDiskCmd(path, filename string) error {
 	defer f.Close()
 
 	w := bufio.NewWriter(f)
+
+	if v.item.Type == cvault.BinaryLarge {
+		ctx, cancel := context.WithTimeout(v.baseContext, largeItemTimeout)
+		defer cancel()
+
+		if err := v.client.DecryptAndSaveLargeVaultItem(ctx, v.item.ID, w); err != nil {
+			return err
+		}
+		return e.Wrap("write file problem", w.Flush())
+	}
+
 	_, err = w.Write(v.value.Value)
 	if err != nil {
 		return e.Wrap("Write file problem", err)
@@ -76,6 +76,9 @@ func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
 	v.form = form
 	v.Frame.SetPrimitive(form)
 
+	if v.item.ID != "" {
+		return v.keyHandler, "tab next • ctrl+r history • esc back • "
+	}
 	return v.keyHandler, "tab next • esc back • "
 }
 
@@ -149,6 +152,15 @@ func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
 				ViewType: common.ListItems,
 			}
 		}()
+	case tcell.KeyCtrlR:
+		if v.item.ID == "" {
+			return event
+		}
+		id := v.item.ID
+		v.value = vault.Card{}
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+		item.ToHistory(v.msgCh, id)
 	}
 
 	return event
@@ -42,3 +42,14 @@ func Delete(ctx context.Context, c *client.Client, id string) error {
 	}
 	return nil
 }
+
+// ToHistory switches to the history of the item with the given ID,
+// the message is sent asynchronously, so it can be called from the key handlers.
+func ToHistory(msgCh chan<- any, id string) {
+	go func() {
+		msgCh <- common.ToViewMsg{
+			ViewType: common.ItemHistory,
+			Value:    id,
+		}
+	}()
+}
@@ -3,12 +3,14 @@ package list
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
 	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/model"
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/view/common"
 )
@@ -25,11 +27,12 @@ type View struct {
 	appUpdateFn func(func()) *tview.Application
 
 	idNames []vault.IDName
+	// usage is known only after the vault is synced with the server.
+	usage *model.Usage
 }
 
 func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
-	frame := tview.NewFrame(nil).
-		AddText("Your vault:", true, tview.AlignLeft, tcell.ColorWhite)
+	frame := tview.NewFrame(nil)
 	v := View{
 		client:      c,
 		msgCh:       msgCh,
@@ -48,8 +51,13 @@ func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
 
 	v.list = list
 	v.Frame.SetPrimitive(list)
+	v.Frame.Clear().
+		AddText("Your vault:", true, tview.AlignLeft, tcell.ColorWhite)
+	if v.usage != nil {
+		v.Frame.AddText(usageText(*v.usage), false, tview.AlignLeft, tcell.ColorGray)
+	}
 
-	return v.keyHandler, "ctrl+n create • tab next • ctrl+u sync • "
+	return v.keyHandler, "ctrl+n create • tab next • ctrl+u sync • ctrl+o sessions • ctrl+p password • ctrl+t 2fa • ctrl+b certificate • ctrl+d delete account • "
 }
 
 func (v *View) Update(ctx context.Context) error {
@@ -70,12 +78,13 @@ func (v *View) sync() {
 	ctx, cancel := context.WithTimeout(v.baseContext, syncCmdTimeout)
 	defer cancel()
 
-	err := v.client.SyncVaultItems(ctx)
-	if err != nil {
-		if errors.Is(err, client.ErrUserNeedAuthentication) {
+	// the rest of the vault is synced even if the quota is exceeded
+	syncErr := v.client.SyncVaultItems(ctx)
+	if syncErr != nil && !errors.Is(syncErr, client.ErrQuotaExceeded) {
+		if errors.Is(syncErr, client.ErrUserNeedAuthentication) {
 			return
 		}
-		v.msgCh <- common.NewErrMsg(err)
+		v.msgCh <- common.NewErrMsg(syncErr)
 		return
 	}
 
@@ -84,13 +93,48 @@ func (v *View) sync() {
 		return
 	}
 
+	// usage is not critical, so the vault is shown without it on error
+	if usage, err := v.client.GetAccountUsage(ctx); err == nil {
+		v.usage = &usage
+	}
+
 	v.appUpdateFn(func() {
 		v.Init()
 	})
 
+	if syncErr != nil {
+		v.msgCh <- common.NewErrMsg(syncErr)
+		return
+	}
 	v.msgCh <- common.NewMsg("Vault synced!")
 }
 
+// usageText returns the usage of the vault on the server, e.g. "items: 3/100 • size: 1.5 KiB/1.0 MiB".
+func usageText(u model.Usage) string {
+	items := fmt.Sprintf("items: %d", u.Items)
+	if u.MaxItems != 0 {
+		items += fmt.Sprintf("/%d", u.MaxItems)
+	}
+	size := "size: " + formatBytes(u.Size)
+	if u.MaxSize != 0 {
+		size += "/" + formatBytes(u.MaxSize)
+	}
+	return items + " • " + size
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() { // nolint:exhaustive
 	case tcell.KeyCtrlN:
@@ -109,6 +153,36 @@ func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
 		}()
 	case tcell.KeyCtrlU:
 		go v.sync()
+	case tcell.KeyCtrlO:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.Sessions,
+			}
+		}()
+	case tcell.KeyCtrlP:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ChangePassword,
+			}
+		}()
+	case tcell.KeyCtrlT:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.TOTP,
+			}
+		}()
+	case tcell.KeyCtrlB:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.Certificate,
+			}
+		}()
+	case tcell.KeyCtrlD:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.DeleteAccount,
+			}
+		}()
 	}
 	return event
 }
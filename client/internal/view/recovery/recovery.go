@@ -0,0 +1,124 @@
+package recovery
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+type View struct {
+	Frame *tview.Frame
+	form  *tview.Form
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	email       string
+	recoveryKey string
+	newPassword string
+	confirm     string
+	// inProgress prevents the repeated recovery while the keys are derived
+	inProgress *atomic.Bool
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		appUpdateFn: appUpdateFn,
+		inProgress:  new(atomic.Bool),
+	}
+	frame := tview.NewFrame(nil).
+		AddText("Reset the lost password by the recovery key: all devices will be logged out", true, tview.AlignLeft, tcell.ColorWhite)
+	v.Frame = frame
+	return v
+}
+
+func (v *View) Update(ctx context.Context) {
+	v.baseContext = ctx
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	form := tview.NewForm()
+	form.SetBorderPadding(1, 1, 0, 1)
+	form.AddInputField("Email", "", 35, nil, func(email string) {
+		v.email = email
+	})
+	form.AddInputField("Recovery key", "", 50, nil, func(key string) {
+		v.recoveryKey = key
+	})
+	form.AddPasswordField("New password", "", 35, '*', func(password string) {
+		v.newPassword = password
+	})
+	form.AddPasswordField("Confirm new password", "", 35, '*', func(password string) {
+		v.confirm = password
+	})
+	form.AddButton("Reset password", func() {
+		if v.newPassword != v.confirm {
+			go func() {
+				v.msgCh <- common.NewErrMsg(errors.New("passwords do not match"))
+			}()
+			return
+		}
+		if !v.inProgress.CompareAndSwap(false, true) {
+			return
+		}
+		go v.cmd()
+	})
+	v.form = form
+	v.Frame.SetPrimitive(form)
+
+	return v.keyHandler, "esc back • "
+}
+
+func (v *View) cmd() {
+	defer v.inProgress.Store(false)
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	err := v.client.RecoverAccount(ctx, v.email, v.recoveryKey, []byte(v.newPassword))
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.clear()
+
+	v.msgCh <- common.NewMsg("Password reset! Login with the new password")
+	v.msgCh <- common.ToViewMsg{
+		ViewType: common.Auth,
+	}
+}
+
+func (v *View) clear() {
+	v.email = ""
+	v.recoveryKey = ""
+	v.newPassword = ""
+	v.confirm = ""
+	v.appUpdateFn(func() {
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+	})
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.clear()
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.Auth,
+			}
+		}()
+	}
+	return event
+}
@@ -0,0 +1,140 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/model"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+type View struct {
+	Frame *tview.Frame
+	list  *tview.List
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	sessions []model.Session
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	frame := tview.NewFrame(nil).
+		AddText("Your devices logged in to the server:", true, tview.AlignLeft, tcell.ColorWhite)
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		Frame:       frame,
+		appUpdateFn: appUpdateFn,
+	}
+	return v
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	list := tview.NewList()
+
+	for i := 0; i < len(v.sessions); i++ {
+		name := v.sessions[i].DeviceName
+		if len(name) == 0 {
+			name = "unknown device"
+		}
+		if v.sessions[i].IsCurrent {
+			name += " (this device)"
+		}
+		info := fmt.Sprintf("version: %s, ip: %s, logged in: %s",
+			v.sessions[i].ClientVersion,
+			v.sessions[i].IP,
+			v.sessions[i].CreatedAt.Format(time.DateTime))
+		list = list.AddItem(name, info, 0, nil)
+	}
+
+	v.list = list
+	v.Frame.SetPrimitive(list)
+
+	return v.keyHandler, "ctrl+d revoke • ctrl+u update • esc back • "
+}
+
+func (v *View) Update(ctx context.Context) error {
+	v.baseContext = ctx
+
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	sessions, err := v.client.ListSessions(ctx)
+	if err != nil {
+		return err
+	}
+	v.sessions = sessions
+	return nil
+}
+
+func (v *View) update() {
+	if err := v.Update(v.baseContext); err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.appUpdateFn(func() {
+		v.Init()
+	})
+}
+
+func (v *View) revoke(sess model.Session) {
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	// the session of this client is revoked by logout to clear local credentials too
+	if sess.IsCurrent {
+		if err := v.client.Logout(ctx); err != nil {
+			v.msgCh <- common.NewErrMsg(err)
+		}
+		v.msgCh <- common.ToViewMsg{
+			ViewType: common.Auth,
+		}
+		return
+	}
+
+	if err := v.client.RevokeSession(ctx, sess.ID); err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.msgCh <- common.NewMsg("Session revoked!")
+	v.update()
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ListItems,
+			}
+		}()
+	case tcell.KeyCtrlD:
+		if len(v.sessions) == 0 {
+			return event
+		}
+		sess := v.sessions[v.list.GetCurrentItem()]
+		modal := tview.NewModal().
+			SetText(fmt.Sprintf("Revoke session of %s?", sess.DeviceName)).
+			AddButtons([]string{"Yes", "No"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				if buttonIndex == 0 {
+					go v.revoke(sess)
+				}
+				v.Frame.SetPrimitive(v.list)
+			})
+		v.Frame.SetPrimitive(modal)
+	case tcell.KeyCtrlU:
+		go v.update()
+	}
+	return event
+}
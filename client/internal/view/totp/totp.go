@@ -0,0 +1,127 @@
+package totp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/Karzoug/goph_keeper/client/internal/client"
+	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+)
+
+type View struct {
+	Frame *tview.Frame
+	form  *tview.Form
+
+	baseContext context.Context
+	client      *client.Client
+	msgCh       chan<- any
+	appUpdateFn func(func()) *tview.Application
+
+	code string
+}
+
+func New(c *client.Client, msgCh chan<- any, appUpdateFn func(func()) *tview.Application) View {
+	v := View{
+		client:      c,
+		msgCh:       msgCh,
+		appUpdateFn: appUpdateFn,
+	}
+	frame := tview.NewFrame(nil).
+		AddText("Two-factor authentication: add the secret to the authenticator app and enter the code", true, tview.AlignLeft, tcell.ColorWhite)
+	v.Frame = frame
+	return v
+}
+
+func (v *View) Update(ctx context.Context) {
+	v.baseContext = ctx
+}
+
+func (v *View) Init() (common.KeyHandlerFnc, common.Help) {
+	// the secret is generated by server, so the form is built after the enrollment request
+	go v.enrollCmd()
+
+	return v.keyHandler, "esc back • "
+}
+
+func (v *View) enrollCmd() {
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	secret, uri, err := v.client.EnrollTOTP(ctx)
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.appUpdateFn(func() {
+		form := tview.NewForm()
+		form.SetBorderPadding(1, 1, 0, 1)
+		form.AddTextView("Secret", secret, 0, 1, false, false)
+		form.AddTextView("URI", uri, 0, 3, false, false)
+		form.AddInputField("Code", "", 10, tview.InputFieldInteger, func(code string) {
+			v.code = code
+		})
+		form.AddButton("Confirm", func() {
+			go v.confirmCmd()
+		})
+		v.form = form
+		v.Frame.SetPrimitive(form)
+	})
+}
+
+func (v *View) confirmCmd() {
+	ctx, cancel := context.WithTimeout(v.baseContext, common.StandartTimeout)
+	defer cancel()
+
+	recoveryCodes, err := v.client.ConfirmTOTP(ctx, v.code)
+	if err != nil {
+		v.msgCh <- common.NewErrMsg(err)
+		return
+	}
+
+	v.code = ""
+	// server keeps only hashes of the recovery codes, so they are shown only once
+	v.appUpdateFn(func() {
+		v.form = nil
+		modal := tview.NewModal().
+			SetText("Write down your recovery codes, they are shown only once:\n\n" +
+				strings.Join(recoveryCodes, "\n") +
+				"\n\nEach code can be used once instead of the authenticator app code.").
+			AddButtons([]string{"I have written them down"}).
+			SetDoneFunc(func(int, string) {
+				v.Frame.SetPrimitive(nil)
+				go func() {
+					v.msgCh <- common.ToViewMsg{
+						ViewType: common.ListItems,
+					}
+				}()
+			})
+		v.Frame.SetPrimitive(modal)
+	})
+
+	v.msgCh <- common.NewMsg("Two-factor authentication enabled!")
+}
+
+func (v *View) clear() {
+	v.code = ""
+	v.appUpdateFn(func() {
+		v.Frame.SetPrimitive(nil)
+		v.form = nil
+	})
+}
+
+func (v *View) keyHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() { // nolint:exhaustive
+	case tcell.KeyEsc:
+		go func() {
+			v.clear()
+			v.msgCh <- common.ToViewMsg{
+				ViewType: common.ListItems,
+			}
+		}()
+	}
+	return event
+}
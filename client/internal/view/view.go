@@ -12,8 +12,12 @@ import (
 	"github.com/Karzoug/goph_keeper/client/internal/client"
 	"github.com/Karzoug/goph_keeper/client/internal/model/vault"
 	"github.com/Karzoug/goph_keeper/client/internal/view/auth"
+	"github.com/Karzoug/goph_keeper/client/internal/view/cert"
+	"github.com/Karzoug/goph_keeper/client/internal/view/changepass"
 	"github.com/Karzoug/goph_keeper/client/internal/view/common"
+	"github.com/Karzoug/goph_keeper/client/internal/view/deleteacc"
 	"github.com/Karzoug/goph_keeper/client/internal/view/email"
+	"github.com/Karzoug/goph_keeper/client/internal/view/history"
 	"github.com/Karzoug/goph_keeper/client/internal/view/item"
 	"github.com/Karzoug/goph_keeper/client/internal/view/item/binary"
 	"github.com/Karzoug/goph_keeper/client/internal/view/item/card"
@@ -21,6 +25,9 @@ import (
 	"github.com/Karzoug/goph_keeper/client/internal/view/item/password"
 	"github.com/Karzoug/goph_keeper/client/internal/view/item/text"
 	"github.com/Karzoug/goph_keeper/client/internal/view/list"
+	"github.com/Karzoug/goph_keeper/client/internal/view/recovery"
+	"github.com/Karzoug/goph_keeper/client/internal/view/sessions"
+	"github.com/Karzoug/goph_keeper/client/internal/view/totp"
 	cvault "github.com/Karzoug/goph_keeper/common/model/vault"
 )
 
@@ -48,6 +55,13 @@ type View struct {
 		text     text.View
 		card     card.View
 		binary   binary.View
+		sessions sessions.View
+		passwd   changepass.View
+		delacc   deleteacc.View
+		recovery recovery.View
+		totp     totp.View
+		cert     cert.View
+		history  history.View
 	}
 	footer struct {
 		msgText    *tview.TextView
@@ -83,6 +97,13 @@ func New(client *client.Client) (*View, error) {
 	v.subviews.password = password.New(client, v.msgCh, app.QueueUpdateDraw)
 	v.subviews.text = text.New(client, v.msgCh, app.QueueUpdateDraw)
 	v.subviews.card = card.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.sessions = sessions.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.passwd = changepass.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.delacc = deleteacc.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.recovery = recovery.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.totp = totp.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.cert = cert.New(client, v.msgCh, app.QueueUpdateDraw)
+	v.subviews.history = history.New(client, v.msgCh, app.QueueUpdateDraw)
 	var err error
 	v.subviews.binary, err = binary.New(client, v.msgCh, app)
 	if err != nil {
@@ -98,6 +119,13 @@ func New(client *client.Client) (*View, error) {
 	pages.AddPage(common.Text.String(), v.subviews.text.Frame, true, false)
 	pages.AddPage(common.Card.String(), v.subviews.card.Frame, true, false)
 	pages.AddPage(common.Binary.String(), v.subviews.binary.Frame, true, false)
+	pages.AddPage(common.Sessions.String(), v.subviews.sessions.Frame, true, false)
+	pages.AddPage(common.ChangePassword.String(), v.subviews.passwd.Frame, true, false)
+	pages.AddPage(common.DeleteAccount.String(), v.subviews.delacc.Frame, true, false)
+	pages.AddPage(common.RecoverAccount.String(), v.subviews.recovery.Frame, true, false)
+	pages.AddPage(common.TOTP.String(), v.subviews.totp.Frame, true, false)
+	pages.AddPage(common.Certificate.String(), v.subviews.cert.Frame, true, false)
+	pages.AddPage(common.ItemHistory.String(), v.subviews.history.Frame, true, false)
 	pages.SetChangedFunc(v.initSubview)
 
 	// create header to view app info
@@ -153,6 +181,35 @@ func (v *View) handleMsgs() {
 					}
 				case common.Item:
 					v.toItem(msg.Value)
+				case common.Sessions:
+					if err := v.subviews.sessions.Update(v.baseContext); err != nil {
+						err = common.NewErrMsg(err)
+						v.app.QueueUpdateDraw(func() {
+							v.footer.errText.SetText("Error: " + err.Error())
+						})
+						// sessions are not available offline, stay on the vault list
+						v.currentPage = common.ListItems
+					}
+				case common.ItemHistory:
+					id, _ := msg.Value.(string)
+					if err := v.subviews.history.Update(v.baseContext, id); err != nil {
+						err = common.NewErrMsg(err)
+						v.app.QueueUpdateDraw(func() {
+							v.footer.errText.SetText("Error: " + err.Error())
+						})
+						// history is not available offline, go back to the item
+						v.toItem(id)
+					}
+				case common.ChangePassword:
+					v.subviews.passwd.Update(v.baseContext)
+				case common.DeleteAccount:
+					v.subviews.delacc.Update(v.baseContext)
+				case common.RecoverAccount:
+					v.subviews.recovery.Update(v.baseContext)
+				case common.TOTP:
+					v.subviews.totp.Update(v.baseContext)
+				case common.Certificate:
+					v.subviews.cert.Update(v.baseContext)
 				case common.EmailVerification:
 					v.subviews.email.Update(v.baseContext)
 				case common.Auth:
@@ -265,6 +322,27 @@ func (v *View) initSubview() {
 	case common.Binary:
 		kh, hlp = v.subviews.binary.Init()
 		v.app.SetFocus(v.subviews.binary.Frame)
+	case common.Sessions:
+		kh, hlp = v.subviews.sessions.Init()
+		v.app.SetFocus(v.subviews.sessions.Frame)
+	case common.ChangePassword:
+		kh, hlp = v.subviews.passwd.Init()
+		v.app.SetFocus(v.subviews.passwd.Frame)
+	case common.DeleteAccount:
+		kh, hlp = v.subviews.delacc.Init()
+		v.app.SetFocus(v.subviews.delacc.Frame)
+	case common.RecoverAccount:
+		kh, hlp = v.subviews.recovery.Init()
+		v.app.SetFocus(v.subviews.recovery.Frame)
+	case common.TOTP:
+		kh, hlp = v.subviews.totp.Init()
+		v.app.SetFocus(v.subviews.totp.Frame)
+	case common.Certificate:
+		kh, hlp = v.subviews.cert.Init()
+		v.app.SetFocus(v.subviews.cert.Frame)
+	case common.ItemHistory:
+		kh, hlp = v.subviews.history.Init()
+		v.app.SetFocus(v.subviews.history.Frame)
 	}
 
 	v.root.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -349,7 +427,7 @@ func (v *View) toItem(value any) {
 				v.footer.errText.SetText("Error: " + err.Error())
 			})
 		}
-	case cvault.Binary:
+	case cvault.Binary, cvault.BinaryLarge:
 		v.currentPage = common.Binary
 		if err := v.subviews.binary.Update(v.baseContext, vitem, dv); err != nil {
 			err = common.NewErrMsg(err)
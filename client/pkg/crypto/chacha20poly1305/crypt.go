@@ -18,6 +18,11 @@ func GetCapacityForEncryptedValue(len int) int {
 		chacha20poly1305.NonceSizeX + (len % chunkSize) + chacha20poly1305.Overhead
 }
 
+// GetEncryptedChunkSize returns the size of one encrypted chunk: nonce, data and tag.
+func GetEncryptedChunkSize() int {
+	return chacha20poly1305.NonceSizeX + chunkSize + chacha20poly1305.Overhead
+}
+
 func Encrypt(r io.Reader, w io.Writer, encrKey []byte) error {
 	aead, err := chacha20poly1305.NewX(encrKey)
 	if err != nil {
@@ -29,7 +34,8 @@ func Encrypt(r io.Reader, w io.Writer, encrKey []byte) error {
 	adCounterBytes := make([]byte, 4)
 
 	for {
-		n, err := r.Read(buf)
+		// read full chunks: the reader may be a network stream that returns less data than requested
+		n, err := io.ReadFull(r, buf)
 
 		if n > 0 {
 			nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+n+aead.Overhead())
@@ -45,7 +51,7 @@ func Encrypt(r io.Reader, w io.Writer, encrKey []byte) error {
 			}
 			adCounter += 1
 		}
-		if err == io.EOF {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
 			break
 		}
 		if err != nil {
@@ -67,7 +73,7 @@ func Decrypt(r io.Reader, w io.Writer, encrKey []byte) error {
 	adCounterBytes := make([]byte, 4)
 
 	for {
-		n, err := r.Read(buf)
+		n, err := io.ReadFull(r, buf)
 		if n > 0 {
 			encryptedMsg := buf[:n]
 			if len(encryptedMsg) < aead.NonceSize() {
@@ -83,7 +89,7 @@ func Decrypt(r io.Reader, w io.Writer, encrKey []byte) error {
 				return e.Wrap("error writing", err)
 			}
 		}
-		if err == io.EOF {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
 			break
 		}
 		if err != nil {
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,3 +50,22 @@ func TestGetCapacityForEncryptedValue(t *testing.T) {
 	enccap := GetCapacityForEncryptedValue(int(encr.Size()))
 	assert.LessOrEqual(t, encw.Len(), enccap)
 }
+
+func TestEncryptDecryptChunkedStream(t *testing.T) {
+	data := make([]byte, 3*chunkSize+chunkSize/2)
+	key := make([]byte, 32)
+	rand.Read(data)
+	rand.Read(key)
+
+	// readers return less data than requested, like network streams do
+	encw := bytes.NewBuffer(nil)
+	err := Encrypt(iotest.HalfReader(bytes.NewReader(data)), encw, key)
+	require.NoError(t, err)
+	assert.Equal(t, GetCapacityForEncryptedValue(len(data)), encw.Len())
+
+	decw := bytes.NewBuffer(nil)
+	err = Decrypt(iotest.OneByteReader(encw), decw, key)
+	require.NoError(t, err)
+
+	assert.Equal(t, data, decw.Bytes())
+}
@@ -14,11 +14,17 @@ var (
 	ErrUserNotExists = status.Error(codes.NotFound, "user not exists")
 	// ErrUserEmailNotVerified returned on login when the user email is not yet verified.
 	ErrUserEmailNotVerified = status.Error(codes.Unauthenticated, "user email not verified")
+	// ErrUserEmailAlreadyVerified returned on the verification code resending when the user email is already verified.
+	ErrUserEmailAlreadyVerified = status.Error(codes.FailedPrecondition, "user email already verified")
+	// ErrVerificationCodeResent returned on the verification code resending when the last code was sent recently.
+	ErrVerificationCodeResent = status.Error(codes.ResourceExhausted, "verification code resent recently, try again later")
 	// ErrUserInvalidHash returned if the passed authentication hash is not valid i.e. does not match the user.
 	// See also ErrInvalidHashFormat description.
 	ErrUserInvalidHash = status.Error(codes.Unauthenticated, "user hash not valid")
 	// ErrUserNeedAuthentication returned if user token is no longer valid (expired for example).
 	ErrUserNeedAuthentication = status.Error(codes.Unauthenticated, "user need authentication")
+	// ErrSessionNotExists returned if the session to revoke does not exist (or is already expired).
+	ErrSessionNotExists = status.Error(codes.NotFound, "session not exists")
 	// ErrInvalidTokenFormat returned if user send token with invalid format.
 	ErrInvalidTokenFormat = status.Error(codes.InvalidArgument, "user invalid token format")
 	// ErrInvalidEmailFormat returned if format of the passed email is not valid.
@@ -26,6 +32,27 @@ var (
 	// ErrInvalidHashFormat returned if format of the passed authentication hash is not valid.
 	// See also ErrUserInvalidHash description.
 	ErrInvalidHashFormat = status.Error(codes.InvalidArgument, "invalid hash format")
+	// ErrInvalidVaultKeyFormat returned if the passed wrapped vault key is too big
+	// or it is empty while the user already has the vault key.
+	ErrInvalidVaultKeyFormat = status.Error(codes.InvalidArgument, "invalid vault key format")
+	// ErrUserDisabled returned on authentication if the user account is disabled by the administrator.
+	ErrUserDisabled = status.Error(codes.PermissionDenied, "user disabled")
+	// ErrTOTPRequired returned on login if the user has enabled the second factor, but no code is passed.
+	ErrTOTPRequired = status.Error(codes.Unauthenticated, "totp code required")
+	// ErrInvalidTOTPCode returned if the passed code of the second factor is not valid or already used.
+	ErrInvalidTOTPCode = status.Error(codes.Unauthenticated, "totp code not valid")
+	// ErrTOTPAlreadyEnabled returned on enrollment if the second factor is already enabled.
+	ErrTOTPAlreadyEnabled = status.Error(codes.FailedPrecondition, "totp already enabled")
+	// ErrTOTPNotEnrolled returned on confirmation if the enrollment is not started.
+	ErrTOTPNotEnrolled = status.Error(codes.FailedPrecondition, "totp not enrolled")
+	// ErrClientCertificateRequired returned if the client certificate is required but the connection has none.
+	ErrClientCertificateRequired = status.Error(codes.FailedPrecondition, "client certificate required")
+	// ErrClientCertificateMismatch returned on authentication if the user account is bound to another
+	// client certificate.
+	ErrClientCertificateMismatch = status.Error(codes.PermissionDenied, "client certificate mismatch")
+	// ErrTooManyAttempts returned on authentication if the email or the client address is temporarily locked out
+	// after too many failed attempts.
+	ErrTooManyAttempts = status.Error(codes.ResourceExhausted, "too many attempts, try again later")
 	// ErrEmptyAuthData returned if no auth data is passed.
 	ErrEmptyAuthData = status.Error(codes.InvalidArgument, "empty auth data")
 	// ErrVaultItemVersionConflict returned if the client has changed the item and is trying to send it to the server,
@@ -33,4 +60,21 @@ var (
 	ErrVaultItemConflictVersion = status.Error(codes.InvalidArgument, "vault item: conflict version")
 	// ErrVaultItemValueTooBig returned if the client is trying to send large data using an inappropriate method.
 	ErrVaultItemValueTooBig = status.Error(codes.OutOfRange, "vault item: big value")
+	// ErrQuotaExceeded returned if the user vault does not fit the storage quota after the item is set.
+	ErrQuotaExceeded = status.Error(codes.ResourceExhausted, "vault quota exceeded")
+	// ErrInvalidPageToken returned if the passed page token is not valid.
+	ErrInvalidPageToken = status.Error(codes.InvalidArgument, "invalid page token")
+	// ErrFullResyncRequired returned if the client asks for the vault changes since the time older than
+	// the deleted items retention: the deleted items could be purged, so the client must list the whole vault
+	// (since 0) and drop the synchronized local items not listed.
+	ErrFullResyncRequired = status.Error(codes.FailedPrecondition, "full resync required")
+	// ErrEmptyVaultItem returned if the client stream does not start with the vault item.
+	ErrEmptyVaultItem = status.Error(codes.InvalidArgument, "vault item: empty")
+	// ErrVaultItemNotExists returned if the requested vault item does not exist.
+	ErrVaultItemNotExists = status.Error(codes.NotFound, "vault item: not exists")
+	// ErrVaultItemRevisionNotExists returned if the requested revision of the vault item is not kept.
+	ErrVaultItemRevisionNotExists = status.Error(codes.NotFound, "vault item: revision not exists")
+	// ErrVaultItemWrongType returned if the type of the vault item does not match the called method,
+	// e.g. streaming methods are used for an item that is not a large binary.
+	ErrVaultItemWrongType = status.Error(codes.InvalidArgument, "vault item: wrong type")
 )
@@ -19,20 +19,60 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GophKeeperService_Register_FullMethodName       = "/common.grpc.GophKeeperService/Register"
-	GophKeeperService_Login_FullMethodName          = "/common.grpc.GophKeeperService/Login"
-	GophKeeperService_ListVaultItems_FullMethodName = "/common.grpc.GophKeeperService/ListVaultItems"
-	GophKeeperService_SetVaultItem_FullMethodName   = "/common.grpc.GophKeeperService/SetVaultItem"
+	GophKeeperService_Register_FullMethodName               = "/common.grpc.GophKeeperService/Register"
+	GophKeeperService_Login_FullMethodName                  = "/common.grpc.GophKeeperService/Login"
+	GophKeeperService_ResendVerificationCode_FullMethodName = "/common.grpc.GophKeeperService/ResendVerificationCode"
+	GophKeeperService_RefreshToken_FullMethodName           = "/common.grpc.GophKeeperService/RefreshToken"
+	GophKeeperService_Logout_FullMethodName                 = "/common.grpc.GophKeeperService/Logout"
+	GophKeeperService_ChangePassword_FullMethodName         = "/common.grpc.GophKeeperService/ChangePassword"
+	GophKeeperService_EnrollTOTP_FullMethodName             = "/common.grpc.GophKeeperService/EnrollTOTP"
+	GophKeeperService_ConfirmTOTP_FullMethodName            = "/common.grpc.GophKeeperService/ConfirmTOTP"
+	GophKeeperService_RecoverAccount_FullMethodName         = "/common.grpc.GophKeeperService/RecoverAccount"
+	GophKeeperService_DeleteAccount_FullMethodName          = "/common.grpc.GophKeeperService/DeleteAccount"
+	GophKeeperService_BindCertificate_FullMethodName        = "/common.grpc.GophKeeperService/BindCertificate"
+	GophKeeperService_UnbindCertificate_FullMethodName      = "/common.grpc.GophKeeperService/UnbindCertificate"
+	GophKeeperService_GetAccountUsage_FullMethodName        = "/common.grpc.GophKeeperService/GetAccountUsage"
+	GophKeeperService_ListSessions_FullMethodName           = "/common.grpc.GophKeeperService/ListSessions"
+	GophKeeperService_RevokeSession_FullMethodName          = "/common.grpc.GophKeeperService/RevokeSession"
+	GophKeeperService_ListVaultItems_FullMethodName         = "/common.grpc.GophKeeperService/ListVaultItems"
+	GophKeeperService_SetVaultItem_FullMethodName           = "/common.grpc.GophKeeperService/SetVaultItem"
+	GophKeeperService_SetVaultItems_FullMethodName          = "/common.grpc.GophKeeperService/SetVaultItems"
+	GophKeeperService_UploadVaultItem_FullMethodName        = "/common.grpc.GophKeeperService/UploadVaultItem"
+	GophKeeperService_DownloadVaultItem_FullMethodName      = "/common.grpc.GophKeeperService/DownloadVaultItem"
+	GophKeeperService_WatchVaultItems_FullMethodName        = "/common.grpc.GophKeeperService/WatchVaultItems"
+	GophKeeperService_ListItemRevisions_FullMethodName      = "/common.grpc.GophKeeperService/ListItemRevisions"
+	GophKeeperService_RestoreItemRevision_FullMethodName    = "/common.grpc.GophKeeperService/RestoreItemRevision"
 )
 
 // GophKeeperServiceClient is the client API for GophKeeperService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GophKeeperServiceClient interface {
+	// Register, Login, GetAccountUsage, ListVaultItems and SetVaultItem are also available by the REST gateway,
+	// the token is passed by the Authorization header: Bearer <token>.
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	ResendVerificationCode(ctx context.Context, in *ResendVerificationCodeRequest, opts ...grpc.CallOption) (*ResendVerificationCodeResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	ChangePassword(ctx context.Context, opts ...grpc.CallOption) (GophKeeperService_ChangePasswordClient, error)
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error)
+	RecoverAccount(ctx context.Context, in *RecoverAccountRequest, opts ...grpc.CallOption) (*RecoverAccountResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	BindCertificate(ctx context.Context, in *BindCertificateRequest, opts ...grpc.CallOption) (*BindCertificateResponse, error)
+	UnbindCertificate(ctx context.Context, in *UnbindCertificateRequest, opts ...grpc.CallOption) (*UnbindCertificateResponse, error)
+	GetAccountUsage(ctx context.Context, in *GetAccountUsageRequest, opts ...grpc.CallOption) (*GetAccountUsageResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
 	ListVaultItems(ctx context.Context, in *ListVaultItemsRequest, opts ...grpc.CallOption) (*ListVaultItemsResponse, error)
 	SetVaultItem(ctx context.Context, in *SetVaultItemRequest, opts ...grpc.CallOption) (*SetVaultItemResponse, error)
+	SetVaultItems(ctx context.Context, in *SetVaultItemsRequest, opts ...grpc.CallOption) (*SetVaultItemsResponse, error)
+	UploadVaultItem(ctx context.Context, opts ...grpc.CallOption) (GophKeeperService_UploadVaultItemClient, error)
+	DownloadVaultItem(ctx context.Context, in *DownloadVaultItemRequest, opts ...grpc.CallOption) (GophKeeperService_DownloadVaultItemClient, error)
+	WatchVaultItems(ctx context.Context, in *WatchVaultItemsRequest, opts ...grpc.CallOption) (GophKeeperService_WatchVaultItemsClient, error)
+	ListItemRevisions(ctx context.Context, in *ListItemRevisionsRequest, opts ...grpc.CallOption) (*ListItemRevisionsResponse, error)
+	RestoreItemRevision(ctx context.Context, in *RestoreItemRevisionRequest, opts ...grpc.CallOption) (*RestoreItemRevisionResponse, error)
 }
 
 type gophKeeperServiceClient struct {
@@ -61,6 +101,148 @@ func (c *gophKeeperServiceClient) Login(ctx context.Context, in *LoginRequest, o
 	return out, nil
 }
 
+func (c *gophKeeperServiceClient) ResendVerificationCode(ctx context.Context, in *ResendVerificationCodeRequest, opts ...grpc.CallOption) (*ResendVerificationCodeResponse, error) {
+	out := new(ResendVerificationCodeResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_ResendVerificationCode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_RefreshToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_Logout_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) ChangePassword(ctx context.Context, opts ...grpc.CallOption) (GophKeeperService_ChangePasswordClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeperService_ServiceDesc.Streams[0], GophKeeperService_ChangePassword_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperServiceChangePasswordClient{stream}
+	return x, nil
+}
+
+type GophKeeperService_ChangePasswordClient interface {
+	Send(*ChangePasswordRequest) error
+	CloseAndRecv() (*ChangePasswordResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperServiceChangePasswordClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperServiceChangePasswordClient) Send(m *ChangePasswordRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gophKeeperServiceChangePasswordClient) CloseAndRecv() (*ChangePasswordResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ChangePasswordResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperServiceClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error) {
+	out := new(EnrollTOTPResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_EnrollTOTP_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error) {
+	out := new(ConfirmTOTPResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_ConfirmTOTP_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) RecoverAccount(ctx context.Context, in *RecoverAccountRequest, opts ...grpc.CallOption) (*RecoverAccountResponse, error) {
+	out := new(RecoverAccountResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_RecoverAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_DeleteAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) BindCertificate(ctx context.Context, in *BindCertificateRequest, opts ...grpc.CallOption) (*BindCertificateResponse, error) {
+	out := new(BindCertificateResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_BindCertificate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) UnbindCertificate(ctx context.Context, in *UnbindCertificateRequest, opts ...grpc.CallOption) (*UnbindCertificateResponse, error) {
+	out := new(UnbindCertificateResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_UnbindCertificate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) GetAccountUsage(ctx context.Context, in *GetAccountUsageRequest, opts ...grpc.CallOption) (*GetAccountUsageResponse, error) {
+	out := new(GetAccountUsageResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_GetAccountUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_ListSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_RevokeSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperServiceClient) ListVaultItems(ctx context.Context, in *ListVaultItemsRequest, opts ...grpc.CallOption) (*ListVaultItemsResponse, error) {
 	out := new(ListVaultItemsResponse)
 	err := c.cc.Invoke(ctx, GophKeeperService_ListVaultItems_FullMethodName, in, out, opts...)
@@ -79,14 +261,160 @@ func (c *gophKeeperServiceClient) SetVaultItem(ctx context.Context, in *SetVault
 	return out, nil
 }
 
+func (c *gophKeeperServiceClient) SetVaultItems(ctx context.Context, in *SetVaultItemsRequest, opts ...grpc.CallOption) (*SetVaultItemsResponse, error) {
+	out := new(SetVaultItemsResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_SetVaultItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) UploadVaultItem(ctx context.Context, opts ...grpc.CallOption) (GophKeeperService_UploadVaultItemClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeperService_ServiceDesc.Streams[1], GophKeeperService_UploadVaultItem_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperServiceUploadVaultItemClient{stream}
+	return x, nil
+}
+
+type GophKeeperService_UploadVaultItemClient interface {
+	Send(*UploadVaultItemRequest) error
+	CloseAndRecv() (*UploadVaultItemResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperServiceUploadVaultItemClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperServiceUploadVaultItemClient) Send(m *UploadVaultItemRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gophKeeperServiceUploadVaultItemClient) CloseAndRecv() (*UploadVaultItemResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadVaultItemResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperServiceClient) DownloadVaultItem(ctx context.Context, in *DownloadVaultItemRequest, opts ...grpc.CallOption) (GophKeeperService_DownloadVaultItemClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeperService_ServiceDesc.Streams[2], GophKeeperService_DownloadVaultItem_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperServiceDownloadVaultItemClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeperService_DownloadVaultItemClient interface {
+	Recv() (*DownloadVaultItemResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperServiceDownloadVaultItemClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperServiceDownloadVaultItemClient) Recv() (*DownloadVaultItemResponse, error) {
+	m := new(DownloadVaultItemResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperServiceClient) WatchVaultItems(ctx context.Context, in *WatchVaultItemsRequest, opts ...grpc.CallOption) (GophKeeperService_WatchVaultItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeperService_ServiceDesc.Streams[3], GophKeeperService_WatchVaultItems_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperServiceWatchVaultItemsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeperService_WatchVaultItemsClient interface {
+	Recv() (*WatchVaultItemsResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperServiceWatchVaultItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperServiceWatchVaultItemsClient) Recv() (*WatchVaultItemsResponse, error) {
+	m := new(WatchVaultItemsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperServiceClient) ListItemRevisions(ctx context.Context, in *ListItemRevisionsRequest, opts ...grpc.CallOption) (*ListItemRevisionsResponse, error) {
+	out := new(ListItemRevisionsResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_ListItemRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) RestoreItemRevision(ctx context.Context, in *RestoreItemRevisionRequest, opts ...grpc.CallOption) (*RestoreItemRevisionResponse, error) {
+	out := new(RestoreItemRevisionResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_RestoreItemRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GophKeeperServiceServer is the server API for GophKeeperService service.
 // All implementations must embed UnimplementedGophKeeperServiceServer
 // for forward compatibility
 type GophKeeperServiceServer interface {
+	// Register, Login, GetAccountUsage, ListVaultItems and SetVaultItem are also available by the REST gateway,
+	// the token is passed by the Authorization header: Bearer <token>.
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	ResendVerificationCode(context.Context, *ResendVerificationCodeRequest) (*ResendVerificationCodeResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	ChangePassword(GophKeeperService_ChangePasswordServer) error
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	RecoverAccount(context.Context, *RecoverAccountRequest) (*RecoverAccountResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	BindCertificate(context.Context, *BindCertificateRequest) (*BindCertificateResponse, error)
+	UnbindCertificate(context.Context, *UnbindCertificateRequest) (*UnbindCertificateResponse, error)
+	GetAccountUsage(context.Context, *GetAccountUsageRequest) (*GetAccountUsageResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
 	ListVaultItems(context.Context, *ListVaultItemsRequest) (*ListVaultItemsResponse, error)
 	SetVaultItem(context.Context, *SetVaultItemRequest) (*SetVaultItemResponse, error)
+	SetVaultItems(context.Context, *SetVaultItemsRequest) (*SetVaultItemsResponse, error)
+	UploadVaultItem(GophKeeperService_UploadVaultItemServer) error
+	DownloadVaultItem(*DownloadVaultItemRequest, GophKeeperService_DownloadVaultItemServer) error
+	WatchVaultItems(*WatchVaultItemsRequest, GophKeeperService_WatchVaultItemsServer) error
+	ListItemRevisions(context.Context, *ListItemRevisionsRequest) (*ListItemRevisionsResponse, error)
+	RestoreItemRevision(context.Context, *RestoreItemRevisionRequest) (*RestoreItemRevisionResponse, error)
 	mustEmbedUnimplementedGophKeeperServiceServer()
 }
 
@@ -100,12 +428,69 @@ func (UnimplementedGophKeeperServiceServer) Register(context.Context, *RegisterR
 func (UnimplementedGophKeeperServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedGophKeeperServiceServer) ResendVerificationCode(context.Context, *ResendVerificationCodeRequest) (*ResendVerificationCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerificationCode not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) ChangePassword(GophKeeperService_ChangePasswordServer) error {
+	return status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) RecoverAccount(context.Context, *RecoverAccountRequest) (*RecoverAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverAccount not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) BindCertificate(context.Context, *BindCertificateRequest) (*BindCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BindCertificate not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) UnbindCertificate(context.Context, *UnbindCertificateRequest) (*UnbindCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbindCertificate not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) GetAccountUsage(context.Context, *GetAccountUsageRequest) (*GetAccountUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountUsage not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
 func (UnimplementedGophKeeperServiceServer) ListVaultItems(context.Context, *ListVaultItemsRequest) (*ListVaultItemsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVaultItems not implemented")
 }
 func (UnimplementedGophKeeperServiceServer) SetVaultItem(context.Context, *SetVaultItemRequest) (*SetVaultItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetVaultItem not implemented")
 }
+func (UnimplementedGophKeeperServiceServer) SetVaultItems(context.Context, *SetVaultItemsRequest) (*SetVaultItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultItems not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) UploadVaultItem(GophKeeperService_UploadVaultItemServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadVaultItem not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) DownloadVaultItem(*DownloadVaultItemRequest, GophKeeperService_DownloadVaultItemServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadVaultItem not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) WatchVaultItems(*WatchVaultItemsRequest, GophKeeperService_WatchVaultItemsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVaultItems not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) ListItemRevisions(context.Context, *ListItemRevisionsRequest) (*ListItemRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItemRevisions not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) RestoreItemRevision(context.Context, *RestoreItemRevisionRequest) (*RestoreItemRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItemRevision not implemented")
+}
 func (UnimplementedGophKeeperServiceServer) mustEmbedUnimplementedGophKeeperServiceServer() {}
 
 // UnsafeGophKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -155,6 +540,248 @@ func _GophKeeperService_Login_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeperService_ResendVerificationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerificationCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).ResendVerificationCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_ResendVerificationCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).ResendVerificationCode(ctx, req.(*ResendVerificationCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_ChangePassword_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GophKeeperServiceServer).ChangePassword(&gophKeeperServiceChangePasswordServer{stream})
+}
+
+type GophKeeperService_ChangePasswordServer interface {
+	SendAndClose(*ChangePasswordResponse) error
+	Recv() (*ChangePasswordRequest, error)
+	grpc.ServerStream
+}
+
+type gophKeeperServiceChangePasswordServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperServiceChangePasswordServer) SendAndClose(m *ChangePasswordResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gophKeeperServiceChangePasswordServer) Recv() (*ChangePasswordRequest, error) {
+	m := new(ChangePasswordRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GophKeeperService_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_RecoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).RecoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_RecoverAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).RecoverAccount(ctx, req.(*RecoverAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_BindCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).BindCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_BindCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).BindCertificate(ctx, req.(*BindCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_UnbindCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbindCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).UnbindCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_UnbindCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).UnbindCertificate(ctx, req.(*UnbindCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_GetAccountUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).GetAccountUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_GetAccountUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).GetAccountUsage(ctx, req.(*GetAccountUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GophKeeperService_ListVaultItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListVaultItemsRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +818,128 @@ func _GophKeeperService_SetVaultItem_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeperService_SetVaultItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).SetVaultItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_SetVaultItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).SetVaultItems(ctx, req.(*SetVaultItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_UploadVaultItem_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GophKeeperServiceServer).UploadVaultItem(&gophKeeperServiceUploadVaultItemServer{stream})
+}
+
+type GophKeeperService_UploadVaultItemServer interface {
+	SendAndClose(*UploadVaultItemResponse) error
+	Recv() (*UploadVaultItemRequest, error)
+	grpc.ServerStream
+}
+
+type gophKeeperServiceUploadVaultItemServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperServiceUploadVaultItemServer) SendAndClose(m *UploadVaultItemResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gophKeeperServiceUploadVaultItemServer) Recv() (*UploadVaultItemRequest, error) {
+	m := new(UploadVaultItemRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GophKeeperService_DownloadVaultItem_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadVaultItemRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServiceServer).DownloadVaultItem(m, &gophKeeperServiceDownloadVaultItemServer{stream})
+}
+
+type GophKeeperService_DownloadVaultItemServer interface {
+	Send(*DownloadVaultItemResponse) error
+	grpc.ServerStream
+}
+
+type gophKeeperServiceDownloadVaultItemServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperServiceDownloadVaultItemServer) Send(m *DownloadVaultItemResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeperService_WatchVaultItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVaultItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServiceServer).WatchVaultItems(m, &gophKeeperServiceWatchVaultItemsServer{stream})
+}
+
+type GophKeeperService_WatchVaultItemsServer interface {
+	Send(*WatchVaultItemsResponse) error
+	grpc.ServerStream
+}
+
+type gophKeeperServiceWatchVaultItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperServiceWatchVaultItemsServer) Send(m *WatchVaultItemsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeperService_ListItemRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).ListItemRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_ListItemRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).ListItemRevisions(ctx, req.(*ListItemRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_RestoreItemRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItemRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).RestoreItemRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_RestoreItemRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).RestoreItemRevision(ctx, req.(*RestoreItemRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GophKeeperService_ServiceDesc is the grpc.ServiceDesc for GophKeeperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -206,6 +955,54 @@ var GophKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _GophKeeperService_Login_Handler,
 		},
+		{
+			MethodName: "ResendVerificationCode",
+			Handler:    _GophKeeperService_ResendVerificationCode_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _GophKeeperService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _GophKeeperService_Logout_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _GophKeeperService_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _GophKeeperService_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "RecoverAccount",
+			Handler:    _GophKeeperService_RecoverAccount_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _GophKeeperService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "BindCertificate",
+			Handler:    _GophKeeperService_BindCertificate_Handler,
+		},
+		{
+			MethodName: "UnbindCertificate",
+			Handler:    _GophKeeperService_UnbindCertificate_Handler,
+		},
+		{
+			MethodName: "GetAccountUsage",
+			Handler:    _GophKeeperService_GetAccountUsage_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _GophKeeperService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _GophKeeperService_RevokeSession_Handler,
+		},
 		{
 			MethodName: "ListVaultItems",
 			Handler:    _GophKeeperService_ListVaultItems_Handler,
@@ -214,7 +1011,40 @@ var GophKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetVaultItem",
 			Handler:    _GophKeeperService_SetVaultItem_Handler,
 		},
+		{
+			MethodName: "SetVaultItems",
+			Handler:    _GophKeeperService_SetVaultItems_Handler,
+		},
+		{
+			MethodName: "ListItemRevisions",
+			Handler:    _GophKeeperService_ListItemRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreItemRevision",
+			Handler:    _GophKeeperService_RestoreItemRevision_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ChangePassword",
+			Handler:       _GophKeeperService_ChangePassword_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadVaultItem",
+			Handler:       _GophKeeperService_UploadVaultItem_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadVaultItem",
+			Handler:       _GophKeeperService_DownloadVaultItem_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchVaultItems",
+			Handler:       _GophKeeperService_WatchVaultItems_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "common/api/keeper.proto",
 }
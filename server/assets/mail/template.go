@@ -13,6 +13,14 @@ var (
 	welcomeVerificationHTMLTemplateBody string
 	//go:embed verification/welcome.txt
 	welcomeVerificationTextTemplateBody string
+	//go:embed verification/reminder.html
+	verificationReminderHTMLTemplateBody string
+	//go:embed verification/reminder.txt
+	verificationReminderTextTemplateBody string
+	//go:embed account/deleted.html
+	accountDeletedHTMLTemplateBody string
+	//go:embed account/deleted.txt
+	accountDeletedTextTemplateBody string
 
 	Templates = map[string]Template{
 		task.TypeWelcomeVerificationEmail: {
@@ -22,6 +30,20 @@ var (
 			FromEmail:    "noreply@gophkeeper.com",
 			FromName:     "GophKeeper team",
 		},
+		task.TypeVerificationReminderEmail: {
+			HTMLTemplate: htemplate.Must(htemplate.New("verification_reminder_email_html").Parse(verificationReminderHTMLTemplateBody)),
+			TextTemplate: template.Must(template.New("verification_reminder_email_text").Parse(verificationReminderTextTemplateBody)),
+			Subject:      "Reminder: confirm your GophKeeper account",
+			FromEmail:    "noreply@gophkeeper.com",
+			FromName:     "GophKeeper team",
+		},
+		task.TypeAccountDeletedEmail: {
+			HTMLTemplate: htemplate.Must(htemplate.New("account_deleted_email_html").Parse(accountDeletedHTMLTemplateBody)),
+			TextTemplate: template.Must(template.New("account_deleted_email_text").Parse(accountDeletedTextTemplateBody)),
+			Subject:      "Your GophKeeper account has been deleted",
+			FromEmail:    "noreply@gophkeeper.com",
+			FromName:     "GophKeeper team",
+		},
 	}
 )
 
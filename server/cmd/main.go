@@ -18,12 +18,28 @@ var (
 	buildDate    = "N/A"
 )
 
+// adminCommand is the first argument to run the administrator command instead of the server.
+const adminCommand = "admin"
+
 func main() {
 	cfg, err := buildConfig()
 	if err != nil {
 		log.Fatal("parse config error: ", err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	// admin subcommand prints its output to stdout, so the logs are written to stderr
+	if len(os.Args) > 1 && os.Args[1] == adminCommand {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		if err := app.RunAdmin(ctx, cfg, logger, os.Args[2:], os.Stdout); err != nil {
+			stop()
+			log.Fatal(err)
+		}
+		return
+	}
+
 	logger := buildLogger(cfg.Env)
 
 	logger.Info(
@@ -33,9 +49,6 @@ func main() {
 		slog.String("build date", buildDate),
 	)
 
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-	defer stop()
-
 	if err := app.Run(ctx, cfg, logger); err != nil {
 		logger.Error("application stopped with error", sl.Error(err))
 		os.Exit(1)
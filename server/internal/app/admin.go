@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"io"
+
+	"log/slog"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/config"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/admin"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+// RunAdmin runs the administrator command with the same storage and caches as the server,
+// the blob storage, task manager and mail are not used by the commands.
+// The auth cache must be shared with the server (GOPHKEEPER_SERVICE_AUTH_CACHE_URI),
+// otherwise the revoked sessions would stay valid on the server.
+func RunAdmin(ctx context.Context, cfg *config.Config, logger *slog.Logger, args []string, out io.Writer) error {
+	const op = "app run admin"
+
+	serviceStorage, err := buildServiceStorage(ctx, cfg.Service.Storage)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer serviceStorage.Close()
+
+	opts, closeFns, err := buildServiceOptions(cfg.Service)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer func() {
+		for _, fn := range closeFns {
+			if err := fn(); err != nil {
+				logger.Error("close before admin command exit failed", sl.Error(err))
+			}
+		}
+	}()
+	opts = append(opts, service.WithSLogger(logger))
+
+	service, err := service.NewAdmin(cfg.Service, serviceStorage, opts...)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return admin.Run(ctx, service, args, out)
+}
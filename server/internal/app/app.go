@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"log/slog"
 
@@ -14,19 +15,51 @@ import (
 	"github.com/Karzoug/goph_keeper/server/internal/config"
 	scfg "github.com/Karzoug/goph_keeper/server/internal/config/service"
 	"github.com/Karzoug/goph_keeper/server/internal/config/storage"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/gateway"
 	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/metrics"
 	rtasks "github.com/Karzoug/goph_keeper/server/internal/delivery/rtask"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/mail/smtp"
 	rtaskc "github.com/Karzoug/goph_keeper/server/internal/repository/rtask"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/fs"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/postgres"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/redis"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/s3"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/sqlite"
+	"github.com/Karzoug/goph_keeper/server/internal/requestid"
 	"github.com/Karzoug/goph_keeper/server/internal/service"
 )
 
+const (
+	defaultBlobStorageDir  = "blobs"
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// pinger is a storage that can check the connection, it is reported by the health service.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
 func Run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 	const op = "app run"
 
+	// records written with the request context get its request ID
+	logger = slog.New(requestid.NewHandler(logger.Handler()))
+
+	shutdownTracing, err := setupTracing(ctx, cfg.Trace)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("shutdown tracing failed", sl.Error(err))
+		}
+	}()
+	logger.Info("app run: tracing set up", slog.String("exporter", cfg.Trace.Exporter))
+
 	serviceStorage, err := buildServiceStorage(ctx, cfg.Service.Storage)
 	if err != nil {
 		return e.Wrap(op, err)
@@ -34,6 +67,13 @@ func Run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 	defer serviceStorage.Close()
 	logger.Info("app run: service storage created")
 
+	blobStorage, err := buildBlobStorage(ctx, cfg.Service.BlobStorage)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer blobStorage.Close()
+	logger.Info("app run: blob storage created")
+
 	rtaskClient, err := rtaskc.New(cfg.RTask.Storage.URI, logger)
 	if err != nil {
 		return e.Wrap(op, err)
@@ -59,13 +99,37 @@ func Run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 	}()
 	opts = append(opts, service.WithSLogger(logger))
 
-	service, err := service.New(cfg.Service, serviceStorage, rtaskClient, smtpClient, opts...)
+	service, err := service.New(cfg.Service,
+		meteredStorage{Storage: serviceStorage, name: "storage"},
+		meteredBlobStorage{BlobStorage: blobStorage, name: "blob_storage"},
+		rtaskClient, smtpClient, opts...)
 	if err != nil {
 		return e.Wrap(op, err)
 	}
 	logger.Info("app run: service created")
 
-	grpcServer, err := grpc.New(cfg.GRPC, service, logger)
+	rtaskRedis, err := redis.New(cfg.RTask.Storage)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer rtaskRedis.Close()
+
+	grpcOpts := []grpc.Option{
+		grpc.WithHealthCheck("redis", rtaskRedis.Ping),
+		grpc.WithHealthCheck("smtp", smtpClient.Ping),
+	}
+	if p, ok := serviceStorage.(pinger); ok {
+		grpcOpts = append(grpcOpts, grpc.WithHealthCheck("storage", p.Ping))
+	}
+	var gatewaySecret string
+	if len(cfg.GRPC.GatewayPort) != 0 {
+		gatewaySecret, err = forwarded.NewSecret()
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		grpcOpts = append(grpcOpts, grpc.WithGatewaySecret(gatewaySecret))
+	}
+	grpcServer, err := grpc.New(cfg.GRPC, service, logger, grpcOpts...)
 	if err != nil {
 		return e.Wrap(op, err)
 	}
@@ -87,6 +151,28 @@ func Run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 		return rtaskServer.Run(ctx)
 	})
 
+	// the sizes are needed only by the quota, so the server does not wait for them
+	g.Go(func() error {
+		if err := service.BackfillBlobSizes(ctx); err != nil {
+			logger.Error("backfill blob sizes failed", sl.Error(err))
+		}
+		return nil
+	})
+
+	if len(cfg.GRPC.GatewayPort) != 0 {
+		gatewayServer := gateway.New(cfg.GRPC, gatewaySecret, logger)
+		g.Go(func() error {
+			return gatewayServer.Run(ctx)
+		})
+	}
+
+	if len(cfg.GRPC.MetricsPort) != 0 {
+		metricsServer := metrics.New(cfg.GRPC, logger)
+		g.Go(func() error {
+			return metricsServer.Run(ctx)
+		})
+	}
+
 	if err := g.Wait(); err != nil {
 		return e.Wrap(op, err)
 	}
@@ -107,6 +193,21 @@ func buildServiceStorage(ctx context.Context, cfg storage.Config) (service.Stora
 	}
 }
 
+func buildBlobStorage(ctx context.Context, cfg storage.Config) (service.BlobStorage, error) {
+	if len(cfg.URI) == 0 {
+		cfg.URI = fs.URIPreffix + defaultBlobStorageDir
+	}
+
+	switch {
+	case strings.HasPrefix(cfg.URI, fs.URIPreffix):
+		return fs.New(cfg)
+	case strings.HasPrefix(cfg.URI, s3.URIPreffix):
+		return s3.New(ctx, cfg)
+	default:
+		return nil, errors.New("unknown blob storage type")
+	}
+}
+
 func buildServiceOptions(cfg scfg.Config) ([]service.Option, []func() error, error) {
 	opts := make([]service.Option, 0)
 	closeFns := make([]func() error, 0)
@@ -116,7 +217,7 @@ func buildServiceOptions(cfg scfg.Config) ([]service.Option, []func() error, err
 		if err != nil {
 			return nil, nil, err
 		} else {
-			opts = append(opts, service.WithAuthCache(ac))
+			opts = append(opts, service.WithAuthCache(meteredKvStorage{KvStorage: ac, name: "auth_cache"}))
 			closeFns = append(closeFns, ac.Close)
 		}
 	}
@@ -125,11 +226,31 @@ func buildServiceOptions(cfg scfg.Config) ([]service.Option, []func() error, err
 		if err != nil {
 			return nil, nil, err
 		} else {
-			opts = append(opts, service.WithMailCache(mc))
+			opts = append(opts, service.WithMailCache(meteredKvStorage{KvStorage: mc, name: "mail_cache"}))
 			closeFns = append(closeFns, mc.Close)
 		}
 	}
 
+	if len(cfg.AttemptsCache.URI) != 0 {
+		atc, err := buildServiceCache(cfg.AttemptsCache)
+		if err != nil {
+			return nil, nil, err
+		} else {
+			opts = append(opts, service.WithAttemptsCache(meteredKvStorage{KvStorage: atc, name: "attempts_cache"}))
+			closeFns = append(closeFns, atc.Close)
+		}
+	}
+
+	if len(cfg.PubSub.URI) != 0 {
+		ps, err := buildServicePubSub(cfg.PubSub)
+		if err != nil {
+			return nil, nil, err
+		} else {
+			opts = append(opts, service.WithPubSub(ps))
+			closeFns = append(closeFns, ps.Close)
+		}
+	}
+
 	return opts, closeFns, nil
 }
 
@@ -141,3 +262,12 @@ func buildServiceCache(cfg storage.Config) (service.KvStorage, error) {
 		return nil, errors.New("unknown storage type")
 	}
 }
+
+func buildServicePubSub(cfg storage.Config) (service.PubSub, error) {
+	switch {
+	case strings.HasPrefix(cfg.URI, redis.URIPreffix):
+		return redis.New(cfg)
+	default:
+		return nil, errors.New("unknown pub/sub type")
+	}
+}
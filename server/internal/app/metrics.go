@@ -0,0 +1,386 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/page"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+var storageErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gophkeeper",
+	Subsystem: "storage",
+	Name:      "errors_total",
+	Help:      "Total number of storage and cache errors by storage name and method.",
+}, []string{"storage", "method"})
+
+var tracer = otel.Tracer("github.com/Karzoug/goph_keeper/server/internal/app")
+
+// countError counts the error of the storage method, expected results
+// like not found record are not errors of the storage.
+func countError(name, method string, err error) {
+	if !isStorageError(err) {
+		return
+	}
+	storageErrorsTotal.WithLabelValues(name, method).Inc()
+}
+
+// isStorageError reports whether err is a failure of the storage,
+// not an expected result like not found record.
+func isStorageError(err error) bool {
+	return err != nil &&
+		!errors.Is(err, storage.ErrRecordNotFound) &&
+		!errors.Is(err, storage.ErrRecordAlreadyExists) &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) &&
+		!errors.Is(err, context.Canceled)
+}
+
+// observe starts the span of the storage method, returned function ends the span
+// and counts the error.
+func observe(ctx context.Context, name, method string) (context.Context, func(error)) {
+	ctx, span := tracer.Start(ctx, name+": "+method, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, func(err error) {
+		defer span.End()
+
+		countError(name, method, err)
+		if isStorageError(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
+
+// meteredStorage is the service storage that counts its errors and traces its calls.
+type meteredStorage struct {
+	service.Storage
+	name string
+}
+
+func (s meteredStorage) AddUser(ctx context.Context, u user.User) error {
+	ctx, end := observe(ctx, s.name, "AddUser")
+	err := s.Storage.AddUser(ctx, u)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) GetUser(ctx context.Context, email string) (user.User, error) {
+	ctx, end := observe(ctx, s.name, "GetUser")
+	u, err := s.Storage.GetUser(ctx, email)
+	end(err)
+	return u, err
+}
+
+func (s meteredStorage) SetUserEmailVerified(ctx context.Context, email string) error {
+	ctx, end := observe(ctx, s.name, "SetUserEmailVerified")
+	err := s.Storage.SetUserEmailVerified(ctx, email)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetUserAuthKey(ctx context.Context, email string, key auth.Key, wrappedVaultKey []byte) error {
+	ctx, end := observe(ctx, s.name, "SetUserAuthKey")
+	err := s.Storage.SetUserAuthKey(ctx, email, key, wrappedVaultKey)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetUserTOTP(ctx context.Context, email string, secret totp.Secret, enabled bool, recoveryCodes []string) error {
+	ctx, end := observe(ctx, s.name, "SetUserTOTP")
+	err := s.Storage.SetUserTOTP(ctx, email, secret, enabled, recoveryCodes)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetUserCertFingerprint(ctx context.Context, email, fingerprint string) error {
+	ctx, end := observe(ctx, s.name, "SetUserCertFingerprint")
+	err := s.Storage.SetUserCertFingerprint(ctx, email, fingerprint)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetUserDisabled(ctx context.Context, email string, disabled bool) error {
+	ctx, end := observe(ctx, s.name, "SetUserDisabled")
+	err := s.Storage.SetUserDisabled(ctx, email, disabled)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetUserQuota(ctx context.Context, email string, quota user.Quota) error {
+	ctx, end := observe(ctx, s.name, "SetUserQuota")
+	err := s.Storage.SetUserQuota(ctx, email, quota)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) UpdateTOTPRecoveryCodes(ctx context.Context, email string, old, new []string) error {
+	ctx, end := observe(ctx, s.name, "UpdateTOTPRecoveryCodes")
+	err := s.Storage.UpdateTOTPRecoveryCodes(ctx, email, old, new)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) ListUsers(ctx context.Context, after string, limit int) ([]user.User, error) {
+	ctx, end := observe(ctx, s.name, "ListUsers")
+	users, err := s.Storage.ListUsers(ctx, after, limit)
+	end(err)
+	return users, err
+}
+
+func (s meteredStorage) ListUnverifiedUsers(ctx context.Context, createdBefore time.Time) ([]user.User, error) {
+	ctx, end := observe(ctx, s.name, "ListUnverifiedUsers")
+	users, err := s.Storage.ListUnverifiedUsers(ctx, createdBefore)
+	end(err)
+	return users, err
+}
+
+func (s meteredStorage) GetUserUsage(ctx context.Context, email string, exclude ...string) (user.Usage, error) {
+	ctx, end := observe(ctx, s.name, "GetUserUsage")
+	usage, err := s.Storage.GetUserUsage(ctx, email, exclude...)
+	end(err)
+	return usage, err
+}
+
+func (s meteredStorage) GetUsersUsage(ctx context.Context, emails []string) (map[string]user.Usage, error) {
+	ctx, end := observe(ctx, s.name, "GetUsersUsage")
+	usage, err := s.Storage.GetUsersUsage(ctx, emails)
+	end(err)
+	return usage, err
+}
+
+func (s meteredStorage) ChangePassword(ctx context.Context, u user.User, items []vault.Item, refs map[string]blob.Ref) error {
+	ctx, end := observe(ctx, s.name, "ChangePassword")
+	err := s.Storage.ChangePassword(ctx, u, items, refs)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) DeleteUser(ctx context.Context, email string) ([]string, error) {
+	ctx, end := observe(ctx, s.name, "DeleteUser")
+	keys, err := s.Storage.DeleteUser(ctx, email)
+	end(err)
+	return keys, err
+}
+
+func (s meteredStorage) DeleteUnverifiedUser(ctx context.Context, email string) error {
+	ctx, end := observe(ctx, s.name, "DeleteUnverifiedUser")
+	err := s.Storage.DeleteUnverifiedUser(ctx, email)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetVerificationRemindedAt(ctx context.Context, email string, t time.Time) error {
+	ctx, end := observe(ctx, s.name, "SetVerificationRemindedAt")
+	err := s.Storage.SetVerificationRemindedAt(ctx, email, t)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetVaultItem(ctx context.Context, email string, item vault.Item, quota user.Quota) error {
+	ctx, end := observe(ctx, s.name, "SetVaultItem")
+	err := s.Storage.SetVaultItem(ctx, email, item, quota)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) SetVaultItems(ctx context.Context, email string, items []vault.Item, quota user.Quota) ([]error, error) {
+	ctx, end := observe(ctx, s.name, "SetVaultItems")
+	errs, err := s.Storage.SetVaultItems(ctx, email, items, quota)
+	end(err)
+	return errs, err
+}
+
+func (s meteredStorage) SetLargeVaultItem(ctx context.Context, email string, item vault.Item, ref blob.Ref, quota user.Quota) error {
+	ctx, end := observe(ctx, s.name, "SetLargeVaultItem")
+	err := s.Storage.SetLargeVaultItem(ctx, email, item, ref, quota)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) GetLargeVaultItem(ctx context.Context, email, id string) (vault.Item, blob.Ref, error) {
+	ctx, end := observe(ctx, s.name, "GetLargeVaultItem")
+	item, ref, err := s.Storage.GetLargeVaultItem(ctx, email, id)
+	end(err)
+	return item, ref, err
+}
+
+func (s meteredStorage) ListUnsizedBlobKeys(ctx context.Context, after string, limit int) ([]string, error) {
+	ctx, end := observe(ctx, s.name, "ListUnsizedBlobKeys")
+	keys, err := s.Storage.ListUnsizedBlobKeys(ctx, after, limit)
+	end(err)
+	return keys, err
+}
+
+func (s meteredStorage) SetBlobSize(ctx context.Context, key string, size int64) error {
+	ctx, end := observe(ctx, s.name, "SetBlobSize")
+	err := s.Storage.SetBlobSize(ctx, key, size)
+	end(err)
+	return err
+}
+
+func (s meteredStorage) ListVaultItems(ctx context.Context, email string, since int64, after page.Cursor, limit int) ([]vault.Item, error) {
+	ctx, end := observe(ctx, s.name, "ListVaultItems")
+	items, err := s.Storage.ListVaultItems(ctx, email, since, after, limit)
+	end(err)
+	return items, err
+}
+
+func (s meteredStorage) PurgeDeletedVaultItems(ctx context.Context, before int64) (int64, error) {
+	ctx, end := observe(ctx, s.name, "PurgeDeletedVaultItems")
+	count, err := s.Storage.PurgeDeletedVaultItems(ctx, before)
+	end(err)
+	return count, err
+}
+
+func (s meteredStorage) GetTombstonesPurgedAt(ctx context.Context, email string) (int64, error) {
+	ctx, end := observe(ctx, s.name, "GetTombstonesPurgedAt")
+	t, err := s.Storage.GetTombstonesPurgedAt(ctx, email)
+	end(err)
+	return t, err
+}
+
+func (s meteredStorage) ListItemRevisions(ctx context.Context, email, id string) ([]vault.Item, error) {
+	ctx, end := observe(ctx, s.name, "ListItemRevisions")
+	items, err := s.Storage.ListItemRevisions(ctx, email, id)
+	end(err)
+	return items, err
+}
+
+func (s meteredStorage) GetItemRevision(ctx context.Context, email, id string, revision int64) (vault.Item, error) {
+	ctx, end := observe(ctx, s.name, "GetItemRevision")
+	item, err := s.Storage.GetItemRevision(ctx, email, id, revision)
+	end(err)
+	return item, err
+}
+
+func (s meteredStorage) RestoreItemRevision(ctx context.Context, email, id string, revision, updatedAt int64, quota user.Quota) (string, error) {
+	ctx, end := observe(ctx, s.name, "RestoreItemRevision")
+	blobKey, err := s.Storage.RestoreItemRevision(ctx, email, id, revision, updatedAt, quota)
+	end(err)
+	return blobKey, err
+}
+
+func (s meteredStorage) TrimItemRevisions(ctx context.Context, email string, keep int, ids ...string) error {
+	ctx, end := observe(ctx, s.name, "TrimItemRevisions")
+	err := s.Storage.TrimItemRevisions(ctx, email, keep, ids...)
+	end(err)
+	return err
+}
+
+// meteredKvStorage is the key-value storage (cache) that counts its errors and traces its calls.
+type meteredKvStorage struct {
+	service.KvStorage
+	name string
+}
+
+func (s meteredKvStorage) Get(ctx context.Context, key string) (string, error) {
+	ctx, end := observe(ctx, s.name, "Get")
+	value, err := s.KvStorage.Get(ctx, key)
+	end(err)
+	return value, err
+}
+
+func (s meteredKvStorage) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	ctx, end := observe(ctx, s.name, "Set")
+	err := s.KvStorage.Set(ctx, key, value, expiration)
+	end(err)
+	return err
+}
+
+func (s meteredKvStorage) SetXX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	ctx, end := observe(ctx, s.name, "SetXX")
+	ok, err := s.KvStorage.SetXX(ctx, key, value, expiration)
+	end(err)
+	return ok, err
+}
+
+func (s meteredKvStorage) SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	ctx, end := observe(ctx, s.name, "SetNX")
+	ok, err := s.KvStorage.SetNX(ctx, key, value, expiration)
+	end(err)
+	return ok, err
+}
+
+func (s meteredKvStorage) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	ctx, end := observe(ctx, s.name, "Incr")
+	count, err := s.KvStorage.Incr(ctx, key, expiration)
+	end(err)
+	return count, err
+}
+
+func (s meteredKvStorage) Delete(ctx context.Context, key string) error {
+	ctx, end := observe(ctx, s.name, "Delete")
+	err := s.KvStorage.Delete(ctx, key)
+	end(err)
+	return err
+}
+
+func (s meteredKvStorage) GetDel(ctx context.Context, key string) (string, error) {
+	ctx, end := observe(ctx, s.name, "GetDel")
+	value, err := s.KvStorage.GetDel(ctx, key)
+	end(err)
+	return value, err
+}
+
+func (s meteredKvStorage) SAdd(ctx context.Context, key, member string, expiration time.Duration) error {
+	ctx, end := observe(ctx, s.name, "SAdd")
+	err := s.KvStorage.SAdd(ctx, key, member, expiration)
+	end(err)
+	return err
+}
+
+func (s meteredKvStorage) SMembers(ctx context.Context, key string) ([]string, error) {
+	ctx, end := observe(ctx, s.name, "SMembers")
+	members, err := s.KvStorage.SMembers(ctx, key)
+	end(err)
+	return members, err
+}
+
+func (s meteredKvStorage) SRem(ctx context.Context, key string, members ...string) error {
+	ctx, end := observe(ctx, s.name, "SRem")
+	err := s.KvStorage.SRem(ctx, key, members...)
+	end(err)
+	return err
+}
+
+// meteredBlobStorage is the blob storage that counts its errors and traces its calls.
+type meteredBlobStorage struct {
+	service.BlobStorage
+	name string
+}
+
+func (s meteredBlobStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	ctx, end := observe(ctx, s.name, "Put")
+	err := s.BlobStorage.Put(ctx, key, r)
+	end(err)
+	return err
+}
+
+func (s meteredBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ctx, end := observe(ctx, s.name, "Get")
+	rc, err := s.BlobStorage.Get(ctx, key)
+	end(err)
+	return rc, err
+}
+
+func (s meteredBlobStorage) Delete(ctx context.Context, key string) error {
+	ctx, end := observe(ctx, s.name, "Delete")
+	err := s.BlobStorage.Delete(ctx, key)
+	end(err)
+	return err
+}
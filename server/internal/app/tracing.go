@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	tcfg "github.com/Karzoug/goph_keeper/server/internal/config/trace"
+)
+
+const serviceName = "goph_keeper_server"
+
+// setupTracing sets the global propagator and tracer provider.
+// The propagator is set even if the exporter is none: the trace context
+// of the client is passed to the email tasks anyway.
+// Returned function flushes the spans and must be called before the app stop.
+func setupTracing(ctx context.Context, cfg tcfg.Config) (func(context.Context) error, error) {
+	const op = "setup tracing"
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closeFn, err := buildSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), closeFn())
+	}, nil
+}
+
+func buildSpanExporter(ctx context.Context, cfg tcfg.Config) (sdktrace.SpanExporter, func() error, error) {
+	noopCloseFn := func() error { return nil }
+
+	switch cfg.Exporter {
+	case "", tcfg.ExporterNone:
+		return nil, noopCloseFn, nil
+	case tcfg.ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, noopCloseFn, nil
+	case tcfg.ExporterStdout:
+		var (
+			w       io.Writer = os.Stdout
+			closeFn           = noopCloseFn
+		)
+		if len(cfg.FileName) != 0 {
+			f, err := os.OpenFile(cfg.FileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, err
+			}
+			w, closeFn = f, f.Close
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, closeFn, nil
+	default:
+		return nil, nil, errors.New("unknown trace exporter")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/Karzoug/goph_keeper/server/internal/config/rtask"
 	"github.com/Karzoug/goph_keeper/server/internal/config/service"
 	"github.com/Karzoug/goph_keeper/server/internal/config/smtp"
+	"github.com/Karzoug/goph_keeper/server/internal/config/trace"
 )
 
 // Config is a configuration for GophKeeper server.
@@ -19,4 +20,6 @@ type Config struct {
 	RTask rtask.Config `envPrefix:"RTASK_"`
 	// SMTP is a configuration for SMTP server.
 	SMTP smtp.Config `envPrefix:"SMTP_"`
+	// Trace is a configuration for OpenTelemetry tracing.
+	Trace trace.Config `envPrefix:"TRACE_"`
 }
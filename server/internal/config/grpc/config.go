@@ -1,12 +1,42 @@
 package grpc
 
+import "time"
+
 type Config struct {
 	Host         string `env:"HOST"`
 	Port         string `env:"PORT,notEmpty" envDefault:"8080"`
 	CertFileName string `env:"CERT_FILE_NAME"`
 	KeyFileName  string `env:"KEY_FILE_NAME"`
+	// ClientCAFileName is the CA certificate to verify client certificates (mutual TLS),
+	// if it is set, the clients without certificate signed by the CA are rejected.
+	// The gateway presents the server certificate as its client certificate, so the CA file
+	// should also contain the server certificate (or its issuer) when the gateway is enabled,
+	// the certificate of the gateway client is forwarded to the server in the metadata.
+	ClientCAFileName string `env:"CLIENT_CA_FILE_NAME"`
+	// MetricsHost and MetricsPort are the address of HTTP server with Prometheus metrics,
+	// if the port is empty, the metrics are not exposed.
+	MetricsHost string `env:"METRICS_HOST"`
+	MetricsPort string `env:"METRICS_PORT"`
+	// GatewayHost and GatewayPort are the address of REST/JSON gateway to the gRPC server,
+	// it uses the same certificate and key. If the port is empty, the gateway is disabled.
+	GatewayHost string `env:"GATEWAY_HOST"`
+	GatewayPort string `env:"GATEWAY_PORT"`
+	// LogSecretKey is the secret key to hash emails of the users in the access log,
+	// if it is empty, a random key is used: the hashes of different runs do not match.
+	LogSecretKey string `env:"LOG_SECRET_KEY,unset"`
+	// HealthCheckInterval is the interval between checks of the storage, Redis and SMTP server
+	// reported by the gRPC health service.
+	HealthCheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL,notEmpty" envDefault:"15s"`
 }
 
 func (cfg Config) Address() string {
 	return cfg.Host + ":" + cfg.Port
 }
+
+func (cfg Config) MetricsAddress() string {
+	return cfg.MetricsHost + ":" + cfg.MetricsPort
+}
+
+func (cfg Config) GatewayAddress() string {
+	return cfg.GatewayHost + ":" + cfg.GatewayPort
+}
@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"crypto/x509"
+	"errors"
+	"os"
+)
+
+// LoadCertPool creates a new certificate pool from the given PEM file.
+func LoadCertPool(filename string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("failed to add certificate")
+	}
+
+	return certPool, nil
+}
@@ -8,6 +8,12 @@ type Config struct {
 	// If set to a zero or negative value, will be overwrited by the value
 	// to the number of CPUs usable by the current process.
 	Concurrency int `env:"CONCURRENCY" envDefault:"0"`
+	// PurgeTombstonesSchedule is the cron spec (or @every duration) of the task purging
+	// the deleted vault items older than the retention, empty disables the task.
+	PurgeTombstonesSchedule string `env:"PURGE_TOMBSTONES_SCHEDULE" envDefault:"@daily"`
+	// CleanupUnverifiedUsersSchedule is the cron spec (or @every duration) of the task reminding and deleting
+	// the users that never verified the email, empty disables the task.
+	CleanupUnverifiedUsersSchedule string `env:"CLEANUP_UNVERIFIED_USERS_SCHEDULE" envDefault:"@hourly"`
 	// Storage is a configuration for storage (redis).
 	Storage storage.Config `envPrefix:"STORAGE_"`
 }
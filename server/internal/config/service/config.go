@@ -9,18 +9,88 @@ import (
 
 type Config struct {
 	Token struct {
-		// TokenLifetime is the lifetime of the token.
-		TokenLifetime time.Duration `env:"TOKEN_LIFETIME,notEmpty" envDefault:"168h"`
+		// TokenLifetime is the lifetime of the token to access the vault.
+		TokenLifetime time.Duration `env:"TOKEN_LIFETIME,notEmpty" envDefault:"15m"`
+		// RefreshTokenLifetime is the lifetime of the token to get a new token,
+		// the user has to log in again after it expires.
+		RefreshTokenLifetime time.Duration `env:"REFRESH_TOKEN_LIFETIME,notEmpty" envDefault:"720h"`
 		// SecretKey is the secret key to sign token.
 		SecretKey token.SecretKey `env:"TOKEN_SECRET_KEY,notEmpty,unset"`
 	}
 	Email struct {
 		CodeLength   int           `env:"EMAIL_CODE_LENGTH,notEmpty" envDefault:"6"`
 		CodeLifetime time.Duration `env:"EMAIL_CODE_LIFETIME,notEmpty" envDefault:"24h"`
+		// CodeMaxAttempts is the number of wrong codes after which the email code is invalidated,
+		// the user has to request a new one.
+		CodeMaxAttempts int `env:"EMAIL_CODE_MAX_ATTEMPTS,notEmpty" envDefault:"5"`
+		// ResendInterval is the minimum interval between the verification code emails to the same user.
+		ResendInterval time.Duration `env:"EMAIL_RESEND_INTERVAL,notEmpty" envDefault:"1m"`
+	}
+	// UnverifiedUsers is a configuration of the scheduled cleanup of the users that never verified the email:
+	// such a user holds the email, so nobody else can register with it.
+	UnverifiedUsers struct {
+		// Remind enables the reminder with a new verification code, it is sent once
+		// when the first code has expired.
+		Remind bool `env:"UNVERIFIED_USERS_REMIND" envDefault:"true"`
+		// Delete enables deletion of the users that did not verify the email
+		// within the grace period after the last sent code has expired.
+		Delete      bool          `env:"UNVERIFIED_USERS_DELETE" envDefault:"true"`
+		GracePeriod time.Duration `env:"UNVERIFIED_USERS_GRACE_PERIOD" envDefault:"168h"`
+	}
+	// AuthAttempts is a configuration of the brute-force protection of the authentication methods.
+	AuthAttempts struct {
+		// MaxPerEmail is the number of failed attempts with the same email before the lockout.
+		MaxPerEmail int `env:"AUTH_ATTEMPTS_MAX_PER_EMAIL,notEmpty" envDefault:"5"`
+		// MaxPerAddress is the number of failed attempts from the same peer IP before the lockout,
+		// it is greater than per email limit because many users can share one IP.
+		MaxPerAddress int `env:"AUTH_ATTEMPTS_MAX_PER_ADDRESS,notEmpty" envDefault:"30"`
+		// Lockout is the lockout duration after the limit is reached,
+		// it is doubled on every next failed attempt up to MaxLockout.
+		Lockout    time.Duration `env:"AUTH_ATTEMPTS_LOCKOUT,notEmpty" envDefault:"1m"`
+		MaxLockout time.Duration `env:"AUTH_ATTEMPTS_MAX_LOCKOUT,notEmpty" envDefault:"1h"`
+		// Window is the time failed attempts are remembered for.
+		Window time.Duration `env:"AUTH_ATTEMPTS_WINDOW,notEmpty" envDefault:"24h"`
 	}
 	// Storage is a configuration for storage.
-	Storage                 storage.Config `envPrefix:"STORAGE_"`
-	StorageMaxSizeItemValue uint           `envPrefix:"STORAGE_MAX_SIZE_ITEM_VALUE,notempty"  envDefault:"1048576"`
-	AuthCache               storage.Config `envPrefix:"AUTH_CACHE_"`
-	MailCache               storage.Config `envPrefix:"MAIL_CACHE_"`
+	Storage storage.Config `envPrefix:"STORAGE_"`
+	// StorageMaxSizeItemValue is the maximum size in bytes of the item value
+	// that can be sent in a single request.
+	StorageMaxSizeItemValue uint `env:"STORAGE_MAX_SIZE_ITEM_VALUE,notEmpty" envDefault:"1048576"`
+	// StorageMaxSizeLargeItemValue is the maximum size in bytes of the large binary item value
+	// that can be uploaded by stream.
+	StorageMaxSizeLargeItemValue uint `env:"STORAGE_MAX_SIZE_LARGE_ITEM_VALUE,notEmpty" envDefault:"104857600"`
+	// Quota is the default limit of the user vault, it can be overridden for the user
+	// by the administrator. Zero values mean no limit.
+	Quota struct {
+		// MaxBytes is the maximum total size in bytes of the item values of the user,
+		// including large binary item values, but not the item revisions.
+		MaxBytes int64 `env:"QUOTA_MAX_BYTES" envDefault:"0"`
+		// MaxItems is the maximum number of not deleted items of the user.
+		MaxItems int64 `env:"QUOTA_MAX_ITEMS" envDefault:"0"`
+	}
+	// ItemRevisions is the number of previous versions kept for every vault item to restore them,
+	// zero disables the history. The revisions are not counted by the quota.
+	ItemRevisions int `env:"ITEM_REVISIONS,notEmpty" envDefault:"10"`
+	// TombstoneRetention is the time the deleted vault items are kept to synchronize other devices,
+	// then they are purged by the scheduled task. A client that was synchronized before the newest
+	// purged item was deleted has to synchronize the whole vault again. Zero means the deleted items are kept forever.
+	TombstoneRetention time.Duration `env:"TOMBSTONE_RETENTION" envDefault:"720h"`
+	// ListVaultItemsMaxPageSize is the maximum number of items in one page of the vault items list.
+	ListVaultItemsMaxPageSize int `env:"LIST_VAULT_ITEMS_MAX_PAGE_SIZE,notEmpty" envDefault:"1000"`
+	// ListVaultItemsMaxPageBytes is the maximum total size in bytes of the item values in one page
+	// of the vault items list, it keeps the response within gRPC message size limit.
+	ListVaultItemsMaxPageBytes int `env:"LIST_VAULT_ITEMS_MAX_PAGE_BYTES,notEmpty" envDefault:"2097152"`
+	// BlobStorage is a configuration for storage of large binary item values:
+	// local directory (file://path) or S3-compatible storage (s3://...).
+	// If empty, the blobs directory in the working directory is used.
+	BlobStorage storage.Config `envPrefix:"BLOB_STORAGE_"`
+	AuthCache   storage.Config `envPrefix:"AUTH_CACHE_"`
+	MailCache   storage.Config `envPrefix:"MAIL_CACHE_"`
+	// AttemptsCache is a configuration for storage of the failed authentication attempts counters,
+	// it must be shared by all server instances. If empty, in-memory storage is used.
+	AttemptsCache storage.Config `envPrefix:"ATTEMPTS_CACHE_"`
+	// PubSub is a configuration for publish/subscribe broker of the vault changes,
+	// it is required if several server instances are running.
+	// If empty, in-memory broker is used.
+	PubSub storage.Config `envPrefix:"PUBSUB_"`
 }
@@ -0,0 +1,23 @@
+package trace
+
+// A list of supported exporters.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+type Config struct {
+	// Exporter is a type of spans exporter: none, otlp or stdout.
+	Exporter string `env:"EXPORTER" envDefault:"none"`
+	// Endpoint is an address of OTLP collector (gRPC), e.g. localhost:4317.
+	Endpoint string `env:"ENDPOINT" envDefault:"localhost:4317"`
+	// Insecure disables TLS for connection to OTLP collector.
+	Insecure bool `env:"INSECURE" envDefault:"false"`
+	// FileName is a name of file the stdout exporter writes spans to,
+	// if it is empty, spans are written to stdout.
+	FileName string `env:"FILE_NAME"`
+	// SampleRatio is a fraction of traces to sample, traces started
+	// by the client are sampled according to its decision.
+	SampleRatio float64 `env:"SAMPLE_RATIO" envDefault:"1"`
+}
@@ -0,0 +1,278 @@
+// Package admin is a command line interface of the server administrator,
+// it works with the same storages as the server, so no raw SQL is needed.
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+// usersPageSize is the number of users requested from the storage at once.
+const usersPageSize = 100
+
+const usage = `Usage: goph_keeper_server admin <command> [arguments]
+
+Commands:
+  users [-after email] [-limit n]  list users with verification status and item counts
+  user <email>                     show the user account
+  verify <email>                   mark the user email as verified
+  disable <email>                  disable the user account and revoke its sessions
+  enable <email>                   enable the disabled user account
+  expire-sessions <email>          revoke all sessions of the user
+  quota <email> <bytes> <items>    set the storage quota of the user, 0 means the server default
+  usage                            print the storage usage of all users
+
+Sessions are revoked in the auth cache (GOPHKEEPER_SERVICE_AUTH_CACHE_URI),
+so it must be the same cache the server uses.
+`
+
+// ErrUsage returned if the command or its arguments are not valid, the usage is printed.
+var ErrUsage = errors.New("invalid command usage")
+
+// Service is the part of the service used by the administrator commands.
+type Service interface {
+	ListUsers(ctx context.Context, after string, limit int) ([]service.UserInfo, error)
+	GetUserInfo(ctx context.Context, email string) (service.UserInfo, error)
+	VerifyUserEmail(ctx context.Context, email string) error
+	SetUserDisabled(ctx context.Context, email string, disabled bool) error
+	ExpireUserSessions(ctx context.Context, email string) error
+	SetUserQuota(ctx context.Context, email string, quota user.Quota) error
+}
+
+type cli struct {
+	service Service
+	out     io.Writer
+}
+
+// Run runs the administrator command given by args (without the "admin" subcommand itself)
+// and writes its output to out.
+func Run(ctx context.Context, service Service, args []string, out io.Writer) error {
+	c := cli{
+		service: service,
+		out:     out,
+	}
+
+	if len(args) == 0 {
+		fmt.Fprint(out, usage)
+		return ErrUsage
+	}
+
+	var err error
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "users":
+		err = c.users(ctx, args)
+	case "user":
+		err = c.withEmail(ctx, args, c.user)
+	case "verify":
+		err = c.withEmail(ctx, args, c.verify)
+	case "disable":
+		err = c.withEmail(ctx, args, c.disable)
+	case "enable":
+		err = c.withEmail(ctx, args, c.enable)
+	case "expire-sessions":
+		err = c.withEmail(ctx, args, c.expireSessions)
+	case "quota":
+		err = c.quota(ctx, args)
+	case "usage":
+		err = c.usage(ctx)
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		err = ErrUsage
+	}
+
+	if errors.Is(err, ErrUsage) {
+		fmt.Fprint(out, usage)
+	}
+	return e.Wrap("admin", err)
+}
+
+// withEmail runs the command with the only argument: the user email.
+func (c cli) withEmail(ctx context.Context, args []string, fn func(context.Context, string) error) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+	return fn(ctx, args[0])
+}
+
+func (c cli) users(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("users", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	after := fs.String("after", "", "list users after the email")
+	limit := fs.Int("limit", 0, "maximum number of users, 0 means all")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return ErrUsage
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EMAIL\tVERIFIED\tDISABLED\tITEMS\tCREATED")
+
+	err := c.eachUser(ctx, *after, *limit, func(info service.UserInfo) {
+		fmt.Fprintf(w, "%s\t%t\t%t\t%d\t%s\n",
+			info.User.Email, info.User.IsEmailVerified, info.User.IsDisabled,
+			info.Usage.Items, info.User.CreatedAt.Format(time.RFC3339))
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func (c cli) user(ctx context.Context, email string) error {
+	info, err := c.service.GetUserInfo(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "email:\t%s\n", info.User.Email)
+	fmt.Fprintf(w, "verified:\t%t\n", info.User.IsEmailVerified)
+	if !info.User.VerificationRemindedAt.IsZero() {
+		fmt.Fprintf(w, "reminded:\t%s\n", info.User.VerificationRemindedAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "disabled:\t%t\n", info.User.IsDisabled)
+	fmt.Fprintf(w, "2fa:\t%t\n", info.User.IsTOTPEnabled)
+	fmt.Fprintf(w, "recovery key:\t%t\n", len(info.User.RecoveryKey) != 0)
+	fmt.Fprintf(w, "certificate:\t%s\n", orNone(info.User.CertFingerprint))
+	fmt.Fprintf(w, "created:\t%s\n", info.User.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "items:\t%d\n", info.Usage.Items)
+	fmt.Fprintf(w, "deleted items:\t%d\n", info.Usage.DeletedItems)
+	fmt.Fprintf(w, "large items:\t%d\n", info.Usage.LargeItems)
+	fmt.Fprintf(w, "size:\t%d\n", info.Usage.Size)
+	fmt.Fprintf(w, "quota bytes:\t%s\n", orDefault(info.User.Quota.MaxBytes))
+	fmt.Fprintf(w, "quota items:\t%s\n", orDefault(info.User.Quota.MaxItems))
+
+	return w.Flush()
+}
+
+func (c cli) verify(ctx context.Context, email string) error {
+	if err := c.service.VerifyUserEmail(ctx, email); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "user %s verified\n", email)
+	return nil
+}
+
+func (c cli) disable(ctx context.Context, email string) error {
+	if err := c.service.SetUserDisabled(ctx, email, true); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "user %s disabled\n", email)
+	return nil
+}
+
+func (c cli) enable(ctx context.Context, email string) error {
+	if err := c.service.SetUserDisabled(ctx, email, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "user %s enabled\n", email)
+	return nil
+}
+
+func (c cli) expireSessions(ctx context.Context, email string) error {
+	if err := c.service.ExpireUserSessions(ctx, email); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "sessions of user %s expired\n", email)
+	return nil
+}
+
+func (c cli) quota(ctx context.Context, args []string) error {
+	if len(args) != 3 {
+		return ErrUsage
+	}
+	email := args[0]
+	maxBytes, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return ErrUsage
+	}
+	maxItems, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return ErrUsage
+	}
+
+	if err := c.service.SetUserQuota(ctx, email, user.Quota{
+		MaxBytes: maxBytes,
+		MaxItems: maxItems,
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "quota of user %s set\n", email)
+	return nil
+}
+
+func (c cli) usage(ctx context.Context) error {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EMAIL\tITEMS\tDELETED\tLARGE\tSIZE")
+
+	var total service.UserInfo
+	var users int
+	err := c.eachUser(ctx, "", 0, func(info service.UserInfo) {
+		users++
+		total.Usage.Items += info.Usage.Items
+		total.Usage.DeletedItems += info.Usage.DeletedItems
+		total.Usage.LargeItems += info.Usage.LargeItems
+		total.Usage.Size += info.Usage.Size
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", info.User.Email,
+			info.Usage.Items, info.Usage.DeletedItems, info.Usage.LargeItems, info.Usage.Size)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "total (%d users)\t%d\t%d\t%d\t%d\n", users,
+		total.Usage.Items, total.Usage.DeletedItems, total.Usage.LargeItems, total.Usage.Size)
+
+	return w.Flush()
+}
+
+// eachUser calls fn for every user after the given email page by page, limit 0 means all users.
+func (c cli) eachUser(ctx context.Context, after string, limit int, fn func(service.UserInfo)) error {
+	for count := 0; limit == 0 || count < limit; {
+		size := usersPageSize
+		if limit != 0 && limit-count < size {
+			size = limit - count
+		}
+
+		infos, err := c.service.ListUsers(ctx, after, size)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			fn(info)
+		}
+
+		count += len(infos)
+		if len(infos) < size {
+			return nil
+		}
+		after = infos[len(infos)-1].User.Email
+	}
+	return nil
+}
+
+func orNone(s string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return s
+}
+
+func orDefault(n int64) string {
+	if n == 0 {
+		return "default"
+	}
+	return strconv.FormatInt(n, 10)
+}
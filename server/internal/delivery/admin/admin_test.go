@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+// fakeService keeps the users sorted by email and records the calls of the commands.
+type fakeService struct {
+	infos    []service.UserInfo
+	listed   int
+	verified []string
+	disabled map[string]bool
+	expired  []string
+	quotas   map[string]user.Quota
+}
+
+func newFakeService(n int) *fakeService {
+	s := &fakeService{
+		disabled: make(map[string]bool),
+		quotas:   make(map[string]user.Quota),
+	}
+	for i := 0; i < n; i++ {
+		s.infos = append(s.infos, service.UserInfo{
+			User:  user.User{Email: fmt.Sprintf("user%04d@example.com", i)},
+			Usage: user.Usage{Items: 2, DeletedItems: 1, LargeItems: 1, Size: 10},
+		})
+	}
+	return s
+}
+
+func (s *fakeService) ListUsers(_ context.Context, after string, limit int) ([]service.UserInfo, error) {
+	s.listed++
+	res := make([]service.UserInfo, 0)
+	for _, info := range s.infos {
+		if info.User.Email > after && (limit == 0 || len(res) < limit) {
+			res = append(res, info)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeService) GetUserInfo(_ context.Context, email string) (service.UserInfo, error) {
+	for _, info := range s.infos {
+		if info.User.Email == email {
+			return info, nil
+		}
+	}
+	return service.UserInfo{}, service.ErrUserNotExists
+}
+
+func (s *fakeService) VerifyUserEmail(_ context.Context, email string) error {
+	s.verified = append(s.verified, email)
+	return nil
+}
+
+func (s *fakeService) SetUserDisabled(_ context.Context, email string, disabled bool) error {
+	s.disabled[email] = disabled
+	return nil
+}
+
+func (s *fakeService) ExpireUserSessions(_ context.Context, email string) error {
+	s.expired = append(s.expired, email)
+	return nil
+}
+
+func (s *fakeService) SetUserQuota(_ context.Context, email string, quota user.Quota) error {
+	s.quotas[email] = quota
+	return nil
+}
+
+func TestRunInvalidUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"unknown"}},
+		{name: "no email", args: []string{"verify"}},
+		{name: "extra argument", args: []string{"disable", "a@example.com", "b@example.com"}},
+		{name: "unknown flag", args: []string{"users", "-unknown"}},
+		{name: "not a number quota", args: []string{"quota", "a@example.com", "many", "10"}},
+		{name: "missing quota", args: []string{"quota", "a@example.com", "10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(context.Background(), newFakeService(0), tt.args, &out)
+			assert.ErrorIs(t, err, ErrUsage)
+			assert.Contains(t, out.String(), "Usage:")
+		})
+	}
+}
+
+func TestRunUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all users page by page", func(t *testing.T) {
+		s := newFakeService(usersPageSize + 5)
+		var out bytes.Buffer
+		require.NoError(t, Run(ctx, s, []string{"users"}, &out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, usersPageSize+5+1, "header and all users")
+		assert.Equal(t, 2, s.listed)
+	})
+
+	t.Run("limit after email", func(t *testing.T) {
+		s := newFakeService(10)
+		var out bytes.Buffer
+		require.NoError(t, Run(ctx, s, []string{"users", "-after", s.infos[2].User.Email, "-limit", "3"}, &out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 4)
+		assert.True(t, strings.HasPrefix(lines[1], s.infos[3].User.Email))
+		assert.True(t, strings.HasPrefix(lines[3], s.infos[5].User.Email))
+	})
+}
+
+func TestRunUsageTotal(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Run(context.Background(), newFakeService(3), []string{"usage"}, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, []string{"total", "(3", "users)", "6", "3", "3", "30"}, strings.Fields(lines[4]))
+}
+
+func TestRunUser(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeService(1)
+	email := s.infos[0].User.Email
+
+	var out bytes.Buffer
+	require.NoError(t, Run(ctx, s, []string{"user", email}, &out))
+	assert.Contains(t, out.String(), email)
+	assert.Regexp(t, `certificate:\s+none`, out.String())
+	assert.Regexp(t, `quota bytes:\s+default`, out.String())
+
+	err := Run(ctx, s, []string{"user", "unknown@example.com"}, &out)
+	assert.ErrorIs(t, err, service.ErrUserNotExists)
+}
+
+func TestRunAccountCommands(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s := newFakeService(0)
+	var out bytes.Buffer
+
+	require.NoError(t, Run(ctx, s, []string{"verify", email}, &out))
+	assert.Equal(t, []string{email}, s.verified)
+
+	require.NoError(t, Run(ctx, s, []string{"disable", email}, &out))
+	assert.True(t, s.disabled[email])
+	require.NoError(t, Run(ctx, s, []string{"enable", email}, &out))
+	assert.False(t, s.disabled[email])
+
+	require.NoError(t, Run(ctx, s, []string{"expire-sessions", email}, &out))
+	assert.Equal(t, []string{email}, s.expired)
+
+	require.NoError(t, Run(ctx, s, []string{"quota", email, "1024", "0"}, &out))
+	assert.Equal(t, user.Quota{MaxBytes: 1024}, s.quotas[email])
+}
@@ -0,0 +1,202 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"log/slog"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	gcfg "github.com/Karzoug/goph_keeper/server/internal/config/grpc"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+
+	authorizationHeader = "Authorization"
+	bearerPreffix       = "Bearer "
+	// tokenMetadataKey is the metadata key of the token checked by the auth interceptor of the gRPC server.
+	tokenMetadataKey     = "token"
+	requestIDMetadataKey = "x-request-id"
+)
+
+type server struct {
+	cfg    gcfg.Config
+	logger *slog.Logger
+	// secret is shared with the gRPC server, it marks the calls of the gateway
+	// to trust the client address forwarded by it
+	secret string
+
+	httpServer *http.Server
+}
+
+// New creates REST/JSON gateway to the gRPC server, it serves the routes
+// of keeper.proto annotated with google.api.http under /v1.
+// The secret must be passed to the gRPC server with grpc.WithGatewaySecret.
+func New(cfg gcfg.Config, secret string, logger *slog.Logger) *server {
+	return &server{
+		cfg:    cfg,
+		logger: logger.With("from", "gateway server"),
+		secret: secret,
+		httpServer: &http.Server{
+			Addr:              cfg.GatewayAddress(),
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+func (s *server) Run(ctx context.Context) error {
+	const op = "gateway server: run"
+
+	creds, err := loadClientCredentials(s.cfg)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if len(s.cfg.ClientCAFileName) != 0 {
+		// the gateway requires client certificates the same way as the gRPC server
+		certPool, err := gcfg.LoadCertPool(s.cfg.ClientCAFileName)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		s.httpServer.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS13,
+			ClientCAs:  certPool,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithMetadata(tokenFromAuthorizationHeader),
+		runtime.WithMetadata(s.forwardedMetadata),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	err = pb.RegisterGophKeeperServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint(s.cfg),
+		[]grpc.DialOption{grpc.WithTransportCredentials(creds)})
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	s.httpServer.Handler = mux
+
+	s.logger.Info("running", slog.String("address", s.cfg.GatewayAddress()))
+
+	idleConnsClosed := make(chan struct{})
+
+	go func() {
+		<-ctx.Done()
+		s.shutdown()
+		close(idleConnsClosed)
+	}()
+
+	err = s.httpServer.ListenAndServeTLS(s.cfg.CertFileName, s.cfg.KeyFileName)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return e.Wrap(op, err)
+	}
+
+	<-idleConnsClosed
+
+	return nil
+}
+
+func (s *server) shutdown() {
+	s.logger.Info("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("shutdown failed", sl.Error(err))
+	}
+}
+
+// tokenFromAuthorizationHeader passes the bearer token of the Authorization header
+// as the token metadata expected by the gRPC server.
+func tokenFromAuthorizationHeader(_ context.Context, r *http.Request) metadata.MD {
+	h := r.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(h, bearerPreffix) {
+		return nil
+	}
+	return metadata.Pairs(tokenMetadataKey, strings.TrimPrefix(h, bearerPreffix))
+}
+
+// forwardedMetadata marks the call as made by the gateway and passes the fingerprint of the client certificate,
+// the gRPC server sees the certificate of the gateway itself.
+func (s *server) forwardedMetadata(_ context.Context, r *http.Request) metadata.MD {
+	md := metadata.Pairs(forwarded.SecretMetadataKey, s.secret)
+	if r.TLS != nil && len(r.TLS.PeerCertificates) != 0 {
+		md.Set(forwarded.CertFingerprintMetadataKey, forwarded.CertFingerprint(r.TLS.PeerCertificates[0].Raw))
+	}
+	return md
+}
+
+// incomingHeaderMatcher passes the request ID to the gRPC server, the Authorization header
+// is passed as the token metadata only. The metadata set by the gateway itself can not be passed by the client.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case authorizationHeader:
+		return "", false
+	case textproto.CanonicalMIMEHeaderKey(requestIDMetadataKey):
+		return requestIDMetadataKey, true
+	default:
+		mdKey, ok := runtime.DefaultHeaderMatcher(key)
+		if !ok || forwarded.IsForwardedKey(mdKey) {
+			return "", false
+		}
+		return mdKey, true
+	}
+}
+
+// outgoingHeaderMatcher returns the request ID by the same header as it is passed.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == requestIDMetadataKey {
+		return textproto.CanonicalMIMEHeaderKey(requestIDMetadataKey), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// grpcEndpoint returns the address of the gRPC server for the gateway,
+// it is localhost if the server listens on all interfaces.
+func grpcEndpoint(cfg gcfg.Config) string {
+	if len(cfg.Host) == 0 {
+		return "localhost:" + cfg.Port
+	}
+	return cfg.Address()
+}
+
+// loadClientCredentials returns TLS credentials for the connection to the gRPC server,
+// the server certificate is trusted as is. If the server requires client certificates,
+// the gateway presents the server certificate, the certificate of the gateway client is forwarded in the metadata.
+func loadClientCredentials(cfg gcfg.Config) (credentials.TransportCredentials, error) {
+	certPool, err := gcfg.LoadCertPool(cfg.CertFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		RootCAs:    certPool,
+	}
+	if len(cfg.ClientCAFileName) != 0 {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFileName, cfg.KeyFileName)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// healthCheckTimeout is the timeout of the one dependency check.
+const healthCheckTimeout = 5 * time.Second
+
+// HealthCheckFunc checks the server dependency, e.g. pings the storage.
+type HealthCheckFunc func(ctx context.Context) error
+
+// WithHealthCheck adds the check of the server dependency reported by the gRPC health service
+// with the given service name. The server (empty service name) is serving only if all checks pass.
+func WithHealthCheck(name string, check HealthCheckFunc) Option {
+	return func(s *server) {
+		s.healthChecks[name] = check
+	}
+}
+
+// runHealthChecks periodically runs the dependency checks and updates the health service statuses
+// until ctx is done.
+func (s *server) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.checkHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *server) checkHealth(ctx context.Context) {
+	const op = "health check"
+
+	serving := true
+	for name, check := range s.healthChecks {
+		status := healthpb.HealthCheckResponse_SERVING
+
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := check(checkCtx)
+		cancel()
+		if err != nil {
+			// shutdown is not a failure of the dependency
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn(op, slog.String("service", name), sl.Error(err))
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			serving = false
+		}
+
+		s.health.SetServingStatus(name, status)
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.health.SetServingStatus("", status)
+}
@@ -11,27 +11,31 @@ import (
 
 	gerr "github.com/Karzoug/goph_keeper/common/grpc"
 	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/logging"
 	"github.com/Karzoug/goph_keeper/server/internal/service"
 )
 
 type (
 	authContextKey int8
-	AuthFunc       func(ctx context.Context, token string) (string, error)
+	// AuthFunc verifies the token used with the client certificate and returns the email of the user.
+	AuthFunc func(ctx context.Context, token, certFingerprint string) (string, error)
 )
 
-const emailAuthCtxKey authContextKey = 0
+const (
+	emailAuthCtxKey authContextKey = iota
+	tokenAuthCtxKey
+)
 
-var ErrCtxEmailNotFound = errors.New("email not found in context")
+var (
+	ErrCtxEmailNotFound = errors.New("email not found in context")
+	ErrCtxTokenNotFound = errors.New("token not found in context")
+)
 
+// AuthUnaryServerInterceptor authenticates the user by the token from the metadata
+// and puts the email and the token to the context. Public methods are not authenticated.
 func AuthUnaryServerInterceptor(authFunc AuthFunc, publicMethods []string, logger *slog.Logger) grpc.UnaryServerInterceptor {
-	isPublicMethodCheckFnc := func(m string) bool {
-		for i := 0; i < len(publicMethods); i++ {
-			if m == publicMethods[i] {
-				return true
-			}
-		}
-		return false
-	}
+	isPublicMethodCheckFnc := isPublicMethodCheckFunc(publicMethods)
 
 	logger = logger.With("from", "auth grpc interceptor")
 
@@ -40,32 +44,75 @@ func AuthUnaryServerInterceptor(authFunc AuthFunc, publicMethods []string, logge
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, gerr.ErrEmptyAuthData
+		newCtx, err := authenticate(ctx, authFunc, logger)
+		if err != nil {
+			return nil, err
 		}
+		return handler(newCtx, req)
+	}
+}
+
+// AuthStreamServerInterceptor is the same as AuthUnaryServerInterceptor for streaming RPCs:
+// the email and the token are put to the stream context.
+func AuthStreamServerInterceptor(authFunc AuthFunc, publicMethods []string, logger *slog.Logger) grpc.StreamServerInterceptor {
+	isPublicMethodCheckFnc := isPublicMethodCheckFunc(publicMethods)
+
+	logger = logger.With("from", "auth grpc interceptor")
 
-		tokenSlice := md["token"]
-		if len(tokenSlice) == 0 {
-			return nil, gerr.ErrEmptyAuthData
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublicMethodCheckFnc(info.FullMethod) {
+			return handler(srv, ss)
 		}
 
-		email, err := authFunc(ctx, tokenSlice[0])
+		newCtx, err := authenticate(ss.Context(), authFunc, logger)
 		if err != nil {
-			switch {
-			case errors.Is(err, service.ErrInvalidTokenFormat):
-				return nil, gerr.ErrInvalidTokenFormat
-			case errors.Is(err, service.ErrUserNeedAuthentication):
-				return nil, gerr.ErrUserNeedAuthentication
-			default:
-				logger.Error("auth user failed", sl.Error(err))
-				return nil, gerr.ErrInternal
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+func isPublicMethodCheckFunc(publicMethods []string) func(string) bool {
+	return func(m string) bool {
+		for i := 0; i < len(publicMethods); i++ {
+			if m == publicMethods[i] {
+				return true
 			}
 		}
+		return false
+	}
+}
 
-		newCtx := context.WithValue(ctx, emailAuthCtxKey, email)
-		return handler(newCtx, req)
+// authenticate verifies the token from the metadata and returns a copy of ctx with the email and the token.
+func authenticate(ctx context.Context, authFunc AuthFunc, logger *slog.Logger) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, gerr.ErrEmptyAuthData
 	}
+
+	tokenSlice := md["token"]
+	if len(tokenSlice) == 0 {
+		return nil, gerr.ErrEmptyAuthData
+	}
+
+	email, err := authFunc(ctx, tokenSlice[0], forwarded.CertFingerprintFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTokenFormat):
+			return nil, gerr.ErrInvalidTokenFormat
+		case errors.Is(err, service.ErrUserNeedAuthentication):
+			return nil, gerr.ErrUserNeedAuthentication
+		default:
+			logger.ErrorContext(ctx, "auth user failed", sl.Error(err))
+			return nil, gerr.ErrInternal
+		}
+	}
+
+	logging.SetUserEmail(ctx, email)
+
+	newCtx := context.WithValue(ctx, emailAuthCtxKey, email)
+	newCtx = context.WithValue(newCtx, tokenAuthCtxKey, tokenSlice[0])
+	return newCtx, nil
 }
 
 func EmailFromContext(ctx context.Context) (string, error) {
@@ -76,3 +123,22 @@ func EmailFromContext(ctx context.Context) (string, error) {
 
 	return value.(string), nil
 }
+
+func TokenFromContext(ctx context.Context) (string, error) {
+	value := ctx.Value(tokenAuthCtxKey)
+	if value == nil {
+		return "", ErrCtxTokenNotFound
+	}
+
+	return value.(string), nil
+}
+
+// serverStream is grpc.ServerStream with the context containing the email and the token.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,145 @@
+package forwarded
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// SecretMetadataKey is the metadata key of the secret shared by the REST gateway and the gRPC server,
+	// the forwarded metadata is trusted only on the calls with this secret.
+	SecretMetadataKey = "x-gateway-secret"
+	// ForMetadataKey is the metadata key of the gateway client address appended by the gateway.
+	ForMetadataKey = "x-forwarded-for"
+	// CertFingerprintMetadataKey is the metadata key of the gateway client certificate fingerprint,
+	// it is not set if the client has no certificate.
+	CertFingerprintMetadataKey = "x-forwarded-client-cert"
+)
+
+type certFingerprintContextKey struct{}
+
+// NewSecret returns a random secret to share between the gateway and the gRPC server of one process.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// IsForwardedKey reports whether the metadata key is set by the gateway itself,
+// the gateway must not pass such keys from the client headers.
+func IsForwardedKey(key string) bool {
+	switch strings.ToLower(key) {
+	case SecretMetadataKey, ForMetadataKey, CertFingerprintMetadataKey:
+		return true
+	}
+	return false
+}
+
+// ForwardedUnaryServerInterceptor replaces the peer of the calls made by the local REST gateway
+// with the gateway client, so the next interceptors and handlers see the real client address
+// and certificate (see CertFingerprintFromContext).
+// The calls are recognized by the secret, the empty secret means that no calls are forwarded.
+func ForwardedUnaryServerInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(newContext(ctx, secret), req)
+	}
+}
+
+// ForwardedStreamServerInterceptor is the same as ForwardedUnaryServerInterceptor for streaming RPCs.
+func ForwardedStreamServerInterceptor(secret string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: newContext(ss.Context(), secret)})
+	}
+}
+
+// CertFingerprint returns the SHA-256 fingerprint of the DER encoded certificate.
+func CertFingerprint(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// CertFingerprintFromContext returns the fingerprint of the client certificate verified on the TLS handshake,
+// for the calls of the gateway it is the certificate of the gateway client.
+// It is empty if the client has no certificate.
+func CertFingerprintFromContext(ctx context.Context) string {
+	if fingerprint, ok := ctx.Value(certFingerprintContextKey{}).(string); ok {
+		return fingerprint
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return CertFingerprint(tlsInfo.State.PeerCertificates[0].Raw)
+}
+
+// newContext returns a copy of ctx with the peer and the certificate fingerprint of the gateway client
+// if the call is made by the gateway, otherwise it returns ctx as is.
+func newContext(ctx context.Context, secret string) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || !isGateway(md, secret) {
+		return ctx
+	}
+
+	var fingerprint string
+	if values := md.Get(CertFingerprintMetadataKey); len(values) == 1 {
+		fingerprint = values[0]
+	}
+	ctx = context.WithValue(ctx, certFingerprintContextKey{}, fingerprint)
+
+	if addr := forwardedFor(md); len(addr) != 0 {
+		// the port of the client is unknown
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr)}})
+	}
+	return ctx
+}
+
+func isGateway(md metadata.MD, secret string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	values := md.Get(SecretMetadataKey)
+	if len(values) != 1 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(values[0]), []byte(secret)) == 1
+}
+
+// forwardedFor returns the last address of x-forwarded-for metadata:
+// the gateway appends the address of its client, the previous ones are passed by the client
+// and can not be trusted.
+func forwardedFor(md metadata.MD) string {
+	values := md.Get(ForMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	addrs := strings.Split(values[len(values)-1], ",")
+	return strings.TrimSpace(addrs[len(addrs)-1])
+}
+
+// serverStream is grpc.ServerStream with the context containing the gateway client peer.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Karzoug/goph_keeper/server/internal/requestid"
+)
+
+// RequestIDMetadataKey is the metadata key of the request ID, the ID passed by the client is used
+// if it is valid, otherwise a new one is generated. The server returns the ID in the response header
+// and trailer, so it is available for failed calls too.
+const RequestIDMetadataKey = "x-request-id"
+
+const (
+	maxRequestIDLength   = 64
+	healthMethodsPreffix = "/grpc.health.v1.Health/"
+)
+
+type userEmailContextKey struct{}
+
+// emailRequest is a request with the email of the user, e.g. login request.
+type emailRequest interface {
+	GetEmail() string
+}
+
+// LoggingUnaryServerInterceptor assigns the request ID to the call and writes the access log record
+// with the method, hashed email of the user, peer address, status code and duration.
+// The email is hashed with the secret key, so it can not be found by hashing the known emails.
+func LoggingUnaryServerInterceptor(logger *slog.Logger, emailHashKey []byte) grpc.UnaryServerInterceptor {
+	logger = logger.With("from", "logging grpc interceptor")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		ctx, id, email := newRequestContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			_ = grpc.SetTrailer(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+		}
+
+		if len(*email) == 0 {
+			if r, ok := req.(emailRequest); ok {
+				*email = r.GetEmail()
+			}
+		}
+		log(ctx, logger, emailHashKey, info.FullMethod, *email, start, err)
+
+		return resp, err
+	}
+}
+
+// LoggingStreamServerInterceptor is the same as LoggingUnaryServerInterceptor for streaming RPCs.
+func LoggingStreamServerInterceptor(logger *slog.Logger, emailHashKey []byte) grpc.StreamServerInterceptor {
+	logger = logger.With("from", "logging grpc interceptor")
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		ctx, id, email := newRequestContext(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIDMetadataKey, id))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		if err != nil {
+			ss.SetTrailer(metadata.Pairs(RequestIDMetadataKey, id))
+		}
+
+		log(ctx, logger, emailHashKey, info.FullMethod, *email, start, err)
+
+		return err
+	}
+}
+
+// SetUserEmail sets the email of the authenticated user for the access log record of the call.
+func SetUserEmail(ctx context.Context, email string) {
+	if p, ok := ctx.Value(userEmailContextKey{}).(*string); ok {
+		*p = email
+	}
+}
+
+// newRequestContext returns a copy of ctx with the request ID and the placeholder of the user email.
+func newRequestContext(ctx context.Context) (context.Context, string, *string) {
+	id := requestIDFromMetadata(ctx)
+	if len(id) == 0 {
+		id = newRequestID()
+	}
+
+	email := new(string)
+	ctx = requestid.NewContext(ctx, id)
+	ctx = context.WithValue(ctx, userEmailContextKey{}, email)
+
+	return ctx, id, email
+}
+
+func log(ctx context.Context, logger *slog.Logger, emailHashKey []byte, method, email string, start time.Time, err error) {
+	code := status.Code(err)
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if len(email) != 0 {
+		attrs = append(attrs, slog.String("email_hash", hashEmail(emailHashKey, email)))
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case code == codes.Internal || code == codes.Unknown:
+		level = slog.LevelError
+	case strings.HasPrefix(method, healthMethodsPreffix):
+		// health checks are too frequent
+		level = slog.LevelDebug
+	}
+	logger.LogAttrs(ctx, level, "request handled", attrs...)
+}
+
+// requestIDFromMetadata returns the request ID passed by the client or empty string
+// if it is not passed or not valid.
+func requestIDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(RequestIDMetadataKey)
+	if len(values) == 0 || len(values[0]) > maxRequestIDLength {
+		return ""
+	}
+	for _, r := range values[0] {
+		if r < '!' || r > '~' {
+			return ""
+		}
+	}
+	return values[0]
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// hashEmail hides the email of the user in the logs, but it is still possible
+// to find all records of the user knowing the key.
+func hashEmail(key []byte, email string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// serverStream is grpc.ServerStream with the context containing the request ID.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gophkeeper",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Total number of RPCs completed on the server by method and status code.",
+	}, []string{"method", "code"})
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gophkeeper",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of RPCs handled by the server by method, for streams it is the stream lifetime.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// MetricsUnaryServerInterceptor counts unary RPCs and observes their latency.
+func MetricsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// MetricsStreamServerInterceptor counts streaming RPCs and observes their latency.
+func MetricsStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	requestsTotal.WithLabelValues(method, status.Code(err).String()).Inc()
+	requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	gerr "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+// Limiter counts failed authentication attempts by email and peer address.
+type Limiter interface {
+	// CheckAuthAttempts returns service.ErrTooManyAttempts if the email or the address is locked out.
+	CheckAuthAttempts(ctx context.Context, email, addr string) error
+	FailAuthAttempt(ctx context.Context, email, addr string)
+	ResetAuthAttempts(ctx context.Context, email string)
+}
+
+// emailRequest is a request with the email of the user, e.g. login request.
+type emailRequest interface {
+	GetEmail() string
+}
+
+// RateLimitUnaryServerInterceptor protects limited methods from brute-force: it rejects the call
+// with ErrTooManyAttempts while the email or the peer address is locked out
+// and counts the calls failed with one of the failures errors.
+func RateLimitUnaryServerInterceptor(limiter Limiter,
+	limitedMethods []string,
+	failures []error,
+	logger *slog.Logger,
+) grpc.UnaryServerInterceptor {
+	isLimitedMethodCheckFnc := func(m string) bool {
+		for i := 0; i < len(limitedMethods); i++ {
+			if m == limitedMethods[i] {
+				return true
+			}
+		}
+		return false
+	}
+	isFailureCheckFnc := func(err error) bool {
+		for i := 0; i < len(failures); i++ {
+			if errors.Is(err, failures[i]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	logger = logger.With("from", "rate limit grpc interceptor")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isLimitedMethodCheckFnc(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		var email string
+		if r, ok := req.(emailRequest); ok {
+			email = r.GetEmail()
+		}
+		addr := addrFromContext(ctx)
+
+		if err := limiter.CheckAuthAttempts(ctx, email, addr); err != nil {
+			if errors.Is(err, service.ErrTooManyAttempts) {
+				return nil, gerr.ErrTooManyAttempts
+			}
+			logger.ErrorContext(ctx, "check auth attempts failed", sl.Error(err))
+			return nil, gerr.ErrInternal
+		}
+
+		resp, err := handler(ctx, req)
+		switch {
+		case err == nil:
+			limiter.ResetAuthAttempts(ctx, email)
+		case isFailureCheckFnc(err):
+			limiter.FailAuthAttempt(ctx, email, addr)
+		}
+
+		return resp, err
+	}
+}
+
+// addrFromContext returns the peer IP without port or empty string if it is unknown.
+// For calls of the local REST gateway it is the address of the gateway client set by the forwarded interceptor.
+func addrFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return addr
+}
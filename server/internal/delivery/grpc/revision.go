@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+func (s *server) ListItemRevisions(ctx context.Context, req *pb.ListItemRevisionsRequest) (*pb.ListItemRevisionsResponse, error) {
+	const op = "list item revisions"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	items, err := s.service.ListItemRevisions(ctx, email, req.Id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, op, sl.Error(err))
+		return nil, pb.ErrInternal
+	}
+
+	pbItems := make([]*pb.VaultItem, len(items))
+	for i := 0; i < len(items); i++ {
+		pbItems[i] = &pb.VaultItem{
+			Id:              items[i].ID,
+			Name:            items[i].Name,
+			Itype:           pb.IType(items[i].Type),
+			Value:           items[i].Value,
+			ServerUpdatedAt: items[i].ServerUpdatedAt,
+		}
+	}
+
+	return &pb.ListItemRevisionsResponse{
+		Revisions: pbItems,
+	}, nil
+}
+
+func (s *server) RestoreItemRevision(ctx context.Context, req *pb.RestoreItemRevisionRequest) (*pb.RestoreItemRevisionResponse, error) {
+	const op = "restore item revision"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	t, err := s.service.RestoreItemRevision(ctx, email, req.Id, req.Revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVaultItemRevisionNotExists):
+			return nil, pb.ErrVaultItemRevisionNotExists
+		case errors.Is(err, service.ErrQuotaExceeded):
+			return nil, pb.ErrQuotaExceeded
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.RestoreItemRevisionResponse{
+		ServerUpdatedAt: t,
+	}, nil
+}
@@ -2,55 +2,130 @@ package grpc
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"net"
 
 	"log/slog"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
 	"github.com/Karzoug/goph_keeper/pkg/e"
 	gcfg "github.com/Karzoug/goph_keeper/server/internal/config/grpc"
 	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/logging"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/metrics"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/ratelimit"
 	"github.com/Karzoug/goph_keeper/server/internal/service"
 )
 
+// vaultItemChunkSize is a size of the vault item value chunk sent by stream,
+// it matches the size of the chunk encrypted by client: nonce + 32 KiB of data + tag.
+const vaultItemChunkSize = 24 + 32*1024 + 16
+
 type server struct {
 	cfg     gcfg.Config
 	logger  *slog.Logger
 	service *service.Service
 
 	grpcServer *grpc.Server
+	health     *health.Server
+	// healthChecks are checks of the server dependencies by name reported by the health service
+	healthChecks map[string]HealthCheckFunc
+	// gatewaySecret is shared with the local REST gateway to trust the client data forwarded by it,
+	// empty if the gateway is disabled
+	gatewaySecret string
+	// done is closed on shutdown to finish long-lived streams,
+	// otherwise graceful stop waits for them forever
+	done chan struct{}
 	pb.UnimplementedGophKeeperServiceServer
 }
 
-func New(cfg gcfg.Config, service *service.Service, logger *slog.Logger) (*server, error) {
+type Option func(*server)
+
+// WithGatewaySecret sets the secret of the local REST gateway calls,
+// the client address passed by the gateway is trusted only on the calls with the secret.
+func WithGatewaySecret(secret string) Option {
+	return func(s *server) {
+		s.gatewaySecret = secret
+	}
+}
+
+func New(cfg gcfg.Config, service *service.Service, logger *slog.Logger, options ...Option) (*server, error) {
 	const op = "create grpc server"
 
 	publicMethods := []string{
 		pb.GophKeeperService_Register_FullMethodName,
 		pb.GophKeeperService_Login_FullMethodName,
+		pb.GophKeeperService_ResendVerificationCode_FullMethodName,
+		pb.GophKeeperService_RefreshToken_FullMethodName,
+		pb.GophKeeperService_RecoverAccount_FullMethodName,
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
 	}
 
-	tlsCfg, err := loadConfig(cfg.CertFileName, cfg.KeyFileName)
+	tlsCfg, err := loadConfig(cfg.CertFileName, cfg.KeyFileName, cfg.ClientCAFileName)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
 
-	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCfg)),
-		grpc.UnaryInterceptor(
-			auth.AuthUnaryServerInterceptor(service.AuthUser, publicMethods, logger)))
+	emailHashKey := []byte(cfg.LogSecretKey)
+	if len(emailHashKey) == 0 {
+		emailHashKey = make([]byte, 32)
+		if _, err := rand.Read(emailHashKey); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	// methods where the user credentials can be guessed
+	limitedMethods := []string{
+		pb.GophKeeperService_Login_FullMethodName,
+		pb.GophKeeperService_ResendVerificationCode_FullMethodName,
+		pb.GophKeeperService_RecoverAccount_FullMethodName,
+	}
+	// only the guesses are counted: wrong email codes are limited by the code itself
+	authFailures := []error{
+		pb.ErrUserInvalidHash,
+		pb.ErrInvalidTOTPCode,
+	}
 
 	ss := &server{
-		cfg:        cfg,
-		logger:     logger.With("from", "grpc server"),
-		service:    service,
-		grpcServer: grpcServer,
+		cfg:          cfg,
+		logger:       logger.With("from", "grpc server"),
+		service:      service,
+		health:       health.NewServer(),
+		healthChecks: make(map[string]HealthCheckFunc),
+		done:         make(chan struct{}),
+	}
+
+	for _, opt := range options {
+		opt(ss)
 	}
 
+	ss.grpcServer = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCfg)),
+		// the span of the call is started from the trace context in the request metadata
+		grpc.ChainUnaryInterceptor(
+			otelgrpc.UnaryServerInterceptor(),
+			forwarded.ForwardedUnaryServerInterceptor(ss.gatewaySecret),
+			metrics.MetricsUnaryServerInterceptor(),
+			logging.LoggingUnaryServerInterceptor(logger, emailHashKey),
+			ratelimit.RateLimitUnaryServerInterceptor(service, limitedMethods, authFailures, logger),
+			auth.AuthUnaryServerInterceptor(service.AuthUser, publicMethods, logger)),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			forwarded.ForwardedStreamServerInterceptor(ss.gatewaySecret),
+			metrics.MetricsStreamServerInterceptor(),
+			logging.LoggingStreamServerInterceptor(logger, emailHashKey),
+			auth.AuthStreamServerInterceptor(service.AuthUser, publicMethods, logger)))
+
 	pb.RegisterGophKeeperServiceServer(ss.grpcServer, ss)
+	healthpb.RegisterHealthServer(ss.grpcServer, ss.health)
 
 	return ss, nil
 }
@@ -74,6 +149,8 @@ func (s *server) Run(ctx context.Context) error {
 		close(idleConnsClosed)
 	}()
 
+	go s.runHealthChecks(ctx)
+
 	if err := s.grpcServer.Serve(listen); err != nil {
 		return e.Wrap(op, err)
 	}
@@ -86,19 +163,36 @@ func (s *server) Run(ctx context.Context) error {
 func (s *server) shutdown() {
 	s.logger.Info("shutting down")
 
+	close(s.done)
+
+	s.health.Shutdown()
+
 	s.grpcServer.GracefulStop()
 }
 
 // loadConfig creates a new TLS config from the given certificate and key files.
-func loadConfig(certFilename, keyFilename string) (*tls.Config, error) {
+// If the client CA file is set, client certificates signed by the CA are required.
+func loadConfig(certFilename, keyFilename, clientCAFilename string) (*tls.Config, error) {
 	serverCert, err := tls.LoadX509KeyPair(certFilename, keyFilename)
 	if err != nil {
 		return nil, err
 	}
 
-	return &tls.Config{
+	cfg := &tls.Config{
 		MinVersion:   tls.VersionTLS13,
 		Certificates: []tls.Certificate{serverCert},
 		ClientAuth:   tls.NoClientCert,
-	}, nil
+	}
+	if clientCAFilename == "" {
+		return cfg, nil
+	}
+
+	certPool, err := gcfg.LoadCertPool(clientCAFilename)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ClientCAs = certPool
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return cfg, nil
 }
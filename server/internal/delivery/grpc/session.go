@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
+	"github.com/Karzoug/goph_keeper/server/internal/model/session"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+const (
+	deviceNameMetadataKey    = "device-name"
+	clientVersionMetadataKey = "client-version"
+)
+
+func (s *server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	const op = "list sessions"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	sessions, currentID, err := s.service.ListSessions(ctx, email, token)
+	if err != nil {
+		s.logger.ErrorContext(ctx, op, sl.Error(err))
+		return nil, pb.ErrInternal
+	}
+
+	pbSessions := make([]*pb.Session, len(sessions))
+	for i := 0; i < len(sessions); i++ {
+		pbSessions[i] = &pb.Session{
+			Id:            sessions[i].ID,
+			DeviceName:    sessions[i].Device.Name,
+			ClientVersion: sessions[i].Device.ClientVersion,
+			Ip:            sessions[i].Device.IP,
+			CreatedAt:     sessions[i].CreatedAt.UnixMicro(),
+			Current:       sessions[i].ID == currentID,
+		}
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: pbSessions,
+	}, nil
+}
+
+func (s *server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	const op = "revoke session"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	if err := s.service.RevokeSession(ctx, email, req.Id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrSessionNotExists):
+			return nil, pb.ErrSessionNotExists
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+// deviceFromContext returns the client device described by request metadata and peer address.
+func deviceFromContext(ctx context.Context) session.Device {
+	var name, clientVersion, ip string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(deviceNameMetadataKey); len(v) != 0 {
+			name = v[0]
+		}
+		if v := md.Get(clientVersionMetadataKey); len(v) != 0 {
+			clientVersion = v[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	device := session.NewDevice(name, clientVersion, ip)
+	device.CertFingerprint = forwarded.CertFingerprintFromContext(ctx)
+
+	return device
+}
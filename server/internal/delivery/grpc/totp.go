@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/service"
+)
+
+func (s *server) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	const op = "enroll totp"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	secret, uri, err := s.service.EnrollTOTP(ctx, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		case errors.Is(err, service.ErrTOTPAlreadyEnabled):
+			return nil, pb.ErrTOTPAlreadyEnabled
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret: secret.String(),
+		Uri:    uri,
+	}, nil
+}
+
+func (s *server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	const op = "confirm totp"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	codes, err := s.service.ConfirmTOTP(ctx, email, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		case errors.Is(err, service.ErrTOTPAlreadyEnabled):
+			return nil, pb.ErrTOTPAlreadyEnabled
+		case errors.Is(err, service.ErrTOTPNotEnrolled):
+			return nil, pb.ErrTOTPNotEnrolled
+		case errors.Is(err, service.ErrInvalidTOTPCode):
+			return nil, pb.ErrInvalidTOTPCode
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
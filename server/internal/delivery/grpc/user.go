@@ -3,25 +3,32 @@ package grpc
 import (
 	"context"
 	"errors"
+	"io"
 
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/delivery/grpc/interceptor/forwarded"
 	"github.com/Karzoug/goph_keeper/server/internal/service"
 )
 
 func (s *server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	const op = "register user"
 
-	if err := s.service.Register(ctx, req.Email, req.Hash); err != nil {
+	if err := s.service.Register(ctx, req.Email, req.Hash, req.WrappedVaultKey,
+		req.RecoveryHash, req.RecoveryWrappedVaultKey); err != nil {
 		switch {
 		case errors.Is(err, service.ErrInvalidEmailFormat):
 			return nil, pb.ErrInvalidEmailFormat
 		case errors.Is(err, service.ErrInvalidHashFormat):
 			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrInvalidVaultKeyFormat):
+			return nil, pb.ErrInvalidVaultKeyFormat
 		case errors.Is(err, service.ErrUserAlreadyExists):
 			return nil, pb.ErrUserAlreadyExists
 		default:
-			s.logger.Error(op, sl.Error(err))
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
 			return nil, pb.ErrInternal
 		}
 	}
@@ -33,13 +40,15 @@ func (s *server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 	const op = "login user"
 
 	var (
-		token string
-		err   error
+		tokens          service.AuthTokens
+		wrappedVaultKey []byte
+		err             error
 	)
+	device := deviceFromContext(ctx)
 	if req.EmailCode != "" {
-		token, err = s.service.LoginWithEmailCode(ctx, req.Email, req.Hash, req.EmailCode)
+		tokens, wrappedVaultKey, err = s.service.LoginWithEmailCode(ctx, req.Email, req.Hash, req.EmailCode, device)
 	} else {
-		token, err = s.service.Login(ctx, req.Email, req.Hash)
+		tokens, wrappedVaultKey, err = s.service.Login(ctx, req.Email, req.Hash, req.TotpCode, device)
 	}
 
 	if err != nil {
@@ -52,13 +61,362 @@ func (s *server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 			return nil, pb.ErrUserInvalidHash
 		case errors.Is(err, service.ErrUserEmailNotVerified):
 			return nil, pb.ErrUserEmailNotVerified
+		case errors.Is(err, service.ErrUserDisabled):
+			return nil, pb.ErrUserDisabled
 		case errors.Is(err, service.ErrUserNotExists):
 			return nil, pb.ErrUserNotExists
+		case errors.Is(err, service.ErrTOTPRequired):
+			return nil, pb.ErrTOTPRequired
+		case errors.Is(err, service.ErrInvalidTOTPCode):
+			return nil, pb.ErrInvalidTOTPCode
+		case errors.Is(err, service.ErrCertificateMismatch):
+			return nil, pb.ErrClientCertificateMismatch
 		default:
-			s.logger.Error(op, sl.Error(err))
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
 			return nil, pb.ErrInternal
 		}
 	}
 
-	return &pb.LoginResponse{Token: token}, nil
+	return &pb.LoginResponse{
+		Token:           tokens.Token,
+		RefreshToken:    tokens.RefreshToken,
+		TokenLifetime:   int64(tokens.TokenLifetime.Seconds()),
+		WrappedVaultKey: wrappedVaultKey,
+	}, nil
+}
+
+func (s *server) ResendVerificationCode(ctx context.Context, req *pb.ResendVerificationCodeRequest) (*pb.ResendVerificationCodeResponse, error) {
+	const op = "resend verification code"
+
+	if err := s.service.ResendVerificationCode(ctx, req.Email, req.Hash); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidEmailFormat):
+			return nil, pb.ErrInvalidEmailFormat
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return nil, pb.ErrUserInvalidHash
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		case errors.Is(err, service.ErrUserEmailAlreadyVerified):
+			return nil, pb.ErrUserEmailAlreadyVerified
+		case errors.Is(err, service.ErrUserDisabled):
+			return nil, pb.ErrUserDisabled
+		case errors.Is(err, service.ErrVerificationCodeResent):
+			return nil, pb.ErrVerificationCodeResent
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.ResendVerificationCodeResponse{}, nil
+}
+
+func (s *server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	const op = "refresh token"
+
+	tokens, err := s.service.RefreshToken(ctx, req.RefreshToken, forwarded.CertFingerprintFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTokenFormat):
+			return nil, pb.ErrInvalidTokenFormat
+		case errors.Is(err, service.ErrUserNeedAuthentication):
+			return nil, pb.ErrUserNeedAuthentication
+		case errors.Is(err, service.ErrUserDisabled):
+			return nil, pb.ErrUserDisabled
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:         tokens.Token,
+		RefreshToken:  tokens.RefreshToken,
+		TokenLifetime: int64(tokens.TokenLifetime.Seconds()),
+	}, nil
+}
+
+func (s *server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	const op = "logout user"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	if err := s.service.Logout(ctx, email, token); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTokenFormat):
+			return nil, pb.ErrInvalidTokenFormat
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+func (s *server) ChangePassword(stream pb.GophKeeperService_ChangePasswordServer) error {
+	const op = "change password"
+
+	ctx := stream.Context()
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return pb.ErrEmptyAuthData
+	}
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return pb.ErrEmptyAuthData
+	}
+
+	// the first message must contain the hashes of the old and new passwords
+	req, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return pb.ErrInvalidHashFormat
+		}
+		return err
+	}
+	hashes := req.GetHashes()
+	if hashes == nil {
+		return pb.ErrInvalidHashFormat
+	}
+
+	it := &changePasswordIterator{stream: stream}
+	t, err := s.service.ChangePassword(ctx, email, token,
+		hashes.Hash, hashes.NewHash, hashes.NewWrappedVaultKey, it.next)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrInvalidVaultKeyFormat):
+			return pb.ErrInvalidVaultKeyFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return pb.ErrUserInvalidHash
+		case errors.Is(err, errEmptyVaultItem):
+			return pb.ErrEmptyVaultItem
+		case errors.Is(err, service.ErrVaultItemVersionConflict):
+			return pb.ErrVaultItemConflictVersion
+		case errors.Is(err, service.ErrVaultItemValueTooBig):
+			return pb.ErrVaultItemValueTooBig
+		case errors.Is(err, service.ErrVaultItemWrongType):
+			return pb.ErrVaultItemWrongType
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return pb.ErrInternal
+		}
+	}
+
+	return stream.SendAndClose(&pb.ChangePasswordResponse{
+		ServerUpdatedAt: t,
+	})
+}
+
+func (s *server) RecoverAccount(ctx context.Context, req *pb.RecoverAccountRequest) (*pb.RecoverAccountResponse, error) {
+	const op = "recover account"
+
+	wrapped, err := s.service.RecoverAccount(ctx, req.Email, req.RecoveryHash, req.NewHash, req.NewWrappedVaultKey)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrInvalidVaultKeyFormat):
+			return nil, pb.ErrInvalidVaultKeyFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return nil, pb.ErrUserInvalidHash
+		case errors.Is(err, service.ErrUserEmailNotVerified):
+			return nil, pb.ErrUserEmailNotVerified
+		case errors.Is(err, service.ErrUserDisabled):
+			return nil, pb.ErrUserDisabled
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.RecoverAccountResponse{
+		RecoveryWrappedVaultKey: wrapped,
+	}, nil
+}
+
+func (s *server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	const op = "delete account"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	if err := s.service.DeleteAccount(ctx, email, req.Hash); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return nil, pb.ErrUserInvalidHash
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (s *server) BindCertificate(ctx context.Context, req *pb.BindCertificateRequest) (*pb.BindCertificateResponse, error) {
+	const op = "bind certificate"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	fingerprint := forwarded.CertFingerprintFromContext(ctx)
+	if err := s.service.BindCertificate(ctx, email, req.Hash, fingerprint, token); err != nil {
+		switch {
+		case errors.Is(err, service.ErrCertificateRequired):
+			return nil, pb.ErrClientCertificateRequired
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return nil, pb.ErrUserInvalidHash
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.BindCertificateResponse{
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+func (s *server) UnbindCertificate(ctx context.Context, req *pb.UnbindCertificateRequest) (*pb.UnbindCertificateResponse, error) {
+	const op = "unbind certificate"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	if err := s.service.UnbindCertificate(ctx, email, req.Hash); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidHashFormat):
+			return nil, pb.ErrInvalidHashFormat
+		case errors.Is(err, service.ErrUserInvalidHash):
+			return nil, pb.ErrUserInvalidHash
+		case errors.Is(err, service.ErrUserNotExists):
+			return nil, pb.ErrUserNotExists
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.UnbindCertificateResponse{}, nil
+}
+
+var errEmptyVaultItem = errors.New("chunk without vault item")
+
+// changePasswordIterator reads the vault items from the client change password stream:
+// each large binary item is followed by the chunks of its value.
+type changePasswordIterator struct {
+	stream pb.GophKeeperService_ChangePasswordServer
+	// pending is the item received while reading the chunks of the previous item
+	pending *pb.VaultItem
+	chunk   *changePasswordChunkReader
+}
+
+func (it *changePasswordIterator) next() (vault.Item, io.Reader, error) {
+	// skip the unread chunks of the previous large binary item
+	if it.chunk != nil {
+		if _, err := io.Copy(io.Discard, it.chunk); err != nil {
+			return vault.Item{}, nil, err
+		}
+		eof := it.chunk.eof
+		it.chunk = nil
+		if eof {
+			return vault.Item{}, nil, io.EOF
+		}
+	}
+
+	pbItem := it.pending
+	it.pending = nil
+	if pbItem == nil {
+		req, err := it.stream.Recv()
+		if err != nil {
+			return vault.Item{}, nil, err
+		}
+		pbItem = req.GetItem()
+		if pbItem == nil {
+			return vault.Item{}, nil, errEmptyVaultItem
+		}
+	}
+
+	item := vault.Item{
+		ID:              pbItem.Id,
+		Name:            pbItem.Name,
+		Type:            vault.ItemType(pbItem.Itype),
+		Value:           pbItem.Value,
+		ServerUpdatedAt: pbItem.ServerUpdatedAt,
+		IsDeleted:       pbItem.IsDeleted,
+	}
+	if item.Type != vault.BinaryLarge {
+		return item, nil, nil
+	}
+
+	it.chunk = &changePasswordChunkReader{it: it}
+	return item, it.chunk, nil
+}
+
+// changePasswordChunkReader reads the value of the large binary item from the chunks
+// until the next item message or the end of the stream.
+type changePasswordChunkReader struct {
+	it    *changePasswordIterator
+	chunk []byte
+	done  bool
+	eof   bool
+}
+
+func (r *changePasswordChunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		req, err := r.it.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				r.done, r.eof = true, true
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+		if pbItem := req.GetItem(); pbItem != nil {
+			r.it.pending = pbItem
+			r.done = true
+			return 0, io.EOF
+		}
+		r.chunk = req.GetChunk()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
 }
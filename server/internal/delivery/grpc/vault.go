@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"io"
 
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
 	"github.com/Karzoug/goph_keeper/common/model/vault"
@@ -18,10 +19,17 @@ func (s *server) ListVaultItems(ctx context.Context, req *pb.ListVaultItemsReque
 	if err != nil {
 		return nil, pb.ErrEmptyAuthData
 	}
-	items, err := s.service.ListVaultItems(ctx, email, req.Since)
+	items, nextPageToken, err := s.service.ListVaultItems(ctx, email, req.Since, int(req.PageSize), req.PageToken)
 	if err != nil {
-		s.logger.Error(op, sl.Error(err))
-		return nil, pb.ErrInternal
+		switch {
+		case errors.Is(err, service.ErrInvalidPageToken):
+			return nil, pb.ErrInvalidPageToken
+		case errors.Is(err, service.ErrFullResyncRequired):
+			return nil, pb.ErrFullResyncRequired
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return nil, pb.ErrInternal
+		}
 	}
 	pbItems := make([]*pb.VaultItem, len(items))
 	for i := 0; i < len(items); i++ {
@@ -31,10 +39,12 @@ func (s *server) ListVaultItems(ctx context.Context, req *pb.ListVaultItemsReque
 			Itype:           pb.IType(items[i].Type),
 			Value:           items[i].Value,
 			ServerUpdatedAt: items[i].ServerUpdatedAt,
+			IsDeleted:       items[i].IsDeleted,
 		}
 	}
 	return &pb.ListVaultItemsResponse{
-		Items: pbItems,
+		Items:         pbItems,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -51,6 +61,7 @@ func (s *server) SetVaultItem(ctx context.Context, req *pb.SetVaultItemRequest)
 		Type:            vault.ItemType(req.Item.Itype),
 		Value:           req.Item.Value,
 		ServerUpdatedAt: req.Item.ServerUpdatedAt,
+		IsDeleted:       req.Item.IsDeleted,
 	})
 	if err != nil {
 		switch {
@@ -58,8 +69,10 @@ func (s *server) SetVaultItem(ctx context.Context, req *pb.SetVaultItemRequest)
 			return nil, pb.ErrVaultItemConflictVersion
 		case errors.Is(err, service.ErrVaultItemValueTooBig):
 			return nil, pb.ErrVaultItemValueTooBig
+		case errors.Is(err, service.ErrQuotaExceeded):
+			return nil, pb.ErrQuotaExceeded
 		default:
-			s.logger.Error(op, sl.Error(err))
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
 			return nil, pb.ErrInternal
 		}
 	}
@@ -67,3 +80,244 @@ func (s *server) SetVaultItem(ctx context.Context, req *pb.SetVaultItemRequest)
 		ServerUpdatedAt: t,
 	}, nil
 }
+
+func (s *server) SetVaultItems(ctx context.Context, req *pb.SetVaultItemsRequest) (*pb.SetVaultItemsResponse, error) {
+	const op = "set vault items"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	items := make([]vault.Item, len(req.Items))
+	for i := 0; i < len(req.Items); i++ {
+		items[i] = vault.Item{
+			ID:              req.Items[i].Id,
+			Name:            req.Items[i].Name,
+			Type:            vault.ItemType(req.Items[i].Itype),
+			Value:           req.Items[i].Value,
+			ServerUpdatedAt: req.Items[i].ServerUpdatedAt,
+			IsDeleted:       req.Items[i].IsDeleted,
+		}
+	}
+
+	results, err := s.service.SetVaultItems(ctx, email, items)
+	if err != nil {
+		s.logger.ErrorContext(ctx, op, sl.Error(err))
+		return nil, pb.ErrInternal
+	}
+
+	pbResults := make([]*pb.SetVaultItemResult, len(results))
+	for i := 0; i < len(results); i++ {
+		pbResults[i] = &pb.SetVaultItemResult{
+			Id:              items[i].ID,
+			ServerUpdatedAt: results[i].ServerUpdatedAt,
+		}
+		switch {
+		case results[i].Err == nil:
+			pbResults[i].Status = pb.SetVaultItemStatus_ACCEPTED
+		case errors.Is(results[i].Err, service.ErrVaultItemVersionConflict):
+			pbResults[i].Status = pb.SetVaultItemStatus_CONFLICT_VERSION
+		case errors.Is(results[i].Err, service.ErrVaultItemValueTooBig):
+			pbResults[i].Status = pb.SetVaultItemStatus_VALUE_TOO_BIG
+		case errors.Is(results[i].Err, service.ErrQuotaExceeded):
+			pbResults[i].Status = pb.SetVaultItemStatus_QUOTA_EXCEEDED
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(results[i].Err))
+			return nil, pb.ErrInternal
+		}
+	}
+
+	return &pb.SetVaultItemsResponse{
+		Results: pbResults,
+	}, nil
+}
+
+func (s *server) UploadVaultItem(stream pb.GophKeeperService_UploadVaultItemServer) error {
+	const op = "upload vault item"
+
+	ctx := stream.Context()
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return pb.ErrEmptyAuthData
+	}
+
+	// the first message must contain the item itself
+	req, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return pb.ErrEmptyVaultItem
+		}
+		return err
+	}
+	pbItem := req.GetItem()
+	if pbItem == nil {
+		return pb.ErrEmptyVaultItem
+	}
+
+	t, err := s.service.SetLargeVaultItem(ctx, email, vault.Item{
+		ID:              pbItem.Id,
+		Name:            pbItem.Name,
+		Type:            vault.ItemType(pbItem.Itype),
+		ServerUpdatedAt: pbItem.ServerUpdatedAt,
+		IsDeleted:       pbItem.IsDeleted,
+	}, &uploadReader{stream: stream})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVaultItemVersionConflict):
+			return pb.ErrVaultItemConflictVersion
+		case errors.Is(err, service.ErrVaultItemValueTooBig):
+			return pb.ErrVaultItemValueTooBig
+		case errors.Is(err, service.ErrQuotaExceeded):
+			return pb.ErrQuotaExceeded
+		case errors.Is(err, service.ErrVaultItemWrongType):
+			return pb.ErrVaultItemWrongType
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return pb.ErrInternal
+		}
+	}
+
+	return stream.SendAndClose(&pb.UploadVaultItemResponse{
+		ServerUpdatedAt: t,
+	})
+}
+
+func (s *server) DownloadVaultItem(req *pb.DownloadVaultItemRequest, stream pb.GophKeeperService_DownloadVaultItemServer) error {
+	const op = "download vault item"
+
+	ctx := stream.Context()
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return pb.ErrEmptyAuthData
+	}
+
+	item, r, err := s.service.GetLargeVaultItem(ctx, email, req.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVaultItemNotExists):
+			return pb.ErrVaultItemNotExists
+		case errors.Is(err, service.ErrVaultItemWrongType):
+			return pb.ErrVaultItemWrongType
+		default:
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return pb.ErrInternal
+		}
+	}
+	defer r.Close()
+
+	err = stream.Send(&pb.DownloadVaultItemResponse{
+		Data: &pb.DownloadVaultItemResponse_Item{
+			Item: &pb.VaultItem{
+				Id:              item.ID,
+				Name:            item.Name,
+				Itype:           pb.IType(item.Type),
+				ServerUpdatedAt: item.ServerUpdatedAt,
+				IsDeleted:       item.IsDeleted,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, vaultItemChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := stream.Send(&pb.DownloadVaultItemResponse{
+				Data: &pb.DownloadVaultItemResponse_Chunk{
+					Chunk: buf[:n],
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, op, sl.Error(err))
+			return pb.ErrInternal
+		}
+	}
+}
+
+func (s *server) WatchVaultItems(_ *pb.WatchVaultItemsRequest, stream pb.GophKeeperService_WatchVaultItemsServer) error {
+	const op = "watch vault items"
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return pb.ErrEmptyAuthData
+	}
+
+	ch, err := s.service.WatchVaultItems(ctx, email)
+	if err != nil {
+		s.logger.ErrorContext(ctx, op, sl.Error(err))
+		return pb.ErrInternal
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return nil
+		case t, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&pb.WatchVaultItemsResponse{
+				ServerUpdatedAt: t,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// uploadReader reads the value of the vault item from the chunks of the client upload stream.
+type uploadReader struct {
+	stream pb.GophKeeperService_UploadVaultItemServer
+	chunk  []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = req.GetChunk()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
+}
+
+func (s *server) GetAccountUsage(ctx context.Context, req *pb.GetAccountUsageRequest) (*pb.GetAccountUsageResponse, error) {
+	const op = "get account usage"
+
+	email, err := auth.EmailFromContext(ctx)
+	if err != nil {
+		return nil, pb.ErrEmptyAuthData
+	}
+
+	usage, quota, err := s.service.GetAccountUsage(ctx, email)
+	if err != nil {
+		s.logger.ErrorContext(ctx, op, sl.Error(err))
+		return nil, pb.ErrInternal
+	}
+
+	return &pb.GetAccountUsageResponse{
+		Items:    usage.Items,
+		Size:     usage.Size,
+		MaxItems: quota.MaxItems,
+		MaxSize:  quota.MaxBytes,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	gcfg "github.com/Karzoug/goph_keeper/server/internal/config/grpc"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+type server struct {
+	cfg    gcfg.Config
+	logger *slog.Logger
+
+	httpServer *http.Server
+}
+
+// New creates HTTP server that exposes Prometheus metrics of the default registry on /metrics.
+func New(cfg gcfg.Config, logger *slog.Logger) *server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &server{
+		cfg:    cfg,
+		logger: logger.With("from", "metrics server"),
+		httpServer: &http.Server{
+			Addr:              cfg.MetricsAddress(),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+func (s *server) Run(ctx context.Context) error {
+	const op = "metrics server: run"
+
+	s.logger.Info("running", slog.String("address", s.cfg.MetricsAddress()))
+
+	idleConnsClosed := make(chan struct{})
+
+	go func() {
+		<-ctx.Done()
+		s.shutdown()
+		close(idleConnsClosed)
+	}()
+
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return e.Wrap(op, err)
+	}
+
+	<-idleConnsClosed
+
+	return nil
+}
+
+func (s *server) shutdown() {
+	s.logger.Info("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("shutdown failed", sl.Error(err))
+	}
+}
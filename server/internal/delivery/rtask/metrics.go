@@ -0,0 +1,86 @@
+package rtask
+
+import (
+	"context"
+
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+var tasksFailedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gophkeeper",
+	Subsystem: "rtask",
+	Name:      "task_failures_total",
+	Help:      "Total number of task handling failures by task type.",
+}, []string{"type"})
+
+// queueCollector collects the queues state from redis on every scrape.
+type queueCollector struct {
+	inspector *asynq.Inspector
+	logger    *slog.Logger
+
+	size      *prometheus.Desc
+	processed *prometheus.Desc
+	failed    *prometheus.Desc
+}
+
+func newQueueCollector(inspector *asynq.Inspector, logger *slog.Logger) *queueCollector {
+	return &queueCollector{
+		inspector: inspector,
+		logger:    logger,
+		size: prometheus.NewDesc("gophkeeper_rtask_queue_size",
+			"Number of tasks in the queue by state.",
+			[]string{"queue", "state"}, nil),
+		processed: prometheus.NewDesc("gophkeeper_rtask_queue_processed_total",
+			"Total number of tasks processed (succeeded or failed) from the queue.",
+			[]string{"queue"}, nil),
+		failed: prometheus.NewDesc("gophkeeper_rtask_queue_failed_total",
+			"Total number of tasks failed to be processed from the queue.",
+			[]string{"queue"}, nil),
+	}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "collect queues metrics"
+
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		c.logger.Warn(op, sl.Error(err))
+		return
+	}
+
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			c.logger.Warn(op, slog.String("queue", queue), sl.Error(err))
+			continue
+		}
+
+		for state, size := range map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(size), queue, state)
+		}
+		ch <- prometheus.MustNewConstMetric(c.processed, prometheus.CounterValue, float64(info.ProcessedTotal), queue)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(info.FailedTotal), queue)
+	}
+}
+
+// handleError counts the failed task, asynq retries it later.
+func (s *server) handleError(_ context.Context, task *asynq.Task, err error) {
+	tasksFailedTotal.WithLabelValues(task.Type()).Inc()
+	s.logger.Warn("task handling failed", slog.String("task type", task.Type()), sl.Error(err))
+}
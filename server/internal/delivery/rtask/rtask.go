@@ -2,12 +2,15 @@ package rtask
 
 import (
 	"context"
+	"time"
 
 	"log/slog"
 
 	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
 	"github.com/Karzoug/goph_keeper/server/internal/config/rtask"
 	"github.com/Karzoug/goph_keeper/server/internal/service"
 	"github.com/Karzoug/goph_keeper/server/internal/service/task"
@@ -18,6 +21,8 @@ type server struct {
 	service *service.Service
 
 	asynqServer *asynq.Server
+	scheduler   *asynq.Scheduler
+	inspector   *asynq.Inspector
 }
 
 func New(cfg rtask.Config, service *service.Service, logger *slog.Logger) (*server, error) {
@@ -28,17 +33,41 @@ func New(cfg rtask.Config, service *service.Service, logger *slog.Logger) (*serv
 		return nil, e.Wrap(op, err)
 	}
 
-	asynqServer := asynq.NewServer(opt,
+	srv := &server{
+		logger:    logger.With("from", "rtask server"),
+		service:   service,
+		inspector: asynq.NewInspector(opt),
+	}
+
+	srv.asynqServer = asynq.NewServer(opt,
 		asynq.Config{
-			Concurrency: cfg.Concurrency,
-			LogLevel:    asynq.InfoLevel,
+			Concurrency:  cfg.Concurrency,
+			LogLevel:     asynq.InfoLevel,
+			ErrorHandler: asynq.ErrorHandlerFunc(srv.handleError),
 		},
 	)
 
-	srv := &server{
-		logger:      logger.With("from", "rtask server"),
-		service:     service,
-		asynqServer: asynqServer,
+	if cfg.PurgeTombstonesSchedule != "" {
+		srv.scheduler = asynq.NewScheduler(opt, &asynq.SchedulerOpts{LogLevel: asynq.InfoLevel})
+		// the task is unique for an hour, so several server instances do not purge twice
+		if _, err := srv.scheduler.Register(cfg.PurgeTombstonesSchedule,
+			task.NewPurgeTombstonesTask(), asynq.Unique(time.Hour)); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+	if cfg.CleanupUnverifiedUsersSchedule != "" {
+		if srv.scheduler == nil {
+			srv.scheduler = asynq.NewScheduler(opt, &asynq.SchedulerOpts{LogLevel: asynq.InfoLevel})
+		}
+		// the task is unique for an hour, so several server instances do not remind the users twice
+		if _, err := srv.scheduler.Register(cfg.CleanupUnverifiedUsersSchedule,
+			task.NewCleanupUnverifiedUsersTask(), asynq.Unique(time.Hour)); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	if err := prometheus.Register(newQueueCollector(srv.inspector, srv.logger)); err != nil {
+		return nil, e.Wrap(op, err)
 	}
 
 	return srv, nil
@@ -50,7 +79,12 @@ func (s *server) Run(ctx context.Context) error {
 	s.logger.Info("running")
 
 	mux := asynq.NewServeMux()
+	mux.Use(tracingMiddleware)
 	mux.HandleFunc(task.TypeWelcomeVerificationEmail, s.service.HandleWelcomeVerificationEmailTask)
+	mux.HandleFunc(task.TypeAccountDeletedEmail, s.service.HandleAccountDeletedEmailTask)
+	mux.HandleFunc(task.TypeVerificationReminderEmail, s.service.HandleVerificationReminderEmailTask)
+	mux.HandleFunc(task.TypePurgeTombstones, s.service.HandlePurgeTombstonesTask)
+	mux.HandleFunc(task.TypeCleanupUnverifiedUsers, s.service.HandleCleanupUnverifiedUsersTask)
 
 	idleConnsClosed := make(chan struct{})
 
@@ -64,6 +98,12 @@ func (s *server) Run(ctx context.Context) error {
 		return e.Wrap(op, err)
 	}
 
+	if s.scheduler != nil {
+		if err := s.scheduler.Start(); err != nil {
+			return e.Wrap(op, err)
+		}
+	}
+
 	<-idleConnsClosed
 
 	return nil
@@ -72,5 +112,12 @@ func (s *server) Run(ctx context.Context) error {
 func (s *server) shutdown() {
 	s.logger.Info("shutting down")
 
+	if s.scheduler != nil {
+		s.scheduler.Shutdown()
+	}
 	s.asynqServer.Shutdown()
+
+	if err := s.inspector.Close(); err != nil {
+		s.logger.Warn("close inspector failed", sl.Error(err))
+	}
 }
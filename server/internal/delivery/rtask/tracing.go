@@ -0,0 +1,44 @@
+package rtask
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Karzoug/goph_keeper/server/internal/service/task"
+)
+
+var tracer = otel.Tracer("github.com/Karzoug/goph_keeper/server/internal/delivery/rtask")
+
+// tracingMiddleware starts the span of the task processing as a child
+// of the request span that created the task.
+func tracingMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		ctx = task.ExtractTraceContext(ctx, t)
+
+		attrs := []attribute.KeyValue{attribute.String("rtask.type", t.Type())}
+		if id, ok := asynq.GetTaskID(ctx); ok {
+			attrs = append(attrs, attribute.String("rtask.id", id))
+		}
+		if retried, ok := asynq.GetRetryCount(ctx); ok {
+			attrs = append(attrs, attribute.Int("rtask.retry_count", retried))
+		}
+
+		ctx, span := tracer.Start(ctx, "rtask: "+t.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
+		err := next.ProcessTask(ctx, t)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,11 @@
+package blob
+
+// Ref is a reference to the value stored in a blob storage.
+type Ref struct {
+	// Key is a key of the value in the blob storage.
+	Key string
+	// Checksum is a hex encoded SHA-256 checksum of the value.
+	Checksum string
+	// Size is the size of the value in bytes.
+	Size int64
+}
@@ -0,0 +1,54 @@
+package page
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid page token")
+
+// Cursor points to the last item of the page, the next page starts right after it.
+// Items are ordered by update time and then by id, so the order is stable.
+type Cursor struct {
+	UpdatedAt int64
+	ID        string
+}
+
+// IsZero reports whether the cursor points to the beginning.
+func (c Cursor) IsZero() bool {
+	return c.UpdatedAt == 0 && len(c.ID) == 0
+}
+
+// Token returns the opaque page token of the cursor.
+func (c Cursor) Token() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.UpdatedAt, 10) + ":" + c.ID))
+}
+
+// ParseToken returns cursor of the page token. Empty token means the beginning.
+func ParseToken(token string) (Cursor, error) {
+	if len(token) == 0 {
+		return Cursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidToken
+	}
+
+	t, id, ok := strings.Cut(string(b), ":")
+	if !ok || len(id) == 0 {
+		return Cursor{}, ErrInvalidToken
+	}
+
+	updatedAt, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || updatedAt <= 0 {
+		return Cursor{}, ErrInvalidToken
+	}
+
+	return Cursor{
+		UpdatedAt: updatedAt,
+		ID:        id,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package page
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_Token_ParseToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{
+			name:   "simple id",
+			cursor: Cursor{UpdatedAt: 1696000000000000, ID: "ckbv0s2l4n3b1r3o2k1g"},
+		},
+		{
+			name:   "id with separator",
+			cursor: Cursor{UpdatedAt: 1, ID: "a:b:c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseToken(tt.cursor.Token())
+			require.NoError(t, err)
+			assert.Equal(t, tt.cursor, got)
+		})
+	}
+}
+
+func TestParseToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    Cursor
+		wantErr bool
+	}{
+		{
+			name:  "empty token",
+			token: "",
+			want:  Cursor{},
+		},
+		{
+			name:    "invalid: not base64",
+			token:   "!!!",
+			wantErr: true,
+		},
+		{
+			name:    "invalid: without separator",
+			token:   "MTIz",
+			wantErr: true,
+		},
+		{
+			name:    "invalid: empty id",
+			token:   "MTIzOg",
+			wantErr: true,
+		},
+		{
+			name:    "invalid: not a number",
+			token:   "YWJjOmlk",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseToken(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, got.IsZero())
+		})
+	}
+}
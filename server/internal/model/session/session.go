@@ -0,0 +1,60 @@
+package session
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/xid"
+)
+
+// maxDeviceFieldLength is a maximum length in runes of the device fields,
+// they are passed by client as is, so the longer values are truncated.
+const maxDeviceFieldLength = 128
+
+// Device describes the client that the user logged in from.
+type Device struct {
+	Name          string `json:"name"`
+	ClientVersion string `json:"client_version"`
+	IP            string `json:"ip"`
+	// CertFingerprint is the SHA-256 fingerprint of the client certificate (mutual TLS),
+	// empty if the client has no certificate.
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+}
+
+// NewDevice returns a new device with truncated fields.
+func NewDevice(name, clientVersion, ip string) Device {
+	return Device{
+		Name:          truncate(name, maxDeviceFieldLength),
+		ClientVersion: truncate(clientVersion, maxDeviceFieldLength),
+		IP:            truncate(ip, maxDeviceFieldLength),
+	}
+}
+
+// Session is a user login on a device, it lives as long as its refresh token.
+type Session struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Device    Device    `json:"device"`
+	CreatedAt time.Time `json:"created_at"`
+	// TokenID is an ID of the current token of the session.
+	TokenID string `json:"token_id"`
+	// RefreshTokenID is an ID of the current refresh token of the session.
+	RefreshTokenID string `json:"refresh_token_id"`
+}
+
+// New returns a new session with unique ID.
+func New(email string, device Device) Session {
+	return Session{
+		ID:        xid.New().String(),
+		Email:     email,
+		Device:    device,
+		CreatedAt: time.Now(),
+	}
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,36 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewDevice(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceName string
+		wantName   string
+	}{
+		{
+			name:       "short name",
+			deviceName: "laptop",
+			wantName:   "laptop",
+		},
+		{
+			name:       "long name",
+			deviceName: strings.Repeat("ноутбук", 30),
+			wantName:   string([]rune(strings.Repeat("ноутбук", 30))[:maxDeviceFieldLength]),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDevice(tt.deviceName, "v1.0.0", "127.0.0.1")
+			if d.Name != tt.wantName {
+				t.Errorf("NewDevice() name = %v, want %v", d.Name, tt.wantName)
+			}
+			if d.ClientVersion != "v1.0.0" || d.IP != "127.0.0.1" {
+				t.Errorf("NewDevice() = %v, unexpected fields", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 default, supported by all authenticator apps
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+)
+
+const (
+	// secretSize is the size of the shared secret recommended by RFC 4226.
+	secretSize = 20
+	// step is the time step of the code.
+	step = 30 * time.Second
+	// digits is the number of digits in the code.
+	digits = 6
+	// skew is the number of time steps before and after the current one
+	// when the code is still accepted, it allows clock drift of the devices.
+	skew = 1
+
+	recoveryCodeSize = 10
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Secret is a shared secret of the time-based one-time password (RFC 6238).
+type Secret []byte
+
+// NewSecret returns a new random secret.
+func NewSecret() (Secret, error) {
+	const op = "model: create totp secret"
+
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return secret, nil
+}
+
+// String returns the secret encoded in base32 as authenticator apps expect.
+func (s Secret) String() string {
+	return encoding.EncodeToString(s)
+}
+
+// URI returns the key URI to add the secret to an authenticator app (by QR code for example).
+func (s Secret) URI(issuer, account string) string {
+	v := url.Values{}
+	v.Set("secret", s.String())
+	v.Set("issuer", issuer)
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+
+	return u.String()
+}
+
+// Code returns the code of the time step that t belongs to.
+func (s Secret) Code(t time.Time) string {
+	return s.code(t.Unix() / int64(step/time.Second))
+}
+
+// Verify checks the code against the time steps around t.
+// It returns the time step of the code, so the caller can reject the code reuse.
+func (s Secret) Verify(code string, t time.Time) (int64, bool) {
+	if len(code) != digits {
+		return 0, false
+	}
+
+	counter := t.Unix() / int64(step/time.Second)
+	for i := counter - skew; i <= counter+skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(s.code(i)), []byte(code)) == 1 {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (s Secret) code(counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, s)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, see RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// NewRecoveryCodes returns n single-use recovery codes to log in without the authenticator app
+// and their hashes to store.
+func NewRecoveryCodes(n int) ([]string, []string, error) {
+	const op = "model: create totp recovery codes"
+
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, e.Wrap(op, err)
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))
+		codes[i] = code[:8] + "-" + code[8:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash of the recovery code typed by the user.
+// The codes are random, so a fast hash is enough.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors.
+var rfcSecret = Secret("12345678901234567890")
+
+func TestSecret_Code(t *testing.T) {
+	// RFC 6238 appendix B test vectors, the last 6 digits of 8-digit codes
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		if got := rfcSecret.Code(time.Unix(tt.unix, 0)); got != tt.want {
+			t.Errorf("Code(%d) = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestSecret_Verify(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code := rfcSecret.Code(now)
+
+	tests := []struct {
+		name string
+		code string
+		at   time.Time
+		want bool
+	}{
+		{name: "current step", code: code, at: now, want: true},
+		{name: "previous step", code: code, at: now.Add(step), want: true},
+		{name: "next step", code: code, at: now.Add(-step), want: true},
+		{name: "expired", code: code, at: now.Add(3 * step), want: false},
+		{name: "wrong code", code: "000000", at: now, want: false},
+		{name: "wrong length", code: "12345", at: now, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := rfcSecret.Verify(tt.code, tt.at); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecret_URI(t *testing.T) {
+	uri := rfcSecret.URI("GophKeeper", "user@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/GophKeeper:user@example.com?") ||
+		!strings.Contains(uri, "secret="+rfcSecret.String()) {
+		t.Errorf("URI() = %v", uri)
+	}
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != 10 || len(hashes) != 10 {
+		t.Fatalf("NewRecoveryCodes() returned %d codes and %d hashes", len(codes), len(hashes))
+	}
+	for i := range codes {
+		if HashRecoveryCode(strings.ToUpper(codes[i])) != hashes[i] {
+			t.Errorf("HashRecoveryCode(%v) does not match the hash", codes[i])
+		}
+	}
+}
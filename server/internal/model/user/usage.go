@@ -0,0 +1,34 @@
+package user
+
+// Usage is the storage usage of the user vault.
+type Usage struct {
+	// Items is the number of not deleted vault items.
+	Items int64
+	// DeletedItems is the number of deleted items kept to synchronize other devices.
+	DeletedItems int64
+	// LargeItems is the number of not deleted items with values in the blob storage.
+	LargeItems int64
+	// Size is the total size in bytes of not deleted item values,
+	// including values in the blob storage. The item revisions are not counted:
+	// their number is limited by the server for every item, so they are not up to the user.
+	Size int64
+}
+
+// Quota is the limit of the user vault storage usage, zero fields mean no limit.
+type Quota struct {
+	// MaxBytes is the maximum total size of the item values in bytes, the item revisions are not counted.
+	MaxBytes int64
+	// MaxItems is the maximum number of not deleted items.
+	MaxItems int64
+}
+
+// IsExceeded reports whether the usage exceeds the quota.
+func (q Quota) IsExceeded(u Usage) bool {
+	return (q.MaxBytes != 0 && u.Size > q.MaxBytes) ||
+		(q.MaxItems != 0 && u.Items > q.MaxItems)
+}
+
+// IsLimited reports whether the quota limits anything.
+func (q Quota) IsLimited() bool {
+	return q.MaxBytes != 0 || q.MaxItems != 0
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/net/idna"
 
 	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
 )
 
 var (
@@ -23,7 +24,32 @@ type User struct {
 	Email           string
 	IsEmailVerified bool
 	AuthKey         auth.Key
-	CreatedAt       time.Time
+	// WrappedVaultKey is a random key to encrypt the vault items,
+	// encrypted by the key derived from the user password on the client.
+	// It is empty for users registered before the vault key was introduced.
+	WrappedVaultKey []byte
+	// RecoveryKey is an auth key built from the hash of the recovery key generated on the client,
+	// it authorizes the password reset. Empty if the user has no recovery key.
+	RecoveryKey auth.Key
+	// RecoveryWrappedVaultKey is the vault key encrypted by the key derived from the recovery key.
+	RecoveryWrappedVaultKey []byte
+	// TOTPSecret is the secret of the second factor, it is set on enrollment
+	// and used for login only after the enrollment is confirmed (IsTOTPEnabled).
+	TOTPSecret    totp.Secret
+	IsTOTPEnabled bool
+	// TOTPRecoveryCodes are hashes of unused single-use recovery codes of the second factor.
+	TOTPRecoveryCodes []string
+	// CertFingerprint is the SHA-256 fingerprint of the client certificate bound to the user,
+	// login is allowed only with this certificate. Empty if no certificate is bound.
+	CertFingerprint string
+	// IsDisabled is set by the administrator, the disabled user can not log in.
+	IsDisabled bool
+	// Quota overrides the server default quota for the user, zero fields mean the default.
+	Quota Quota
+	// VerificationRemindedAt is the time the reminder with a new verification code was sent
+	// to the not verified user, zero if it was not sent.
+	VerificationRemindedAt time.Time
+	CreatedAt              time.Time
 }
 
 // New returns a new user.
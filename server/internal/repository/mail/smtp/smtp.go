@@ -86,6 +86,18 @@ func (c *client) Send(ctx context.Context, m *rmail.Mail) error {
 	return nil
 }
 
+// Ping checks that the SMTP server is available: connects and disconnects.
+func (c *client) Ping(_ context.Context) error {
+	const op = "smtp client: ping"
+
+	smtpClient, err := c.server.Connect()
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return e.Wrap(op, smtpClient.Close())
+}
+
 func (c *client) Validate(email string) error {
 	// TODO: validate email
 	return nil
@@ -0,0 +1,17 @@
+package storage
+
+import "strings"
+
+// JoinCodes joins hashes of the totp recovery codes to store them in one column,
+// the hashes are hex encoded, so they do not contain the separator.
+func JoinCodes(codes []string) string {
+	return strings.Join(codes, ",")
+}
+
+// SplitCodes splits the column value joined by JoinCodes.
+func SplitCodes(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
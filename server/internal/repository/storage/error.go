@@ -6,4 +6,5 @@ var (
 	ErrRecordAlreadyExists = errors.New("record already exists")
 	ErrRecordNotFound      = errors.New("record not found")
 	ErrNoRecordsAffected   = errors.New("no records affected")
+	ErrQuotaExceeded       = errors.New("quota exceeded")
 )
@@ -0,0 +1,142 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	sconfig "github.com/Karzoug/goph_keeper/server/internal/config/storage"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+const (
+	// URIPreffix is a prefix of the local directory URI,
+	// for example: file:///var/lib/goph_keeper/blobs or file://blobs (relative path).
+	URIPreffix = "file://"
+	dirPerm    = 0o700
+	filePerm   = 0o600
+)
+
+var errInvalidKey = errors.New("invalid key")
+
+type storage struct {
+	dir string
+}
+
+// New creates blob storage in the local directory.
+func New(cfg sconfig.Config) (*storage, error) {
+	const op = "create fs blob storage"
+
+	dir := strings.TrimPrefix(cfg.URI, URIPreffix)
+	if len(dir) == 0 {
+		return nil, e.Wrap(op, errors.New("empty directory path"))
+	}
+
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return &storage{
+		dir: dir,
+	}, nil
+}
+
+// Put saves value read from r by key. Existing value is replaced.
+func (s *storage) Put(ctx context.Context, key string, r io.Reader) error {
+	const op = "fs: put"
+
+	path, err := s.path(key)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	// write to temporary file first, so no one can read the partially written value
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, contextReader{ctx: ctx, r: r}); err != nil {
+		f.Close()
+		return e.Wrap(op, err)
+	}
+	if err := f.Chmod(filePerm); err != nil {
+		f.Close()
+		return e.Wrap(op, err)
+	}
+	if err := f.Close(); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return e.Wrap(op, os.Rename(f.Name(), path))
+}
+
+// Get returns reader of the value by key. The caller must close the reader.
+func (s *storage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	const op = "fs: get"
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return nil, e.Wrap(op, err)
+	}
+
+	return f, nil
+}
+
+// Delete deletes value by key. It is not an error if the value does not exist.
+func (s *storage) Delete(_ context.Context, key string) error {
+	const op = "fs: delete"
+
+	path, err := s.path(key)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// Close does nothing, local directory does not hold any resources.
+func (s *storage) Close() error {
+	return nil
+}
+
+func (s *storage) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", errInvalidKey
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// contextReader stops reading when the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,44 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sconfig "github.com/Karzoug/goph_keeper/server/internal/config/storage"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New(sconfig.Config{URI: URIPreffix + t.TempDir()})
+	require.NoError(t, err)
+
+	const key = "owner/item/1"
+	value := bytes.Repeat([]byte("value"), 1024)
+
+	_, err = s.Get(ctx, key)
+	assert.ErrorIs(t, err, serr.ErrRecordNotFound)
+
+	require.NoError(t, s.Put(ctx, key, bytes.NewReader(value)))
+
+	r, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, value, got)
+
+	require.NoError(t, s.Delete(ctx, key))
+	require.NoError(t, s.Delete(ctx, key), "delete of not existing value")
+
+	_, err = s.Get(ctx, key)
+	assert.ErrorIs(t, err, serr.ErrRecordNotFound)
+
+	assert.Error(t, s.Put(ctx, "../outside", bytes.NewReader(value)), "key outside of the directory")
+}
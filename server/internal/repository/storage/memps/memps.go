@@ -0,0 +1,67 @@
+package memps
+
+import (
+	"context"
+	"sync"
+)
+
+type pubsub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan string]struct{}
+}
+
+// New creates in-memory publish/subscribe broker.
+// Messages are delivered only within one server instance, designed mainly for testing purposes.
+func New() *pubsub {
+	return &pubsub{
+		subs: make(map[string]map[chan string]struct{}),
+	}
+}
+
+// Publish sends message to all subscribers of the channel.
+// Message is dropped for subscriber that has not yet received the previous one.
+func (ps *pubsub) Publish(_ context.Context, channel, message string) error {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	for ch := range ps.subs[channel] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns messages of the channel. Returned go channel is closed when ctx is done.
+func (ps *pubsub) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	ch := make(chan string, 1)
+
+	ps.mu.Lock()
+	if ps.subs[channel] == nil {
+		ps.subs[channel] = make(map[chan string]struct{})
+	}
+	ps.subs[channel][ch] = struct{}{}
+	ps.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		ps.mu.Lock()
+		delete(ps.subs[channel], ch)
+		if len(ps.subs[channel]) == 0 {
+			delete(ps.subs, channel)
+		}
+		ps.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Close does nothing, subscriptions are closed by their contexts.
+func (ps *pubsub) Close() error {
+	return nil
+}
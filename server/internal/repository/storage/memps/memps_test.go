@@ -0,0 +1,42 @@
+package memps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSub(t *testing.T) {
+	ps := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := ps.Subscribe(ctx, "channel")
+	require.NoError(t, err)
+
+	otherCh, err := ps.Subscribe(ctx, "other channel")
+	require.NoError(t, err)
+
+	require.NoError(t, ps.Publish(ctx, "channel", "message"))
+
+	select {
+	case msg := <-ch:
+		assert.Equal(t, "message", msg)
+	case <-time.After(time.Second):
+		t.Fatal("message is not received")
+	}
+
+	select {
+	case <-otherCh:
+		t.Fatal("message is received from other channel")
+	default:
+	}
+
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok, "channel must be closed when context is done")
+
+	require.NoError(t, ps.Publish(context.Background(), "channel", "message"), "publish without subscribers")
+}
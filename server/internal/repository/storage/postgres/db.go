@@ -40,6 +40,13 @@ func New(ctx context.Context, cfg sconfig.Config) (*storage, error) {
 	}, nil
 }
 
+// Ping checks the connection to the database.
+func (s *storage) Ping(ctx context.Context) error {
+	const op = "postgres: ping"
+
+	return e.Wrap(op, s.db.Ping(ctx))
+}
+
 func (s *storage) Close() error {
 	s.db.Close()
 
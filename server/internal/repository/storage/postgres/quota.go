@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// querier is the connection pool or the transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// userUsage returns the storage usage of the user vault, the items with the excluded IDs are not counted.
+func userUsage(ctx context.Context, q querier, email string, exclude []string) (user.Usage, error) {
+	if exclude == nil {
+		exclude = []string{}
+	}
+
+	var usage user.Usage
+	err := q.QueryRow(ctx,
+		`SELECT COUNT(*) FILTER (WHERE NOT is_deleted),
+		COUNT(*) FILTER (WHERE is_deleted),
+		COUNT(*) FILTER (WHERE NOT is_deleted AND blob_key IS NOT NULL),
+		COALESCE(SUM(COALESCE(octet_length(value), 0) + COALESCE(blob_size, 0)) FILTER (WHERE NOT is_deleted), 0)
+		FROM vaults
+		WHERE email = $1 AND NOT (id = ANY($2))`, email, exclude).
+		Scan(&usage.Items, &usage.DeletedItems, &usage.LargeItems, &usage.Size)
+
+	return usage, err
+}
+
+// lockUserVault locks the user row until the end of the transaction if the quota is limited,
+// so the concurrent writes to the user vault are serialized and none of them can exceed
+// the quota checked by checkQuota after the write.
+func lockUserVault(ctx context.Context, tx pgx.Tx, email string, quota user.Quota) error {
+	if !quota.IsLimited() {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE email = $1 FOR UPDATE`, email)
+	return err
+}
+
+// checkQuota returns ErrQuotaExceeded if the user vault exceeds the quota after the write in the transaction,
+// the vault must be locked by lockUserVault before the write.
+func checkQuota(ctx context.Context, tx pgx.Tx, email string, quota user.Quota) error {
+	if !quota.IsLimited() {
+		return nil
+	}
+
+	usage, err := userUsage(ctx, tx, email, nil)
+	if err != nil {
+		return err
+	}
+	if quota.IsExceeded(usage) {
+		return serr.ErrQuotaExceeded
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// ListItemRevisions returns the kept previous versions of the vault item, newest first.
+// The version of the revision is its ServerUpdatedAt.
+func (s *storage) ListItemRevisions(ctx context.Context, email, id string) ([]vault.Item, error) {
+	const op = "postgres: list item revisions"
+
+	rows, err := s.db.Query(ctx,
+		`SELECT id, name, type, value, updated_at
+		FROM vault_revisions
+		WHERE email = $1 AND id = $2
+		ORDER BY updated_at DESC`, email, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (vault.Item, error) {
+		var item vault.Item
+		err = rows.Scan(&item.ID, &item.Name, &item.Type, &item.Value, &item.ServerUpdatedAt)
+		return item, err
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// GetItemRevision returns the revision of the vault item or ErrRecordNotFound if it is not kept.
+func (s *storage) GetItemRevision(ctx context.Context, email, id string, revision int64) (vault.Item, error) {
+	const op = "postgres: get item revision"
+
+	item := vault.Item{ID: id, ServerUpdatedAt: revision}
+	err := s.db.QueryRow(ctx,
+		`SELECT name, type, value
+		FROM vault_revisions
+		WHERE email = $1 AND id = $2 AND updated_at = $3`, email, id, revision).
+		Scan(&item.Name, &item.Type, &item.Value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Item{}, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return vault.Item{}, e.Wrap(op, err)
+	}
+
+	return item, nil
+}
+
+// RestoreItemRevision replaces the vault item with its revision, the item gets the new update time.
+// The replaced version is kept in the item revisions. It returns ErrRecordNotFound if the revision is not kept
+// and ErrQuotaExceeded if the vault exceeds the quota after the item is restored.
+// It returns the blob key of the replaced large binary item value, the value is not needed anymore.
+func (s *storage) RestoreItemRevision(ctx context.Context, email, id string, revision, updatedAt int64, quota user.Quota) (string, error) {
+	const op = "postgres: restore item revision"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserVault(ctx, tx, email, quota); err != nil {
+		return "", e.Wrap(op, err)
+	}
+
+	var blobKey string
+	if err := tx.QueryRow(ctx,
+		`SELECT COALESCE(blob_key, '') FROM vaults WHERE email = $1 AND id = $2`,
+		email, id).Scan(&blobKey); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return "", e.Wrap(op, err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO vault_revisions(id,email,name,type,value,updated_at)
+		SELECT id, email, name, type, value, updated_at
+		FROM vaults
+		WHERE email = $1 AND id = $2 AND is_deleted = false AND type <> $3
+		ON CONFLICT DO NOTHING`,
+		email, id, vault.BinaryLarge); err != nil {
+		return "", e.Wrap(op, err)
+	}
+
+	res, err := tx.Exec(ctx,
+		`UPDATE vaults
+		SET name = r.name, type = r.type, value = r.value, blob_key = NULL, blob_checksum = NULL, blob_size = NULL, updated_at = $4, is_deleted = false
+		FROM vault_revisions r
+		WHERE vaults.email = $1 AND vaults.id = $2 AND r.email = vaults.email AND r.id = vaults.id AND r.updated_at = $3`,
+		email, id, revision, updatedAt)
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return "", e.Wrap(op, serr.ErrRecordNotFound)
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return "", e.Wrap(op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", e.Wrap(op, err)
+	}
+
+	return blobKey, nil
+}
+
+// TrimItemRevisions deletes all but the keep newest revisions of the vault items.
+func (s *storage) TrimItemRevisions(ctx context.Context, email string, keep int, ids ...string) error {
+	const op = "postgres: trim item revisions"
+
+	// the revisions older than the newest keep ones are deleted,
+	// the subquery is NULL if there are not more revisions than keep, so nothing is deleted then
+	_, err := s.db.Exec(ctx,
+		`DELETE FROM vault_revisions r
+		WHERE email = $1 AND id = ANY($2) AND updated_at <= (
+			SELECT updated_at FROM vault_revisions
+			WHERE email = r.email AND id = r.id
+			ORDER BY updated_at DESC
+			OFFSET $3 LIMIT 1)`,
+		email, ids, keep)
+
+	return e.Wrap(op, err)
+}
+
+// saveRevision keeps the current version of the item in the item revisions if the item is going to be
+// replaced by the passed one: the passed item is based on the current version.
+// Deleted items and large binary items (their values are in the blob storage) are not kept.
+func saveRevision(ctx context.Context, tx pgx.Tx, email string, item vault.Item) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO vault_revisions(id,email,name,type,value,updated_at)
+		SELECT id, email, name, type, value, updated_at
+		FROM vaults
+		WHERE email = $1 AND id = $2 AND updated_at = $3 AND is_deleted = false AND type <> $4
+		ON CONFLICT DO NOTHING`,
+		email, item.ID, item.ServerUpdatedAt, vault.BinaryLarge)
+	return err
+}
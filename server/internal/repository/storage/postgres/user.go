@@ -3,11 +3,17 @@ package postgres
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
 	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 )
@@ -17,9 +23,9 @@ func (s *storage) AddUser(ctx context.Context, u user.User) error {
 
 	_, err := s.db.Exec(ctx,
 		`INSERT 
-		INTO users(email, is_email_verified, auth_key, created_at) 
-		VALUES ($1, $2, $3, $4)`,
-		u.Email, u.IsEmailVerified, []byte(u.AuthKey), u.CreatedAt)
+		INTO users(email, is_email_verified, auth_key, wrapped_vault_key, recovery_key, recovery_wrapped_vault_key, created_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		u.Email, u.IsEmailVerified, []byte(u.AuthKey), u.WrappedVaultKey, []byte(u.RecoveryKey), u.RecoveryWrappedVaultKey, u.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == duplicateKeyErrorCode {
@@ -37,14 +43,40 @@ func (s *storage) GetUser(ctx context.Context, email string) (user.User, error)
 	u := user.User{
 		Email: email,
 	}
-	var byteKey []byte
+	var (
+		byteKey, byteRecoveryKey, byteTOTPSecret []byte
+		totpRecoveryCodes, certFingerprint       *string
+		quotaMaxBytes, quotaMaxItems             *int64
+		verificationRemindedAt                   *time.Time
+	)
 	err := s.db.QueryRow(ctx,
-		`SELECT is_email_verified, auth_key, created_at 
+		`SELECT is_email_verified, auth_key, wrapped_vault_key, recovery_key, recovery_wrapped_vault_key, 
+		totp_secret, is_totp_enabled, totp_recovery_codes, cert_fingerprint, is_disabled, 
+		quota_max_bytes, quota_max_items, verification_reminded_at, created_at 
 		FROM users 
 		WHERE email = $1`, email).
-		Scan(&u.IsEmailVerified, &byteKey, &u.CreatedAt)
+		Scan(&u.IsEmailVerified, &byteKey, &u.WrappedVaultKey, &byteRecoveryKey, &u.RecoveryWrappedVaultKey,
+			&byteTOTPSecret, &u.IsTOTPEnabled, &totpRecoveryCodes, &certFingerprint, &u.IsDisabled,
+			&quotaMaxBytes, &quotaMaxItems, &verificationRemindedAt, &u.CreatedAt)
 
 	u.AuthKey = byteKey
+	u.RecoveryKey = byteRecoveryKey
+	u.TOTPSecret = byteTOTPSecret
+	if totpRecoveryCodes != nil {
+		u.TOTPRecoveryCodes = serr.SplitCodes(*totpRecoveryCodes)
+	}
+	if certFingerprint != nil {
+		u.CertFingerprint = *certFingerprint
+	}
+	if quotaMaxBytes != nil {
+		u.Quota.MaxBytes = *quotaMaxBytes
+	}
+	if quotaMaxItems != nil {
+		u.Quota.MaxItems = *quotaMaxItems
+	}
+	if verificationRemindedAt != nil {
+		u.VerificationRemindedAt = *verificationRemindedAt
+	}
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -56,14 +88,79 @@ func (s *storage) GetUser(ctx context.Context, email string) (user.User, error)
 	return u, nil
 }
 
-func (s *storage) UpdateUser(ctx context.Context, u user.User) error {
-	const op = "postgres: update user"
+// SetUserEmailVerified marks the user email as verified.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserEmailVerified(ctx context.Context, email string) error {
+	const op = "postgres: set user email verified"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `is_email_verified = true`))
+}
+
+// SetUserAuthKey replaces the user auth key and the wrapped vault key.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserAuthKey(ctx context.Context, email string, key auth.Key, wrappedVaultKey []byte) error {
+	const op = "postgres: set user auth key"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `auth_key = $1, wrapped_vault_key = $2`, []byte(key), wrappedVaultKey))
+}
+
+// SetUserTOTP sets the user second factor: the secret, whether it is enabled and the hashes of the recovery codes.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserTOTP(ctx context.Context, email string, secret totp.Secret, enabled bool, recoveryCodes []string) error {
+	const op = "postgres: set user totp"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `totp_secret = $1, is_totp_enabled = $2, totp_recovery_codes = $3`, []byte(secret), enabled, serr.JoinCodes(recoveryCodes)))
+}
+
+// SetUserCertFingerprint binds the client certificate to the user, empty fingerprint unbinds it.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserCertFingerprint(ctx context.Context, email, fingerprint string) error {
+	const op = "postgres: set user cert fingerprint"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `cert_fingerprint = $1`, fingerprint))
+}
+
+// SetUserDisabled disables or enables the user.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserDisabled(ctx context.Context, email string, disabled bool) error {
+	const op = "postgres: set user disabled"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `is_disabled = $1`, disabled))
+}
 
+// SetUserQuota overrides the server default quota for the user.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserQuota(ctx context.Context, email string, quota user.Quota) error {
+	const op = "postgres: set user quota"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `quota_max_bytes = $1, quota_max_items = $2`, quota.MaxBytes, quota.MaxItems))
+}
+
+// updateUser sets only the columns of the user in the SET clause, so the concurrent updates
+// of the other columns are not overwritten. The placeholders of the clause start from $1, the email is the last.
+func (s *storage) updateUser(ctx context.Context, email, set string, args ...any) error {
 	tag, err := s.db.Exec(ctx,
-		`UPDATE users 
-		SET is_email_verified = $1, auth_key = $2, created_at = $3 
-		WHERE email = $4`,
-		u.IsEmailVerified, []byte(u.AuthKey), u.CreatedAt, u.Email)
+		`UPDATE users SET `+set+` WHERE email = $`+strconv.Itoa(len(args)+1),
+		append(args, email)...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 { // driver specific
+		return serr.ErrNoRecordsAffected
+	}
+
+	return nil
+}
+
+// UpdateTOTPRecoveryCodes replaces the user totp recovery codes only if they are still equal to the old ones,
+// otherwise it returns ErrNoRecordsAffected: the codes were changed concurrently.
+func (s *storage) UpdateTOTPRecoveryCodes(ctx context.Context, email string, old, new []string) error {
+	const op = "postgres: update totp recovery codes"
+
+	tag, err := s.db.Exec(ctx,
+		`UPDATE users SET totp_recovery_codes = $1 WHERE email = $2 AND totp_recovery_codes = $3`,
+		serr.JoinCodes(new), email, serr.JoinCodes(old))
 	if err != nil {
 		return e.Wrap(op, err)
 	}
@@ -74,3 +171,279 @@ func (s *storage) UpdateUser(ctx context.Context, u user.User) error {
 
 	return nil
 }
+
+// ListUsers returns users ordered by email starting after the given email, limit 0 means no limit.
+// The users are returned without keys and second factor data: only the account state.
+func (s *storage) ListUsers(ctx context.Context, after string, limit int) ([]user.User, error) {
+	const op = "postgres: list users"
+
+	var lim any
+	if limit > 0 {
+		lim = limit
+	}
+	rows, err := s.db.Query(ctx,
+		`SELECT email, is_email_verified, is_totp_enabled, COALESCE(cert_fingerprint, ''), is_disabled, created_at 
+		FROM users 
+		WHERE email > $1 
+		ORDER BY email 
+		LIMIT $2;`, after, lim)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (user.User, error) {
+		var u user.User
+		err := row.Scan(&u.Email, &u.IsEmailVerified, &u.IsTOTPEnabled, &u.CertFingerprint, &u.IsDisabled, &u.CreatedAt)
+		return u, err
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ListUnverifiedUsers returns the users with not verified email created before the given time.
+// The users are returned without keys and second factor data: only the account state.
+func (s *storage) ListUnverifiedUsers(ctx context.Context, createdBefore time.Time) ([]user.User, error) {
+	const op = "postgres: list unverified users"
+
+	rows, err := s.db.Query(ctx,
+		`SELECT email, is_disabled, verification_reminded_at, created_at 
+		FROM users 
+		WHERE NOT COALESCE(is_email_verified, false) AND created_at < $1 
+		ORDER BY created_at;`, createdBefore)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (user.User, error) {
+		var (
+			u                      user.User
+			verificationRemindedAt *time.Time
+		)
+		err := row.Scan(&u.Email, &u.IsDisabled, &verificationRemindedAt, &u.CreatedAt)
+		if verificationRemindedAt != nil {
+			u.VerificationRemindedAt = *verificationRemindedAt
+		}
+		return u, err
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// DeleteUnverifiedUser deletes the user if the email is still not verified.
+// It returns ErrRecordNotFound if the user does not exist or the email is verified.
+func (s *storage) DeleteUnverifiedUser(ctx context.Context, email string) error {
+	const op = "postgres: delete unverified user"
+
+	// the user with not verified email can not log in, so it has no vault items
+	tag, err := s.db.Exec(ctx,
+		`DELETE FROM users WHERE email = $1 AND NOT COALESCE(is_email_verified, false)`, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// SetVerificationRemindedAt sets the reminder time of the user if the email is still not verified.
+// It returns ErrRecordNotFound if the user does not exist or the email is verified.
+func (s *storage) SetVerificationRemindedAt(ctx context.Context, email string, t time.Time) error {
+	const op = "postgres: set verification reminded at"
+
+	tag, err := s.db.Exec(ctx,
+		`UPDATE users SET verification_reminded_at = $1 WHERE email = $2 AND NOT COALESCE(is_email_verified, false)`,
+		t, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// GetUserUsage returns the storage usage of the user vault, the items with the excluded IDs
+// and the item revisions are not counted.
+func (s *storage) GetUserUsage(ctx context.Context, email string, exclude ...string) (user.Usage, error) {
+	const op = "postgres: get user usage"
+
+	usage, err := userUsage(ctx, s.db, email, exclude)
+	if err != nil {
+		return user.Usage{}, e.Wrap(op, err)
+	}
+
+	return usage, nil
+}
+
+// GetUsersUsage returns the storage usage of the users vaults by email,
+// the users without vault items are not in the map.
+func (s *storage) GetUsersUsage(ctx context.Context, emails []string) (map[string]user.Usage, error) {
+	const op = "postgres: get users usage"
+
+	res := make(map[string]user.Usage, len(emails))
+	if len(emails) == 0 {
+		return res, nil
+	}
+
+	rows, err := s.db.Query(ctx,
+		`SELECT email, 
+		COUNT(*) FILTER (WHERE NOT is_deleted), 
+		COUNT(*) FILTER (WHERE is_deleted), 
+		COUNT(*) FILTER (WHERE NOT is_deleted AND blob_key IS NOT NULL), 
+		COALESCE(SUM(COALESCE(octet_length(value), 0) + COALESCE(blob_size, 0)) FILTER (WHERE NOT is_deleted), 0) 
+		FROM vaults 
+		WHERE email = ANY($1) 
+		GROUP BY email`, emails)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			email string
+			usage user.Usage
+		)
+		if err := rows.Scan(&email, &usage.Items, &usage.DeletedItems, &usage.LargeItems, &usage.Size); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res[email] = usage
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ChangePassword updates the user auth key with the wrapped vault key and replaces the vault items in one transaction.
+// The values of large binary items are passed by references, refs are mapped by item ID.
+// All the items must have the same new update time (ClientUpdatedAt),
+// ErrNoRecordsAffected is returned if the version of any item conflicts
+// or any not deleted item of the vault is not passed.
+func (s *storage) ChangePassword(ctx context.Context, u user.User, items []vault.Item, refs map[string]blob.Ref) error {
+	const op = "postgres: change password"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	res, err := tx.Exec(ctx,
+		`UPDATE users 
+		SET auth_key = $1, wrapped_vault_key = $2 
+		WHERE email = $3`,
+		[]byte(u.AuthKey), u.WrappedVaultKey, u.Email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return e.Wrap(op, serr.ErrNoRecordsAffected)
+	}
+
+	var updatedAt int64
+	for _, item := range items {
+		updatedAt = item.ClientUpdatedAt
+
+		var blobKey, blobChecksum, blobSize any
+		if ref, ok := refs[item.ID]; ok {
+			blobKey, blobChecksum, blobSize = ref.Key, ref.Checksum, ref.Size
+		}
+		res, err := tx.Exec(ctx,
+			`INSERT INTO vaults(id,email,name,type,value,blob_key,blob_checksum,blob_size,updated_at,is_deleted) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT(id,email) 
+			DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+			WHERE vaults.updated_at=$11;`,
+			item.ID, u.Email, item.Name, item.Type, item.Value, blobKey, blobChecksum, blobSize, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		if res.RowsAffected() == 0 {
+			return e.Wrap(op, serr.ErrNoRecordsAffected)
+		}
+	}
+
+	// revisions are encrypted with the old vault key, so they can not be restored anymore
+	if _, err := tx.Exec(ctx, `DELETE FROM vault_revisions WHERE email = $1`, u.Email); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	// every not deleted item must be re-encrypted, so it must be updated now
+	var notPassed bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM vaults WHERE email = $1 AND is_deleted = false AND updated_at <> $2)`,
+		u.Email, updatedAt).
+		Scan(&notPassed)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if notPassed {
+		return e.Wrap(op, serr.ErrNoRecordsAffected)
+	}
+
+	return e.Wrap(op, tx.Commit(ctx))
+}
+
+// DeleteUser deletes the user with all the vault items in one transaction
+// and returns the blob storage keys of the large binary items values to delete them.
+// It returns ErrRecordNotFound if the user does not exist.
+func (s *storage) DeleteUser(ctx context.Context, email string) ([]string, error) {
+	const op = "postgres: delete user"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	rows, err := tx.Query(ctx,
+		`SELECT blob_key FROM vaults WHERE email = $1 AND blob_key IS NOT NULL`, email)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM vault_revisions WHERE email = $1`, email); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM vaults WHERE email = $1`, email); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := tx.Exec(ctx, `DELETE FROM users WHERE email = $1`, email)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return nil, e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return keys, nil
+}
+
+// nullTime returns nil for the zero time to store it as NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
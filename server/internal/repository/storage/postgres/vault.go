@@ -2,21 +2,45 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5"
 
 	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/page"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 )
 
-func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Item) error {
+// SetVaultItem sets vault item, the replaced version is kept in the item revisions.
+// It returns ErrQuotaExceeded if the vault exceeds the quota after the not deleted item is set.
+func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Item, quota user.Quota) error {
 	const op = "postgres: set vault item"
 
-	res, err := s.db.Exec(ctx,
+	// deletion frees the space, so it is not limited
+	if item.IsDeleted {
+		quota = user.Quota{}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserVault(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
+	if err := saveRevision(ctx, tx, email, item); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	res, err := tx.Exec(ctx,
 		`INSERT INTO vaults(id,email,name,type,value,updated_at,is_deleted) VALUES($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT(id,email) 
-		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
 		WHERE vaults.updated_at=$8;`,
 		item.ID, email, item.Name, item.Type, item.Value, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
 	if err != nil {
@@ -26,25 +50,179 @@ func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Ite
 	if res.RowsAffected() == 0 {
 		return serr.ErrNoRecordsAffected
 	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
 
-	return nil
+	return e.Wrap(op, tx.Commit(ctx))
 }
 
-func (s *storage) ListVaultItems(ctx context.Context, email string, since int64) ([]vault.Item, error) {
-	const op = "postgres: list vault items"
+// SetVaultItems sets items in one transaction and returns an error for every item:
+// nil if the item is set or ErrNoRecordsAffected if the item version conflicts.
+// The replaced versions are kept in the item revisions.
+// It returns ErrQuotaExceeded and sets nothing if the vault exceeds the quota after the items are set,
+// the quota is not checked if only deleted items are set.
+func (s *storage) SetVaultItems(ctx context.Context, email string, items []vault.Item, quota user.Quota) ([]error, error) {
+	const op = "postgres: set vault items"
+
+	if !serr.HasNotDeleted(items) {
+		quota = user.Quota{}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserVault(ctx, tx, email, quota); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	errs := make([]error, len(items))
+	for i, item := range items {
+		if err := saveRevision(ctx, tx, email, item); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res, err := tx.Exec(ctx,
+			`INSERT INTO vaults(id,email,name,type,value,updated_at,is_deleted) VALUES($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT(id,email) 
+			DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+			WHERE vaults.updated_at=$8;`,
+			item.ID, email, item.Name, item.Type, item.Value, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		if res.RowsAffected() == 0 {
+			errs[i] = serr.ErrNoRecordsAffected
+		}
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return errs, nil
+}
+
+// SetLargeVaultItem sets vault item with the value stored in a blob storage:
+// only the reference to the value is saved.
+// It returns ErrQuotaExceeded if the vault exceeds the quota after the item is set.
+func (s *storage) SetLargeVaultItem(ctx context.Context, email string, item vault.Item, ref blob.Ref, quota user.Quota) error {
+	const op = "postgres: set large vault item"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserVault(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
+	if err := saveRevision(ctx, tx, email, item); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	res, err := tx.Exec(ctx,
+		`INSERT INTO vaults(id,email,name,type,value,blob_key,blob_checksum,blob_size,updated_at,is_deleted) VALUES($1, $2, $3, $4, NULL, $5, $6, $7, $8, $9)
+		ON CONFLICT(id,email) 
+		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+		WHERE vaults.updated_at=$10;`,
+		item.ID, email, item.Name, item.Type, ref.Key, ref.Checksum, ref.Size, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return serr.ErrNoRecordsAffected
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
 
-	var (
-		rows pgx.Rows
-		err  error
-	)
-	if since != 0 {
-		rows, err = s.db.Query(ctx,
-			`SELECT id, name, type, value, updated_at, is_deleted FROM vaults WHERE email = $1 AND updated_at > $2;`, email, since)
-	} else {
-		rows, err = s.db.Query(ctx,
-			`SELECT id, name, type, value, updated_at, is_deleted FROM vaults WHERE email = $1`, email)
+	return e.Wrap(op, tx.Commit(ctx))
+}
+
+// GetLargeVaultItem returns vault item (without value) and the reference to its value in a blob storage.
+func (s *storage) GetLargeVaultItem(ctx context.Context, email, id string) (vault.Item, blob.Ref, error) {
+	const op = "postgres: get large vault item"
+
+	var ref blob.Ref
+	item := vault.Item{ID: id}
+	err := s.db.QueryRow(ctx,
+		`SELECT name, type, updated_at, is_deleted, COALESCE(blob_key, ''), COALESCE(blob_checksum, '') 
+		FROM vaults 
+		WHERE email = $1 AND id = $2`, email, id).
+		Scan(&item.Name, &item.Type, &item.ServerUpdatedAt, &item.IsDeleted, &ref.Key, &ref.Checksum)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Item{}, blob.Ref{}, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return vault.Item{}, blob.Ref{}, e.Wrap(op, err)
 	}
 
+	return item, ref, nil
+}
+
+// ListUnsizedBlobKeys returns the blob storage keys of the values without the size ordered by key,
+// the keys start after the given key, limit 0 means no limit.
+func (s *storage) ListUnsizedBlobKeys(ctx context.Context, after string, limit int) ([]string, error) {
+	const op = "postgres: list unsized blob keys"
+
+	var lim any
+	if limit > 0 {
+		lim = limit
+	}
+	rows, err := s.db.Query(ctx,
+		`SELECT blob_key 
+		FROM vaults 
+		WHERE blob_key IS NOT NULL AND blob_size IS NULL AND blob_key > $1 
+		ORDER BY blob_key 
+		LIMIT $2;`, after, lim)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return keys, nil
+}
+
+// SetBlobSize sets the size of the value by its blob storage key if it is not set yet.
+func (s *storage) SetBlobSize(ctx context.Context, key string, size int64) error {
+	const op = "postgres: set blob size"
+
+	_, err := s.db.Exec(ctx,
+		`UPDATE vaults SET blob_size = $1 WHERE blob_key = $2 AND blob_size IS NULL`, size, key)
+
+	return e.Wrap(op, err)
+}
+
+// ListVaultItems returns items changed at or after since time, ordered by update time and id.
+// The items start after the cursor, limit 0 means no limit.
+func (s *storage) ListVaultItems(ctx context.Context, email string, since int64, after page.Cursor, limit int) ([]vault.Item, error) {
+	const op = "postgres: list vault items"
+
+	// values of large binary items are not listed, they must be downloaded separately;
+	// limit is NULL (no limit) if it is not set
+	var lim any
+	if limit > 0 {
+		lim = limit
+	}
+	rows, err := s.db.Query(ctx,
+		`SELECT id, name, type, CASE WHEN type = $2 THEN NULL ELSE value END, updated_at, is_deleted 
+		FROM vaults 
+		WHERE email = $1 AND updated_at >= $3 AND (updated_at, id) > ($4, $5)
+		ORDER BY updated_at, id
+		LIMIT $6;`, email, vault.BinaryLarge, since, after.UpdatedAt, after.ID, lim)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
@@ -66,3 +244,66 @@ func (s *storage) ListVaultItems(ctx context.Context, email string, since int64)
 
 	return res, nil
 }
+
+// PurgeDeletedVaultItems deletes the deleted items updated before the given time with their revisions
+// in one transaction and returns the number of purged items. The newest update time of the purged items
+// is kept by the user as the purge watermark.
+func (s *storage) PurgeDeletedVaultItems(ctx context.Context, before int64) (int64, error) {
+	const op = "postgres: purge deleted vault items"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE users
+		SET tombstones_purged_at = GREATEST(users.tombstones_purged_at, p.purged_at)
+		FROM (SELECT email, MAX(updated_at) AS purged_at
+			FROM vaults
+			WHERE is_deleted = true AND updated_at < $1
+			GROUP BY email) p
+		WHERE users.email = p.email`,
+		before); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM vault_revisions
+		WHERE (email, id) IN (SELECT email, id FROM vaults WHERE is_deleted = true AND updated_at < $1)`,
+		before); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	res, err := tx.Exec(ctx,
+		`DELETE FROM vaults WHERE is_deleted = true AND updated_at < $1`, before)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// GetTombstonesPurgedAt returns the purge watermark of the user: the newest update time
+// of the purged deleted items, zero if nothing was purged.
+func (s *storage) GetTombstonesPurgedAt(ctx context.Context, email string) (int64, error) {
+	const op = "postgres: get tombstones purged at"
+
+	var t int64
+	err := s.db.QueryRow(ctx,
+		`SELECT tombstones_purged_at FROM users WHERE email = $1`, email).
+		Scan(&t)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return 0, e.Wrap(op, err)
+	}
+
+	return t, nil
+}
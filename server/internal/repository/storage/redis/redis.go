@@ -52,6 +52,38 @@ func (q *client) Set(ctx context.Context, key, value string, expiration time.Dur
 	return e.Wrap(op, q.rdb.Set(ctx, key, value, expiration).Err())
 }
 
+// SetNX sets value by key only if the key does not exist and reports whether the value is set.
+func (q *client) SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	const op = "redis: set if not exists"
+
+	ok, err := q.rdb.SetNX(ctx, key, value, expiration).Result()
+	return ok, e.Wrap(op, err)
+}
+
+// SetXX sets value by key only if the key exists and reports whether the value is set.
+func (q *client) SetXX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	const op = "redis: set if exists"
+
+	ok, err := q.rdb.SetXX(ctx, key, value, expiration).Result()
+	return ok, e.Wrap(op, err)
+}
+
+// Incr increments the counter by key and prolongs its expiration, it returns the new value.
+func (q *client) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	const op = "redis: increment"
+
+	var incr *redis.IntCmd
+	_, err := q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	return incr.Val(), nil
+}
+
 // Delete deletes value by key.
 func (q *client) Delete(ctx context.Context, key string) error {
 	const op = "redis: delete"
@@ -63,6 +95,102 @@ func (q *client) Delete(ctx context.Context, key string) error {
 	return e.Wrap(op, err)
 }
 
+// GetDel returns value by key and deletes it atomically.
+func (q *client) GetDel(ctx context.Context, key string) (string, error) {
+	const op = "redis: get and delete"
+
+	val, err := q.rdb.GetDel(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", e.Wrap(op, storage.ErrRecordNotFound)
+	}
+	return val, e.Wrap(op, err)
+}
+
+// SAdd adds member to the set by key and prolongs the set expiration.
+func (q *client) SAdd(ctx context.Context, key, member string, expiration time.Duration) error {
+	const op = "redis: set add"
+
+	_, err := q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, member)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	return e.Wrap(op, err)
+}
+
+// SMembers returns all members of the set by key, empty if the set does not exist.
+func (q *client) SMembers(ctx context.Context, key string) ([]string, error) {
+	const op = "redis: set members"
+
+	val, err := q.rdb.SMembers(ctx, key).Result()
+	return val, e.Wrap(op, err)
+}
+
+// SRem removes members from the set by key.
+func (q *client) SRem(ctx context.Context, key string, members ...string) error {
+	const op = "redis: set remove"
+
+	if len(members) == 0 {
+		return nil
+	}
+	args := make([]any, len(members))
+	for i := 0; i < len(members); i++ {
+		args[i] = members[i]
+	}
+	return e.Wrap(op, q.rdb.SRem(ctx, key, args...).Err())
+}
+
+// Publish sends message to all subscribers of the channel.
+func (q *client) Publish(ctx context.Context, channel, message string) error {
+	const op = "redis: publish"
+
+	return e.Wrap(op, q.rdb.Publish(ctx, channel, message).Err())
+}
+
+// Subscribe returns messages of the channel. Returned go channel is closed when ctx is done.
+func (q *client) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	const op = "redis: subscribe"
+
+	sub := q.rdb.Subscribe(ctx, channel)
+	// wait for confirmation that subscription is created
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, e.Wrap(op, err)
+	}
+
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+
+		msgs := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				select {
+				case ch <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// Ping checks the connection to redis.
+func (q *client) Ping(ctx context.Context) error {
+	const op = "redis: ping"
+
+	return e.Wrap(op, q.rdb.Ping(ctx).Err())
+}
+
 // Close closes the redis client, releasing any open resources.
 func (q *client) Close() error {
 	const op = "redis: close"
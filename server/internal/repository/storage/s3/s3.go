@@ -0,0 +1,122 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	sconfig "github.com/Karzoug/goph_keeper/server/internal/config/storage"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+const (
+	// URIPreffix is a prefix of the S3-compatible storage URI,
+	// for example: s3://access_key:secret_key@localhost:9000/bucket?secure=false&region=us-east-1
+	URIPreffix = "s3://"
+	// partSize is a size of the part of multipart upload,
+	// value size is unknown before upload, so it is used as a buffer size.
+	partSize         = 16 * 1024 * 1024
+	noSuchKeyErrCode = "NoSuchKey"
+)
+
+type storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// New creates S3-compatible blob storage. Bucket is created if it does not exist.
+func New(ctx context.Context, cfg sconfig.Config) (*storage, error) {
+	const op = "create s3 blob storage"
+
+	u, err := url.Parse(cfg.URI)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	bucket := strings.Trim(u.Path, "/")
+	if len(bucket) == 0 {
+		return nil, e.Wrap(op, errors.New("empty bucket name"))
+	}
+
+	secretKey, _ := u.User.Password()
+	query := u.Query()
+	region := query.Get("region")
+
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4(u.User.Username(), secretKey, ""),
+		Secure: query.Get("secure") != "false",
+		Region: region,
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	return &storage{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+// Put saves value read from r by key. Existing value is replaced.
+func (s *storage) Put(ctx context.Context, key string, r io.Reader) error {
+	const op = "s3: put"
+
+	// payload is not signed (no streaming signature): not all S3-compatible storages support it,
+	// value integrity is checked by the service with own checksum
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, -1, minio.PutObjectOptions{
+		PartSize:             partSize,
+		ContentType:          "application/octet-stream",
+		DisableContentSha256: true,
+	})
+
+	return e.Wrap(op, err)
+}
+
+// Get returns reader of the value by key. The caller must close the reader.
+func (s *storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "s3: get"
+
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// object is requested lazily, so check existence before return
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == noSuchKeyErrCode {
+			return nil, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return nil, e.Wrap(op, err)
+	}
+
+	return obj, nil
+}
+
+// Delete deletes value by key. It is not an error if the value does not exist.
+func (s *storage) Delete(ctx context.Context, key string) error {
+	const op = "s3: delete"
+
+	return e.Wrap(op, s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+// Close does nothing, S3 client does not hold any resources.
+func (s *storage) Close() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sconfig "github.com/Karzoug/goph_keeper/server/internal/config/storage"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+
+	// in-memory S3-compatible server
+	ts := httptest.NewServer(gofakes3.New(s3mem.New()).Server())
+	defer ts.Close()
+
+	uri := URIPreffix + "access:secret@" + strings.TrimPrefix(ts.URL, "http://") +
+		"/blobs?secure=false&region=us-east-1"
+	s, err := New(ctx, sconfig.Config{URI: uri})
+	require.NoError(t, err)
+
+	const key = "owner/item/1"
+	value := bytes.Repeat([]byte("value"), 1024)
+
+	_, err = s.Get(ctx, key)
+	assert.ErrorIs(t, err, serr.ErrRecordNotFound)
+
+	require.NoError(t, s.Put(ctx, key, bytes.NewReader(value)))
+
+	r, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, value, got)
+
+	require.NoError(t, s.Delete(ctx, key))
+	require.NoError(t, s.Delete(ctx, key), "delete of not existing value")
+
+	_, err = s.Get(ctx, key)
+	assert.ErrorIs(t, err, serr.ErrRecordNotFound)
+
+	_, err = New(ctx, sconfig.Config{URI: URIPreffix + "access:secret@localhost"})
+	assert.Error(t, err, "empty bucket name")
+}
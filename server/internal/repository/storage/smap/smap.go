@@ -2,6 +2,7 @@ package smap
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -11,11 +12,14 @@ import (
 
 type smap struct {
 	items sync.Map
+	// mu serializes read-modify-write operations, the modified values are replaced, not changed in place
+	mu    sync.Mutex
 	close chan struct{}
 }
 
 type item struct {
 	data    string
+	set     map[string]struct{}
 	expires int64
 }
 
@@ -90,6 +94,50 @@ func (smap *smap) Set(_ context.Context, key string, value string, duration time
 	return nil
 }
 
+// SetNX sets value by key only if the key does not exist and reports whether the value is set.
+func (smap *smap) SetNX(ctx context.Context, key string, value string, duration time.Duration) (bool, error) {
+	smap.mu.Lock()
+	defer smap.mu.Unlock()
+
+	if _, ok := smap.load(key); ok {
+		return false, nil
+	}
+
+	return true, smap.Set(ctx, key, value, duration)
+}
+
+// SetXX sets value by key only if the key exists and reports whether the value is set.
+func (smap *smap) SetXX(ctx context.Context, key string, value string, duration time.Duration) (bool, error) {
+	smap.mu.Lock()
+	defer smap.mu.Unlock()
+
+	if _, ok := smap.load(key); !ok {
+		return false, nil
+	}
+
+	return true, smap.Set(ctx, key, value, duration)
+}
+
+// Incr increments the counter by key and prolongs its expiration, it returns the new value.
+func (smap *smap) Incr(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	const op = "sync map: increment"
+
+	smap.mu.Lock()
+	defer smap.mu.Unlock()
+
+	var count int64
+	if it, ok := smap.load(key); ok {
+		var err error
+		count, err = strconv.ParseInt(it.data, 10, 64)
+		if err != nil {
+			return 0, e.Wrap(op, err)
+		}
+	}
+	count++
+
+	return count, smap.Set(ctx, key, strconv.FormatInt(count, 10), duration)
+}
+
 // Range calls f sequentially for each key and value present in the storage.
 func (smap *smap) Range(f func(key, value any) bool) {
 	now := time.Now().UnixNano()
@@ -113,6 +161,110 @@ func (smap *smap) Delete(_ context.Context, key string) error {
 	return nil
 }
 
+// GetDel returns value by key and deletes it atomically.
+func (smap *smap) GetDel(_ context.Context, key string) (string, error) {
+	const op = "sync map: get and delete"
+
+	obj, exists := smap.items.LoadAndDelete(key)
+	if !exists {
+		return "", e.Wrap(op, storage.ErrRecordNotFound)
+	}
+
+	item := obj.(item)
+
+	if item.expires > 0 && time.Now().UnixNano() > item.expires {
+		return "", e.Wrap(op, storage.ErrRecordNotFound)
+	}
+
+	return item.data, nil
+}
+
+// SAdd adds member to the set by key and prolongs the set expiration.
+func (smap *smap) SAdd(_ context.Context, key, member string, duration time.Duration) error {
+	smap.mu.Lock()
+	defer smap.mu.Unlock()
+
+	set := make(map[string]struct{})
+	if it, ok := smap.load(key); ok {
+		for m := range it.set {
+			set[m] = struct{}{}
+		}
+	}
+	set[member] = struct{}{}
+
+	var expires int64
+	if duration > 0 {
+		expires = time.Now().Add(duration).UnixNano()
+	}
+
+	smap.items.Store(key, item{
+		set:     set,
+		expires: expires,
+	})
+
+	return nil
+}
+
+// SMembers returns all members of the set by key, empty if the set does not exist.
+func (smap *smap) SMembers(_ context.Context, key string) ([]string, error) {
+	it, ok := smap.load(key)
+	if !ok {
+		return []string{}, nil
+	}
+
+	members := make([]string, 0, len(it.set))
+	for m := range it.set {
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// SRem removes members from the set by key.
+func (smap *smap) SRem(_ context.Context, key string, members ...string) error {
+	smap.mu.Lock()
+	defer smap.mu.Unlock()
+
+	it, ok := smap.load(key)
+	if !ok {
+		return nil
+	}
+
+	set := make(map[string]struct{}, len(it.set))
+	for m := range it.set {
+		set[m] = struct{}{}
+	}
+	for _, m := range members {
+		delete(set, m)
+	}
+
+	if len(set) == 0 {
+		smap.items.Delete(key)
+		return nil
+	}
+	smap.items.Store(key, item{
+		set:     set,
+		expires: it.expires,
+	})
+
+	return nil
+}
+
+// load returns not expired item by key.
+func (smap *smap) load(key string) (item, bool) {
+	obj, exists := smap.items.Load(key)
+	if !exists {
+		return item{}, false
+	}
+
+	it := obj.(item)
+	if it.expires > 0 && time.Now().UnixNano() > it.expires {
+		return item{}, false
+	}
+
+	return it, true
+}
+
 // Close closes storage: cleans internal map and stop cleaning work.
 func (smap *smap) Close() error {
 	smap.close <- struct{}{}
@@ -0,0 +1,174 @@
+package smap
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+func TestGetDel(t *testing.T) {
+	s := New(time.Minute)
+	defer s.Close()
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "key", "value", time.Minute))
+
+	// only one of concurrent callers gets the value
+	var got atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := s.GetDel(ctx, "key")
+			if err == nil {
+				assert.Equal(t, "value", value)
+				got.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, storage.ErrRecordNotFound)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), got.Load())
+
+	_, err := s.Get(ctx, "key")
+	assert.ErrorIs(t, err, storage.ErrRecordNotFound, "value must be deleted")
+
+	require.NoError(t, s.Set(ctx, "expired", "value", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	_, err = s.GetDel(ctx, "expired")
+	assert.ErrorIs(t, err, storage.ErrRecordNotFound, "expired value must not be returned")
+}
+
+func TestSet(t *testing.T) {
+	s := New(time.Minute)
+	defer s.Close()
+	ctx := context.Background()
+
+	members, err := s.SMembers(ctx, "set")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	// concurrent additions are not lost
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, s.SAdd(ctx, "set", strconv.Itoa(i), time.Minute))
+		}(i)
+	}
+	wg.Wait()
+
+	members, err = s.SMembers(ctx, "set")
+	require.NoError(t, err)
+	assert.Len(t, members, 10)
+
+	require.NoError(t, s.SRem(ctx, "set", "0", "1", "unknown"))
+	members, err = s.SMembers(ctx, "set")
+	require.NoError(t, err)
+	assert.Len(t, members, 8)
+	assert.NotContains(t, members, "0")
+
+	require.NoError(t, s.SAdd(ctx, "expired", "member", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	members, err = s.SMembers(ctx, "expired")
+	require.NoError(t, err)
+	assert.Empty(t, members, "expired set must be empty")
+}
+
+func TestSetNX(t *testing.T) {
+	s := New(time.Minute)
+	defer s.Close()
+	ctx := context.Background()
+
+	// only one of concurrent callers sets the value
+	var set atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := s.SetNX(ctx, "key", strconv.Itoa(i), time.Minute)
+			assert.NoError(t, err)
+			if ok {
+				set.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), set.Load())
+
+	require.NoError(t, s.Set(ctx, "expired", "value", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	ok, err := s.SetNX(ctx, "expired", "new", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "expired value must be replaced")
+	value, err := s.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, "new", value)
+}
+
+func TestSetXX(t *testing.T) {
+	s := New(time.Minute)
+	defer s.Close()
+	ctx := context.Background()
+
+	ok, err := s.SetXX(ctx, "key", "value", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "missing value must not be set")
+	_, err = s.Get(ctx, "key")
+	assert.ErrorIs(t, err, storage.ErrRecordNotFound)
+
+	require.NoError(t, s.Set(ctx, "key", "value", time.Minute))
+	ok, err = s.SetXX(ctx, "key", "new", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	value, err := s.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "new", value)
+
+	require.NoError(t, s.Set(ctx, "expired", "value", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	ok, err = s.SetXX(ctx, "expired", "new", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "expired value must not be replaced")
+}
+
+func TestIncr(t *testing.T) {
+	s := New(time.Minute)
+	defer s.Close()
+	ctx := context.Background()
+
+	// concurrent increments are not lost
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Incr(ctx, "counter", time.Minute)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	count, err := s.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), count)
+
+	_, err = s.Incr(ctx, "expired", time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	count, err = s.Incr(ctx, "expired", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "expired counter must start from zero")
+}
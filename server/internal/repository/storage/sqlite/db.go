@@ -38,6 +38,13 @@ func New(ctx context.Context, cfg sconfig.Config) (*storage, error) {
 	}, nil
 }
 
+// Ping checks the connection to the database.
+func (s *storage) Ping(ctx context.Context) error {
+	const op = "sqlite: ping"
+
+	return e.Wrap(op, s.db.PingContext(ctx))
+}
+
 func (s *storage) Close() error {
 	const op = "sqlite: close"
 
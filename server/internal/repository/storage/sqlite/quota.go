@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// querier is the database or the transaction.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// userUsage returns the storage usage of the user vault, the items with the excluded IDs are not counted.
+func userUsage(ctx context.Context, q querier, email string, exclude []string) (user.Usage, error) {
+	// sqlite has no arrays, so the excluded IDs are passed one by one
+	args := make([]any, 0, len(exclude)+1)
+	args = append(args, email)
+	var notIn string
+	if len(exclude) != 0 {
+		notIn = " AND id NOT IN (?" + strings.Repeat(", ?", len(exclude)-1) + ")"
+		for _, id := range exclude {
+			args = append(args, id)
+		}
+	}
+
+	var usage user.Usage
+	err := q.QueryRowContext(ctx,
+		`SELECT COUNT(*) FILTER (WHERE NOT is_deleted),
+		COUNT(*) FILTER (WHERE is_deleted),
+		COUNT(*) FILTER (WHERE NOT is_deleted AND blob_key IS NOT NULL),
+		COALESCE(SUM(COALESCE(length(CAST(value AS BLOB)), 0) + COALESCE(blob_size, 0)) FILTER (WHERE NOT is_deleted), 0)
+		FROM vaults
+		WHERE email = ?`+notIn, args...).
+		Scan(&usage.Items, &usage.DeletedItems, &usage.LargeItems, &usage.Size)
+
+	return usage, err
+}
+
+// checkQuota returns ErrQuotaExceeded if the user vault exceeds the quota after the write in the transaction.
+// The transaction holds the database write lock since the write, so the concurrent writes
+// can not change the usage until it ends.
+func checkQuota(ctx context.Context, tx *sql.Tx, email string, quota user.Quota) error {
+	if !quota.IsLimited() {
+		return nil
+	}
+
+	usage, err := userUsage(ctx, tx, email, nil)
+	if err != nil {
+		return err
+	}
+	if quota.IsExceeded(usage) {
+		return serr.ErrQuotaExceeded
+	}
+	return nil
+}
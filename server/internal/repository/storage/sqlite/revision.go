@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// ListItemRevisions returns the kept previous versions of the vault item, newest first.
+// The version of the revision is its ServerUpdatedAt.
+func (s *storage) ListItemRevisions(ctx context.Context, email, id string) ([]vault.Item, error) {
+	const op = "sqlite: list item revisions"
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, type, value, updated_at
+		FROM vault_revisions
+		WHERE email = ? AND id = ?
+		ORDER BY updated_at DESC`, email, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]vault.Item, 0)
+	for rows.Next() {
+		var item vault.Item
+		err := rows.Scan(&item.ID, &item.Name, &item.Type, &item.Value, &item.ServerUpdatedAt)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res = append(res, item)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// GetItemRevision returns the revision of the vault item or ErrRecordNotFound if it is not kept.
+func (s *storage) GetItemRevision(ctx context.Context, email, id string, revision int64) (vault.Item, error) {
+	const op = "sqlite: get item revision"
+
+	item := vault.Item{ID: id, ServerUpdatedAt: revision}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT name, type, value
+		FROM vault_revisions
+		WHERE email = ? AND id = ? AND updated_at = ?`, email, id, revision).
+		Scan(&item.Name, &item.Type, &item.Value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return vault.Item{}, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return vault.Item{}, e.Wrap(op, err)
+	}
+
+	return item, nil
+}
+
+// RestoreItemRevision replaces the vault item with its revision, the item gets the new update time.
+// The replaced version is kept in the item revisions. It returns ErrRecordNotFound if the revision is not kept
+// and ErrQuotaExceeded if the vault exceeds the quota after the item is restored.
+// It returns the blob key of the replaced large binary item value, the value is not needed anymore.
+func (s *storage) RestoreItemRevision(ctx context.Context, email, id string, revision, updatedAt int64, quota user.Quota) (string, error) {
+	const op = "sqlite: restore item revision"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var blobKey string
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE(blob_key, '') FROM vaults WHERE email = ? AND id = ?`,
+		email, id).Scan(&blobKey); err != nil {
+		if err == sql.ErrNoRows {
+			return "", e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return "", e.Wrap(op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO vault_revisions(id,email,name,type,value,updated_at)
+		SELECT id, email, name, type, value, updated_at
+		FROM vaults
+		WHERE email = ? AND id = ? AND NOT is_deleted AND type <> ?`,
+		email, id, vault.BinaryLarge); err != nil {
+		return "", e.Wrap(op, err)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE vaults
+		SET name = r.name, type = r.type, value = r.value, blob_key = NULL, blob_checksum = NULL, blob_size = NULL, updated_at = ?, is_deleted = false
+		FROM vault_revisions AS r
+		WHERE vaults.email = ? AND vaults.id = ? AND r.email = vaults.email AND r.id = vaults.id AND r.updated_at = ?`,
+		updatedAt, email, id, revision)
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	if count == 0 {
+		return "", e.Wrap(op, serr.ErrRecordNotFound)
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return "", e.Wrap(op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", e.Wrap(op, err)
+	}
+
+	return blobKey, nil
+}
+
+// TrimItemRevisions deletes all but the keep newest revisions of the vault items.
+func (s *storage) TrimItemRevisions(ctx context.Context, email string, keep int, ids ...string) error {
+	const op = "sqlite: trim item revisions"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// sqlite has no arrays, so the IDs are passed one by one
+	args := make([]any, 0, len(ids)+2)
+	args = append(args, email)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, keep)
+
+	// the revisions older than the newest keep ones are deleted,
+	// the subquery is NULL if there are not more revisions than keep, so nothing is deleted then
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM vault_revisions
+		WHERE email = ? AND id IN (?`+strings.Repeat(", ?", len(ids)-1)+`) AND updated_at <= (
+			SELECT r.updated_at FROM vault_revisions AS r
+			WHERE r.email = vault_revisions.email AND r.id = vault_revisions.id
+			ORDER BY r.updated_at DESC
+			LIMIT 1 OFFSET ?)`,
+		args...)
+
+	return e.Wrap(op, err)
+}
+
+// saveRevision keeps the current version of the item in the item revisions if the item is going to be
+// replaced by the passed one: the passed item is based on the current version.
+// Deleted items and large binary items (their values are in the blob storage) are not kept.
+func saveRevision(ctx context.Context, tx *sql.Tx, email string, item vault.Item) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO vault_revisions(id,email,name,type,value,updated_at)
+		SELECT id, email, name, type, value, updated_at
+		FROM vaults
+		WHERE email = ? AND id = ? AND updated_at = ? AND NOT is_deleted AND type <> ?`,
+		email, item.ID, item.ServerUpdatedAt, vault.BinaryLarge)
+	return err
+}
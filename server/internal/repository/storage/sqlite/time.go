@@ -0,0 +1,14 @@
+package sqlite
+
+import "fmt"
+
+// unixTimeExpr returns the SQL expression of the unix time in seconds of the time column.
+// The driver stores the time as the text of time.Time.String(): the local time with the zone offset,
+// e.g. "2006-01-02 15:04:05.999999999 -0700 MST", the date functions of sqlite do not parse it as is,
+// so the local time is joined with the offset in the "-07:00" format.
+func unixTimeExpr(column string) string {
+	// the offset follows the first space after the seconds
+	offset := fmt.Sprintf("instr(substr(%[1]s, 20), ' ') + 20", column)
+	return fmt.Sprintf("unixepoch(substr(%[1]s, 1, 19) || substr(%[1]s, %[2]s, 3) || ':' || substr(%[1]s, %[2]s + 3, 2))",
+		column, offset)
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
+	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
 	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 )
@@ -15,9 +20,9 @@ func (s *storage) AddUser(ctx context.Context, u user.User) error {
 
 	_, err := s.db.ExecContext(ctx,
 		`INSERT 
-		INTO users(email, is_email_verified, auth_key, created_at) 
-		VALUES (?, ?, ?, ?)`,
-		u.Email, u.IsEmailVerified, u.AuthKey, u.CreatedAt)
+		INTO users(email, is_email_verified, auth_key, wrapped_vault_key, recovery_key, recovery_wrapped_vault_key, created_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		u.Email, u.IsEmailVerified, u.AuthKey, u.WrappedVaultKey, u.RecoveryKey, u.RecoveryWrappedVaultKey, u.CreatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), duplicateKeyErrorCode) {
 			return e.Wrap(op, serr.ErrRecordAlreadyExists)
@@ -34,11 +39,30 @@ func (s *storage) GetUser(ctx context.Context, email string) (user.User, error)
 	u := user.User{
 		Email: email,
 	}
+	// recovery key, totp data and certificate fingerprint are NULL for users registered without them
+	var (
+		byteRecoveryKey, byteTOTPSecret    []byte
+		totpRecoveryCodes, certFingerprint sql.NullString
+		quotaMaxBytes, quotaMaxItems       sql.NullInt64
+		verificationRemindedAt             sql.NullTime
+	)
 	err := s.db.QueryRowContext(ctx,
-		`SELECT is_email_verified, auth_key, created_at 
+		`SELECT is_email_verified, auth_key, wrapped_vault_key, recovery_key, recovery_wrapped_vault_key, 
+		totp_secret, is_totp_enabled, totp_recovery_codes, cert_fingerprint, is_disabled, 
+		quota_max_bytes, quota_max_items, verification_reminded_at, created_at 
 		FROM users 
 		WHERE email = ?`, email).
-		Scan(&u.IsEmailVerified, &u.AuthKey, &u.CreatedAt)
+		Scan(&u.IsEmailVerified, &u.AuthKey, &u.WrappedVaultKey, &byteRecoveryKey, &u.RecoveryWrappedVaultKey,
+			&byteTOTPSecret, &u.IsTOTPEnabled, &totpRecoveryCodes, &certFingerprint, &u.IsDisabled,
+			&quotaMaxBytes, &quotaMaxItems, &verificationRemindedAt, &u.CreatedAt)
+
+	u.RecoveryKey = byteRecoveryKey
+	u.TOTPSecret = byteTOTPSecret
+	u.TOTPRecoveryCodes = serr.SplitCodes(totpRecoveryCodes.String)
+	u.CertFingerprint = certFingerprint.String
+	u.Quota.MaxBytes = quotaMaxBytes.Int64
+	u.Quota.MaxItems = quotaMaxItems.Int64
+	u.VerificationRemindedAt = verificationRemindedAt.Time
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -50,14 +74,83 @@ func (s *storage) GetUser(ctx context.Context, email string) (user.User, error)
 	return u, nil
 }
 
-func (s *storage) UpdateUser(ctx context.Context, u user.User) error {
-	const op = "sqlite: update user"
+// SetUserEmailVerified marks the user email as verified.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserEmailVerified(ctx context.Context, email string) error {
+	const op = "sqlite: set user email verified"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `is_email_verified = true`))
+}
+
+// SetUserAuthKey replaces the user auth key and the wrapped vault key.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserAuthKey(ctx context.Context, email string, key auth.Key, wrappedVaultKey []byte) error {
+	const op = "sqlite: set user auth key"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `auth_key = ?, wrapped_vault_key = ?`, []byte(key), wrappedVaultKey))
+}
+
+// SetUserTOTP sets the user second factor: the secret, whether it is enabled and the hashes of the recovery codes.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserTOTP(ctx context.Context, email string, secret totp.Secret, enabled bool, recoveryCodes []string) error {
+	const op = "sqlite: set user totp"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `totp_secret = ?, is_totp_enabled = ?, totp_recovery_codes = ?`, []byte(secret), enabled, serr.JoinCodes(recoveryCodes)))
+}
+
+// SetUserCertFingerprint binds the client certificate to the user, empty fingerprint unbinds it.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserCertFingerprint(ctx context.Context, email, fingerprint string) error {
+	const op = "sqlite: set user cert fingerprint"
 
+	return e.Wrap(op, s.updateUser(ctx, email, `cert_fingerprint = ?`, fingerprint))
+}
+
+// SetUserDisabled disables or enables the user.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserDisabled(ctx context.Context, email string, disabled bool) error {
+	const op = "sqlite: set user disabled"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `is_disabled = ?`, disabled))
+}
+
+// SetUserQuota overrides the server default quota for the user.
+// It returns ErrNoRecordsAffected if the user does not exist.
+func (s *storage) SetUserQuota(ctx context.Context, email string, quota user.Quota) error {
+	const op = "sqlite: set user quota"
+
+	return e.Wrap(op, s.updateUser(ctx, email, `quota_max_bytes = ?, quota_max_items = ?`, quota.MaxBytes, quota.MaxItems))
+}
+
+// updateUser sets only the columns of the user in the SET clause, so the concurrent updates
+// of the other columns are not overwritten.
+func (s *storage) updateUser(ctx context.Context, email, set string, args ...any) error {
 	res, err := s.db.ExecContext(ctx,
-		`UPDATE users 
-		SET is_email_verified = ?, auth_key = ?, created_at = ? 
-		WHERE email = ?`,
-		u.IsEmailVerified, u.AuthKey, u.CreatedAt, u.Email)
+		`UPDATE users SET `+set+` WHERE email = ?`,
+		append(args, email)...)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected() // driver specific
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return serr.ErrNoRecordsAffected
+	}
+
+	return nil
+}
+
+// UpdateTOTPRecoveryCodes replaces the user totp recovery codes only if they are still equal to the old ones,
+// otherwise it returns ErrNoRecordsAffected: the codes were changed concurrently.
+func (s *storage) UpdateTOTPRecoveryCodes(ctx context.Context, email string, old, new []string) error {
+	const op = "sqlite: update totp recovery codes"
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET totp_recovery_codes = ? WHERE email = ? AND totp_recovery_codes = ?`,
+		serr.JoinCodes(new), email, serr.JoinCodes(old))
 	if err != nil {
 		return e.Wrap(op, err)
 	}
@@ -72,3 +165,311 @@ func (s *storage) UpdateUser(ctx context.Context, u user.User) error {
 
 	return nil
 }
+
+// ListUsers returns users ordered by email starting after the given email, limit 0 means no limit.
+// The users are returned without keys and second factor data: only the account state.
+func (s *storage) ListUsers(ctx context.Context, after string, limit int) ([]user.User, error) {
+	const op = "sqlite: list users"
+
+	// negative limit means no limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT email, is_email_verified, is_totp_enabled, COALESCE(cert_fingerprint, ''), is_disabled, created_at 
+		FROM users 
+		WHERE email > ? 
+		ORDER BY email 
+		LIMIT ?;`, after, limit)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]user.User, 0)
+	for rows.Next() {
+		var u user.User
+		err := rows.Scan(&u.Email, &u.IsEmailVerified, &u.IsTOTPEnabled, &u.CertFingerprint, &u.IsDisabled, &u.CreatedAt)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ListUnverifiedUsers returns the users with not verified email created before the given time.
+// The users are returned without keys and second factor data: only the account state.
+func (s *storage) ListUnverifiedUsers(ctx context.Context, createdBefore time.Time) ([]user.User, error) {
+	const op = "sqlite: list unverified users"
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT email, is_disabled, verification_reminded_at, created_at 
+		FROM users 
+		WHERE NOT COALESCE(is_email_verified, 0) AND `+unixTimeExpr("created_at")+` < ? 
+		ORDER BY `+unixTimeExpr("created_at")+`;`, createdBefore.Unix())
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]user.User, 0)
+	for rows.Next() {
+		var (
+			u                      user.User
+			verificationRemindedAt sql.NullTime
+		)
+		err := rows.Scan(&u.Email, &u.IsDisabled, &verificationRemindedAt, &u.CreatedAt)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		u.VerificationRemindedAt = verificationRemindedAt.Time
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// DeleteUnverifiedUser deletes the user if the email is still not verified.
+// It returns ErrRecordNotFound if the user does not exist or the email is verified.
+func (s *storage) DeleteUnverifiedUser(ctx context.Context, email string) error {
+	const op = "sqlite: delete unverified user"
+
+	// the user with not verified email can not log in, so it has no vault items
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM users WHERE email = ? AND NOT COALESCE(is_email_verified, 0)`, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return e.Wrap(op, err)
+	} else if count == 0 {
+		return e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// SetVerificationRemindedAt sets the reminder time of the user if the email is still not verified.
+// It returns ErrRecordNotFound if the user does not exist or the email is verified.
+func (s *storage) SetVerificationRemindedAt(ctx context.Context, email string, t time.Time) error {
+	const op = "sqlite: set verification reminded at"
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET verification_reminded_at = ? WHERE email = ? AND NOT COALESCE(is_email_verified, 0)`,
+		t, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if count == 0 {
+		return e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// GetUserUsage returns the storage usage of the user vault, the items with the excluded IDs
+// and the item revisions are not counted.
+func (s *storage) GetUserUsage(ctx context.Context, email string, exclude ...string) (user.Usage, error) {
+	const op = "sqlite: get user usage"
+
+	usage, err := userUsage(ctx, s.db, email, exclude)
+	if err != nil {
+		return user.Usage{}, e.Wrap(op, err)
+	}
+
+	return usage, nil
+}
+
+// GetUsersUsage returns the storage usage of the users vaults by email,
+// the users without vault items are not in the map.
+func (s *storage) GetUsersUsage(ctx context.Context, emails []string) (map[string]user.Usage, error) {
+	const op = "sqlite: get users usage"
+
+	res := make(map[string]user.Usage, len(emails))
+	if len(emails) == 0 {
+		return res, nil
+	}
+
+	// sqlite has no arrays, so the emails are passed one by one
+	args := make([]any, 0, len(emails))
+	for _, email := range emails {
+		args = append(args, email)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT email, 
+		COUNT(*) FILTER (WHERE NOT is_deleted), 
+		COUNT(*) FILTER (WHERE is_deleted), 
+		COUNT(*) FILTER (WHERE NOT is_deleted AND blob_key IS NOT NULL), 
+		COALESCE(SUM(COALESCE(length(CAST(value AS BLOB)), 0) + COALESCE(blob_size, 0)) FILTER (WHERE NOT is_deleted), 0) 
+		FROM vaults 
+		WHERE email IN (?`+strings.Repeat(", ?", len(emails)-1)+`) 
+		GROUP BY email`, args...)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			email string
+			usage user.Usage
+		)
+		if err := rows.Scan(&email, &usage.Items, &usage.DeletedItems, &usage.LargeItems, &usage.Size); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res[email] = usage
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ChangePassword updates the user auth key with the wrapped vault key and replaces the vault items in one transaction.
+// The values of large binary items are passed by references, refs are mapped by item ID.
+// All the items must have the same new update time (ClientUpdatedAt),
+// ErrNoRecordsAffected is returned if the version of any item conflicts
+// or any not deleted item of the vault is not passed.
+func (s *storage) ChangePassword(ctx context.Context, u user.User, items []vault.Item, refs map[string]blob.Ref) error {
+	const op = "sqlite: change password"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE users 
+		SET auth_key = ?, wrapped_vault_key = ? 
+		WHERE email = ?`,
+		u.AuthKey, u.WrappedVaultKey, u.Email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return e.Wrap(op, err)
+	} else if count == 0 {
+		return e.Wrap(op, serr.ErrNoRecordsAffected)
+	}
+
+	var updatedAt int64
+	for _, item := range items {
+		updatedAt = item.ClientUpdatedAt
+
+		var blobKey, blobChecksum, blobSize any
+		if ref, ok := refs[item.ID]; ok {
+			blobKey, blobChecksum, blobSize = ref.Key, ref.Checksum, ref.Size
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO vaults(id,email,name,type,value,blob_key,blob_checksum,blob_size,updated_at,is_deleted) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id,email) 
+			DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+			WHERE vaults.updated_at=?;`,
+			item.ID, u.Email, item.Name, item.Type, item.Value, blobKey, blobChecksum, blobSize, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		count, err := res.RowsAffected()
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		if count == 0 {
+			return e.Wrap(op, serr.ErrNoRecordsAffected)
+		}
+	}
+
+	// revisions are encrypted with the old vault key, so they can not be restored anymore
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vault_revisions WHERE email = ?`, u.Email); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	// every not deleted item must be re-encrypted, so it must be updated now
+	var notPassed bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM vaults WHERE email = ? AND is_deleted = false AND updated_at <> ?)`,
+		u.Email, updatedAt).
+		Scan(&notPassed)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if notPassed {
+		return e.Wrap(op, serr.ErrNoRecordsAffected)
+	}
+
+	return e.Wrap(op, tx.Commit())
+}
+
+// DeleteUser deletes the user with all the vault items in one transaction
+// and returns the blob storage keys of the large binary items values to delete them.
+// It returns ErrRecordNotFound if the user does not exist.
+func (s *storage) DeleteUser(ctx context.Context, email string) ([]string, error) {
+	const op = "sqlite: delete user"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT blob_key FROM vaults WHERE email = ? AND blob_key IS NOT NULL`, email)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vault_revisions WHERE email = ?`, email); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vaults WHERE email = ?`, email); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE email = ?`, email)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return nil, e.Wrap(op, err)
+	} else if count == 0 {
+		return nil, e.Wrap(op, serr.ErrRecordNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return keys, nil
+}
+
+// nullTime returns NULL for the zero time.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -6,16 +6,36 @@ import (
 
 	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/page"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	serr "github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 )
 
-func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Item) error {
+// SetVaultItem sets vault item, the replaced version is kept in the item revisions.
+// It returns ErrQuotaExceeded if the vault exceeds the quota after the not deleted item is set.
+func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Item, quota user.Quota) error {
 	const op = "sqlite: set vault item"
 
-	res, err := s.db.ExecContext(ctx,
+	// deletion frees the space, so it is not limited
+	if item.IsDeleted {
+		quota = user.Quota{}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := saveRevision(ctx, tx, email, item); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	res, err := tx.ExecContext(ctx,
 		`INSERT INTO vaults(id,email,name,type,value,updated_at,is_deleted) VALUES(?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id,email) 
-		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
 		WHERE vaults.updated_at=?;`,
 		item.ID, email, item.Name, item.Type, item.Value, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
 	if err != nil {
@@ -29,25 +49,186 @@ func (s *storage) SetVaultItem(ctx context.Context, email string, item vault.Ite
 	if count == 0 {
 		return serr.ErrNoRecordsAffected
 	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
 
-	return nil
+	return e.Wrap(op, tx.Commit())
 }
 
-func (s *storage) ListVaultItems(ctx context.Context, email string, since int64) ([]vault.Item, error) {
-	const op = "sqlite: list vault items"
+// SetVaultItems sets items in one transaction and returns an error for every item:
+// nil if the item is set or ErrNoRecordsAffected if the item version conflicts.
+// The replaced versions are kept in the item revisions.
+// It returns ErrQuotaExceeded and sets nothing if the vault exceeds the quota after the items are set,
+// the quota is not checked if only deleted items are set.
+func (s *storage) SetVaultItems(ctx context.Context, email string, items []vault.Item, quota user.Quota) ([]error, error) {
+	const op = "sqlite: set vault items"
 
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if since != 0 {
-		rows, err = s.db.QueryContext(ctx,
-			`SELECT id, name, type, value, updated_at, is_deleted FROM vaults WHERE email = ? AND updated_at > ?;`, email, since)
-	} else {
-		rows, err = s.db.QueryContext(ctx,
-			`SELECT id, name, type, value, updated_at, is_deleted FROM vaults WHERE email = ?`, email)
+	if !serr.HasNotDeleted(items) {
+		quota = user.Quota{}
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	errs := make([]error, len(items))
+	for i, item := range items {
+		if err := saveRevision(ctx, tx, email, item); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO vaults(id,email,name,type,value,updated_at,is_deleted) VALUES(?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id,email) 
+			DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+			WHERE vaults.updated_at=?;`,
+			item.ID, email, item.Name, item.Type, item.Value, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		count, err := res.RowsAffected()
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		if count == 0 {
+			errs[i] = serr.ErrNoRecordsAffected
+		}
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return errs, nil
+}
+
+// SetLargeVaultItem sets vault item with the value stored in a blob storage:
+// only the reference to the value is saved.
+// It returns ErrQuotaExceeded if the vault exceeds the quota after the item is set.
+func (s *storage) SetLargeVaultItem(ctx context.Context, email string, item vault.Item, ref blob.Ref, quota user.Quota) error {
+	const op = "sqlite: set large vault item"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := saveRevision(ctx, tx, email, item); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO vaults(id,email,name,type,value,blob_key,blob_checksum,blob_size,updated_at,is_deleted) VALUES(?, ?, ?, ?, NULL, ?, ?, ?, ?, ?)
+		ON CONFLICT(id,email) 
+		DO UPDATE SET name = excluded.name, type = excluded.type, value=excluded.value, blob_key=excluded.blob_key, blob_checksum=excluded.blob_checksum, blob_size=excluded.blob_size, updated_at=excluded.updated_at, is_deleted=excluded.is_deleted
+		WHERE vaults.updated_at=?;`,
+		item.ID, email, item.Name, item.Type, ref.Key, ref.Checksum, ref.Size, item.ClientUpdatedAt, item.IsDeleted, item.ServerUpdatedAt)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if count == 0 {
+		return serr.ErrNoRecordsAffected
+	}
+	if err := checkQuota(ctx, tx, email, quota); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return e.Wrap(op, tx.Commit())
+}
+
+// GetLargeVaultItem returns vault item (without value) and the reference to its value in a blob storage.
+func (s *storage) GetLargeVaultItem(ctx context.Context, email, id string) (vault.Item, blob.Ref, error) {
+	const op = "sqlite: get large vault item"
+
+	var ref blob.Ref
+	item := vault.Item{ID: id}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT name, type, updated_at, is_deleted, COALESCE(blob_key, ''), COALESCE(blob_checksum, '') 
+		FROM vaults 
+		WHERE email = ? AND id = ?`, email, id).
+		Scan(&item.Name, &item.Type, &item.ServerUpdatedAt, &item.IsDeleted, &ref.Key, &ref.Checksum)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return vault.Item{}, blob.Ref{}, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return vault.Item{}, blob.Ref{}, e.Wrap(op, err)
+	}
+
+	return item, ref, nil
+}
+
+// ListUnsizedBlobKeys returns the blob storage keys of the values without the size ordered by key,
+// the keys start after the given key, limit 0 means no limit.
+func (s *storage) ListUnsizedBlobKeys(ctx context.Context, after string, limit int) ([]string, error) {
+	const op = "sqlite: list unsized blob keys"
+
+	// negative limit means no limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT blob_key 
+		FROM vaults 
+		WHERE blob_key IS NOT NULL AND blob_size IS NULL AND blob_key > ? 
+		ORDER BY blob_key 
+		LIMIT ?;`, after, limit)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return keys, nil
+}
+
+// SetBlobSize sets the size of the value by its blob storage key if it is not set yet.
+func (s *storage) SetBlobSize(ctx context.Context, key string, size int64) error {
+	const op = "sqlite: set blob size"
+
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE vaults SET blob_size = ? WHERE blob_key = ? AND blob_size IS NULL`, size, key)
+
+	return e.Wrap(op, err)
+}
+
+// ListVaultItems returns items changed at or after since time, ordered by update time and id.
+// The items start after the cursor, limit 0 means no limit.
+func (s *storage) ListVaultItems(ctx context.Context, email string, since int64, after page.Cursor, limit int) ([]vault.Item, error) {
+	const op = "sqlite: list vault items"
+
+	// values of large binary items are not listed, they must be downloaded separately;
+	// negative limit means no limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, type, CASE WHEN type = ? THEN NULL ELSE value END, updated_at, is_deleted 
+		FROM vaults 
+		WHERE email = ? AND updated_at >= ? AND (updated_at, id) > (?, ?)
+		ORDER BY updated_at, id
+		LIMIT ?;`, vault.BinaryLarge, email, since, after.UpdatedAt, after.ID, limit)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
@@ -69,3 +250,70 @@ func (s *storage) ListVaultItems(ctx context.Context, email string, since int64)
 
 	return res, nil
 }
+
+// PurgeDeletedVaultItems deletes the deleted items updated before the given time with their revisions
+// in one transaction and returns the number of purged items. The newest update time of the purged items
+// is kept by the user as the purge watermark.
+func (s *storage) PurgeDeletedVaultItems(ctx context.Context, before int64) (int64, error) {
+	const op = "sqlite: purge deleted vault items"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users
+		SET tombstones_purged_at = MAX(users.tombstones_purged_at, p.purged_at)
+		FROM (SELECT email, MAX(updated_at) AS purged_at
+			FROM vaults
+			WHERE is_deleted AND updated_at < ?
+			GROUP BY email) AS p
+		WHERE users.email = p.email`,
+		before); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM vault_revisions
+		WHERE (email, id) IN (SELECT email, id FROM vaults WHERE is_deleted AND updated_at < ?)`,
+		before); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM vaults WHERE is_deleted AND updated_at < ?`, before)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	return count, nil
+}
+
+// GetTombstonesPurgedAt returns the purge watermark of the user: the newest update time
+// of the purged deleted items, zero if nothing was purged.
+func (s *storage) GetTombstonesPurgedAt(ctx context.Context, email string) (int64, error) {
+	const op = "sqlite: get tombstones purged at"
+
+	var t int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT tombstones_purged_at FROM users WHERE email = ?`, email).
+		Scan(&t)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, e.Wrap(op, serr.ErrRecordNotFound)
+		}
+		return 0, e.Wrap(op, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,14 @@
+package storage
+
+import "github.com/Karzoug/goph_keeper/common/model/vault"
+
+// HasNotDeleted reports whether any of the items is not deleted: only such items can exceed the quota,
+// deletion frees the space, so it is not limited.
+func HasNotDeleted(items []vault.Item) bool {
+	for _, item := range items {
+		if !item.IsDeleted {
+			return true
+		}
+	}
+	return false
+}
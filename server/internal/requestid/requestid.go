@@ -0,0 +1,51 @@
+// Package requestid passes the request ID through the context
+// and adds it to the log records, so the records of one request can be correlated.
+package requestid
+
+import (
+	"context"
+
+	"log/slog"
+)
+
+type requestIDContextKey struct{}
+
+// LogKey is the key of the request ID attribute in the log records.
+const LogKey = "request_id"
+
+// NewContext returns a copy of ctx with the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok
+}
+
+// handler is slog.Handler that adds the request ID from the context to the records.
+type handler struct {
+	slog.Handler
+}
+
+// NewHandler returns slog.Handler that adds the request ID from the context to the records
+// and passes them to h. Only the context-aware logger methods (InfoContext etc.) get the request ID.
+func NewHandler(h slog.Handler) slog.Handler {
+	return handler{Handler: h}
+}
+
+func (h handler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := FromContext(ctx); ok {
+		r.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h handler) WithGroup(name string) slog.Handler {
+	return handler{Handler: h.Handler.WithGroup(name)}
+}
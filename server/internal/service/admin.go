@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/mail"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// UserInfo is the account state of the user with the storage usage of the vault,
+// it is shown to the server administrator.
+type UserInfo struct {
+	User  user.User
+	Usage user.Usage
+}
+
+// ListUsers returns users ordered by email starting after the given email with the storage usage,
+// limit 0 means no limit. Only the account state of the users is returned, without keys.
+func (s *Service) ListUsers(ctx context.Context, after string, limit int) ([]UserInfo, error) {
+	const op = "service: list users"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	users, err := s.storage.ListUsers(ctx, after, limit)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	emails := make([]string, 0, len(users))
+	for _, u := range users {
+		emails = append(emails, u.Email)
+	}
+	usage, err := s.storage.GetUsersUsage(ctx, emails)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res := make([]UserInfo, 0, len(users))
+	for _, u := range users {
+		res = append(res, UserInfo{User: u, Usage: usage[u.Email]})
+	}
+
+	return res, nil
+}
+
+// GetUserInfo returns the account state of the user with the storage usage.
+func (s *Service) GetUserInfo(ctx context.Context, email string) (UserInfo, error) {
+	const op = "service: get user info"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUserByEmail(ctx, email)
+	if err != nil {
+		return UserInfo{}, e.Wrap(op, err)
+	}
+
+	usage, err := s.storage.GetUserUsage(ctx, email)
+	if err != nil {
+		return UserInfo{}, e.Wrap(op, err)
+	}
+
+	return UserInfo{User: u, Usage: usage}, nil
+}
+
+// VerifyUserEmail marks the user email as verified without the code sent by email,
+// the code is invalidated.
+func (s *Service) VerifyUserEmail(ctx context.Context, email string) error {
+	const op = "service: verify user email"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUserByEmail(ctx, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if u.IsEmailVerified {
+		return ErrUserEmailAlreadyVerified
+	}
+
+	if err := s.storage.SetUserEmailVerified(ctx, email); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	if err := s.caches.mail.Delete(ctx, email); err != nil &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+
+	return nil
+}
+
+// SetUserDisabled disables or enables the user account. All sessions of the disabled user
+// are revoked, so the user loses access immediately.
+func (s *Service) SetUserDisabled(ctx context.Context, email string, disabled bool) error {
+	const op = "service: set user disabled"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUserByEmail(ctx, email)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	if u.IsDisabled != disabled {
+		if err := s.storage.SetUserDisabled(ctx, email, disabled); err != nil {
+			return e.Wrap(op, err)
+		}
+	}
+
+	if !disabled {
+		return nil
+	}
+
+	return e.Wrap(op, s.revokeOtherSessions(ctx, email, ""))
+}
+
+// ExpireUserSessions revokes all sessions of the user, the user has to log in again on every device.
+func (s *Service) ExpireUserSessions(ctx context.Context, email string) error {
+	const op = "service: expire user sessions"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if _, err := s.getUserByEmail(ctx, email); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	// no current session: all sessions are revoked
+	return e.Wrap(op, s.revokeOtherSessions(ctx, email, ""))
+}
+
+// getUserByEmail returns the user without auth hash verification.
+func (s *Service) getUserByEmail(ctx context.Context, email string) (user.User, error) {
+	u, err := s.storage.GetUser(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return user.User{}, ErrUserNotExists
+		}
+		return user.User{}, err
+	}
+	return u, nil
+}
+
+// unavailable is the blob storage, task client and mail sender of the service created by NewAdmin.
+type unavailable struct{}
+
+func (unavailable) Put(context.Context, string, io.Reader) error {
+	return ErrNotAvailable
+}
+
+func (unavailable) Get(context.Context, string) (io.ReadCloser, error) {
+	return nil, ErrNotAvailable
+}
+
+func (unavailable) Delete(context.Context, string) error {
+	return ErrNotAvailable
+}
+
+func (unavailable) Close() error {
+	return nil
+}
+
+func (unavailable) Enqueue(*asynq.Task, time.Duration) error {
+	return ErrNotAvailable
+}
+
+func (unavailable) Send(context.Context, *mail.Mail) error {
+	return ErrNotAvailable
+}
+
+func (unavailable) Validate(string) error {
+	return ErrNotAvailable
+}
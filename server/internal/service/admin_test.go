@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Karzoug/goph_keeper/server/internal/config/service"
+	"github.com/Karzoug/goph_keeper/server/internal/model/session"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/smap"
+)
+
+// adminStorage is the storage of the users used by the administrator methods,
+// the other methods of Storage are not implemented.
+type adminStorage struct {
+	Storage
+	users map[string]user.User
+	usage map[string]user.Usage
+}
+
+func (s *adminStorage) GetUser(_ context.Context, email string) (user.User, error) {
+	u, ok := s.users[email]
+	if !ok {
+		return user.User{}, storage.ErrRecordNotFound
+	}
+	return u, nil
+}
+
+// updateUser applies fn to the user, as the storage updates only the columns of the method.
+func (s *adminStorage) updateUser(email string, fn func(u *user.User)) error {
+	u, ok := s.users[email]
+	if !ok {
+		return storage.ErrNoRecordsAffected
+	}
+	fn(&u)
+	s.users[email] = u
+	return nil
+}
+
+func (s *adminStorage) SetUserEmailVerified(_ context.Context, email string) error {
+	return s.updateUser(email, func(u *user.User) { u.IsEmailVerified = true })
+}
+
+func (s *adminStorage) SetUserDisabled(_ context.Context, email string, disabled bool) error {
+	return s.updateUser(email, func(u *user.User) { u.IsDisabled = disabled })
+}
+
+func (s *adminStorage) SetUserQuota(_ context.Context, email string, quota user.Quota) error {
+	return s.updateUser(email, func(u *user.User) { u.Quota = quota })
+}
+
+func (s *adminStorage) ListUsers(_ context.Context, after string, limit int) ([]user.User, error) {
+	res := make([]user.User, 0)
+	for _, u := range s.users {
+		if u.Email > after {
+			res = append(res, u)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Email < res[j].Email })
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (s *adminStorage) GetUserUsage(_ context.Context, email string, _ ...string) (user.Usage, error) {
+	return s.usage[email], nil
+}
+
+func (s *adminStorage) GetUsersUsage(_ context.Context, emails []string) (map[string]user.Usage, error) {
+	res := make(map[string]user.Usage)
+	for _, email := range emails {
+		if usage, ok := s.usage[email]; ok {
+			res[email] = usage
+		}
+	}
+	return res, nil
+}
+
+func newAdminTestService(t *testing.T, users ...user.User) (*Service, *adminStorage) {
+	t.Helper()
+
+	st := &adminStorage{
+		users: make(map[string]user.User),
+		usage: make(map[string]user.Usage),
+	}
+	for _, u := range users {
+		st.users[u.Email] = u
+	}
+
+	var cfg service.Config
+	cfg.Token.TokenLifetime = time.Minute
+	cfg.Token.RefreshTokenLifetime = time.Hour
+	cfg.Token.SecretKey = []byte("0123456789abcdef0123")
+
+	authCache := smap.New(time.Minute)
+	mailCache := smap.New(time.Minute)
+	t.Cleanup(func() {
+		authCache.Close()
+		mailCache.Close()
+	})
+
+	s, err := NewAdmin(cfg, st, WithAuthCache(authCache), WithMailCache(mailCache))
+	require.NoError(t, err)
+	return s, st
+}
+
+func TestNewAdmin(t *testing.T) {
+	_, err := NewAdmin(service.Config{}, &adminStorage{})
+	assert.ErrorIs(t, err, ErrAuthCacheNotShared)
+
+	s, _ := newAdminTestService(t)
+	assert.ErrorIs(t, s.rtaskClient.Enqueue(nil, time.Second), ErrNotAvailable)
+	assert.ErrorIs(t, s.blobStorage.Delete(context.Background(), "key"), ErrNotAvailable)
+	assert.ErrorIs(t, s.mailSender.Validate("user@example.com"), ErrNotAvailable)
+}
+
+func TestListUsers(t *testing.T) {
+	ctx := context.Background()
+	s, st := newAdminTestService(t,
+		user.User{Email: "a@example.com"},
+		user.User{Email: "b@example.com"},
+		user.User{Email: "c@example.com"},
+	)
+	st.usage["a@example.com"] = user.Usage{Items: 2, Size: 10}
+	st.usage["c@example.com"] = user.Usage{Items: 1, DeletedItems: 1, Size: 5}
+
+	infos, err := s.ListUsers(ctx, "", 0)
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+	assert.Equal(t, "a@example.com", infos[0].User.Email)
+	assert.Equal(t, st.usage["a@example.com"], infos[0].Usage)
+	assert.Equal(t, user.Usage{}, infos[1].Usage, "the user without items has zero usage")
+	assert.Equal(t, st.usage["c@example.com"], infos[2].Usage)
+
+	infos, err = s.ListUsers(ctx, "a@example.com", 1)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "b@example.com", infos[0].User.Email)
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s, st := newAdminTestService(t, user.User{Email: email})
+	require.NoError(t, s.caches.mail.Set(ctx, email, "123456", time.Minute))
+
+	require.NoError(t, s.VerifyUserEmail(ctx, email))
+	assert.True(t, st.users[email].IsEmailVerified)
+	_, err := s.caches.mail.Get(ctx, email)
+	assert.ErrorIs(t, err, storage.ErrRecordNotFound, "the code is invalidated")
+
+	assert.ErrorIs(t, s.VerifyUserEmail(ctx, email), ErrUserEmailAlreadyVerified)
+	assert.ErrorIs(t, s.VerifyUserEmail(ctx, "unknown@example.com"), ErrUserNotExists)
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s, st := newAdminTestService(t, user.User{Email: email, IsEmailVerified: true})
+
+	tokens1, err := s.setUserToAuthCache(ctx, email, session.Device{})
+	require.NoError(t, err)
+	tokens2, err := s.setUserToAuthCache(ctx, email, session.Device{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetUserDisabled(ctx, email, true))
+	assert.True(t, st.users[email].IsDisabled)
+
+	// all sessions are revoked
+	ids, err := s.getSessionIDs(ctx, email)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	for _, tokens := range []AuthTokens{tokens1, tokens2} {
+		_, err := s.AuthUser(ctx, tokens.Token, "")
+		assert.ErrorIs(t, err, ErrUserNeedAuthentication)
+		_, err = s.RefreshToken(ctx, tokens.RefreshToken, "")
+		assert.ErrorIs(t, err, ErrUserNeedAuthentication)
+	}
+
+	require.NoError(t, s.SetUserDisabled(ctx, email, false))
+	assert.False(t, st.users[email].IsDisabled)
+
+	assert.ErrorIs(t, s.SetUserDisabled(ctx, "unknown@example.com", true), ErrUserNotExists)
+}
+
+func TestRefreshTokenDisabledUser(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s, st := newAdminTestService(t, user.User{Email: email, IsEmailVerified: true})
+
+	tokens, err := s.setUserToAuthCache(ctx, email, session.Device{})
+	require.NoError(t, err)
+
+	// the user is disabled, but the session is not revoked yet
+	u := st.users[email]
+	u.IsDisabled = true
+	st.users[email] = u
+
+	_, err = s.RefreshToken(ctx, tokens.RefreshToken, "")
+	assert.ErrorIs(t, err, ErrUserDisabled)
+
+	ids, err := s.getSessionIDs(ctx, email)
+	require.NoError(t, err)
+	assert.Empty(t, ids, "the session of the disabled user is revoked")
+}
+
+func TestExpireUserSessions(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s, _ := newAdminTestService(t, user.User{Email: email, IsEmailVerified: true})
+
+	tokens, err := s.setUserToAuthCache(ctx, email, session.Device{})
+	require.NoError(t, err)
+	_, err = s.AuthUser(ctx, tokens.Token, "")
+	require.NoError(t, err)
+
+	require.NoError(t, s.ExpireUserSessions(ctx, email))
+	_, err = s.AuthUser(ctx, tokens.Token, "")
+	assert.ErrorIs(t, err, ErrUserNeedAuthentication)
+
+	assert.ErrorIs(t, s.ExpireUserSessions(ctx, "unknown@example.com"), ErrUserNotExists)
+}
+
+func TestSetUserQuota(t *testing.T) {
+	ctx := context.Background()
+	const email = "user@example.com"
+	s, st := newAdminTestService(t, user.User{Email: email})
+
+	assert.ErrorIs(t, s.SetUserQuota(ctx, email, user.Quota{MaxBytes: -1}), ErrInvalidQuota)
+
+	quota := user.Quota{MaxBytes: 1024, MaxItems: 10}
+	require.NoError(t, s.SetUserQuota(ctx, email, quota))
+	assert.Equal(t, quota, st.users[email].Quota)
+
+	assert.ErrorIs(t, s.SetUserQuota(ctx, "unknown@example.com", quota), ErrUserNotExists)
+}
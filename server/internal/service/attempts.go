@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"log/slog"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+const (
+	// attemptsEmailKeyPreffix and attemptsAddressKeyPreffix are the prefixes of the attempts cache entries
+	// with the number of failed authentication attempts.
+	attemptsEmailKeyPreffix   = "email:"
+	attemptsAddressKeyPreffix = "addr:"
+	// lockoutKeyPreffix is the prefix of the attempts cache entry that exists while the email or the address is locked out.
+	lockoutKeyPreffix = "lockout:"
+	// emailCodeMissesKeyPreffix is the prefix of the mail cache entry with the number of wrong email codes.
+	emailCodeMissesKeyPreffix = "misses:"
+)
+
+// CheckAuthAttempts returns ErrTooManyAttempts if the email or the peer address
+// is locked out because of failed authentication attempts. Empty values are not checked.
+func (s *Service) CheckAuthAttempts(ctx context.Context, email, addr string) error {
+	const op = "service: check auth attempts"
+
+	for _, key := range attemptsKeys(email, addr) {
+		_, err := s.caches.attempts.Get(ctx, lockoutKeyPreffix+key)
+		if err == nil {
+			return ErrTooManyAttempts
+		}
+		if !errors.Is(err, storage.ErrRecordNotFound) {
+			return e.Wrap(op, err)
+		}
+	}
+
+	return nil
+}
+
+// FailAuthAttempt counts the failed authentication attempt of the email and the peer address.
+// When the limit is reached the email (address) is locked out, every next failed attempt
+// doubles the lockout duration up to the configured maximum.
+func (s *Service) FailAuthAttempt(ctx context.Context, email, addr string) {
+	const op = "service: fail auth attempt"
+
+	keys := attemptsKeys(email, addr)
+	for _, key := range keys {
+		limit := s.cfg.AuthAttempts.MaxPerEmail
+		if strings.HasPrefix(key, attemptsAddressKeyPreffix) {
+			limit = s.cfg.AuthAttempts.MaxPerAddress
+		}
+
+		count, err := s.caches.attempts.Incr(ctx, key, s.cfg.AuthAttempts.Window)
+		if err != nil {
+			s.logger.WarnContext(ctx, op, sl.Error(err))
+			continue
+		}
+		if count < int64(limit) {
+			continue
+		}
+
+		lockout := s.cfg.AuthAttempts.Lockout
+		for i := int64(limit); i < count && lockout < s.cfg.AuthAttempts.MaxLockout; i++ {
+			lockout *= 2
+		}
+		if lockout > s.cfg.AuthAttempts.MaxLockout {
+			lockout = s.cfg.AuthAttempts.MaxLockout
+		}
+
+		if err := s.caches.attempts.Set(ctx, lockoutKeyPreffix+key, "1", lockout); err != nil {
+			s.logger.WarnContext(ctx, op, sl.Error(err))
+			continue
+		}
+
+		s.logger.InfoContext(ctx, "auth attempts limit reached",
+			slog.String("key", key),
+			slog.Int64("attempts", count),
+			slog.Duration("lockout", lockout))
+	}
+}
+
+// ResetAuthAttempts resets failed authentication attempts of the email after the successful one.
+// Attempts of the peer address are not reset: otherwise an attacker could reset them by logging in to own account.
+func (s *Service) ResetAuthAttempts(ctx context.Context, email string) {
+	const op = "service: reset auth attempts"
+
+	if len(email) == 0 {
+		return
+	}
+
+	if err := s.caches.attempts.Delete(ctx, attemptsEmailKeyPreffix+strings.ToLower(email)); err != nil &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
+
+// failEmailCode counts the wrong email code and invalidates the code when the limit is reached,
+// so the code can not be guessed during its lifetime.
+func (s *Service) failEmailCode(ctx context.Context, email string) {
+	const op = "fail email code"
+
+	count, err := s.caches.mail.Incr(ctx, emailCodeMissesKeyPreffix+email, s.cfg.Email.CodeLifetime)
+	if err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+		return
+	}
+	if count < int64(s.cfg.Email.CodeMaxAttempts) {
+		return
+	}
+
+	s.deleteEmailCode(ctx, email)
+
+	s.logger.InfoContext(ctx, "email code invalidated: too many wrong codes", slog.String("email", email))
+}
+
+// deleteEmailCode deletes the email code with the counter of wrong codes.
+func (s *Service) deleteEmailCode(ctx context.Context, email string) {
+	_ = s.caches.mail.Delete(ctx, email)
+	_ = s.caches.mail.Delete(ctx, emailCodeMissesKeyPreffix+email)
+}
+
+func attemptsKeys(email, addr string) []string {
+	keys := make([]string, 0, 2)
+	if len(email) != 0 {
+		keys = append(keys, attemptsEmailKeyPreffix+strings.ToLower(email))
+	}
+	if len(addr) != 0 {
+		keys = append(keys, attemptsAddressKeyPreffix+addr)
+	}
+	return keys
+}
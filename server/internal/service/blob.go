@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+)
+
+// blobSizesPageSize is the number of blob keys requested from the storage at once by BackfillBlobSizes.
+const blobSizesPageSize = 100
+
+// blobKey returns key of the large value of the vault item version in blob storage.
+// Email and id are hashed: the key must not expose the user and must be safe as a path.
+func blobKey(email, id string, version int64) string {
+	emailHash := sha256.Sum256([]byte(email))
+	idHash := sha256.Sum256([]byte(id))
+
+	return hex.EncodeToString(emailHash[:]) + "/" +
+		hex.EncodeToString(idHash[:]) + "/" +
+		strconv.FormatInt(version, 10)
+}
+
+// deleteBlob deletes value from blob storage, the error is only logged:
+// orphan value does not break anything, but takes up space.
+func (s *Service) deleteBlob(ctx context.Context, key string) {
+	const op = "delete blob"
+
+	if err := s.blobStorage.Delete(ctx, key); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
+
+// BackfillBlobSizes sets the sizes of the large binary item values stored before the sizes were kept,
+// they are counted by the quota. The sizes are read from the blob storage, the values failed to read
+// are skipped with a warning and tried again on the next run.
+func (s *Service) BackfillBlobSizes(ctx context.Context) error {
+	const op = "service: backfill blob sizes"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var (
+		after  string
+		filled int
+	)
+	for {
+		keys, err := s.storage.ListUnsizedBlobKeys(ctx, after, blobSizesPageSize)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+
+		for _, key := range keys {
+			size, err := s.blobSize(ctx, key)
+			if err != nil {
+				s.logger.WarnContext(ctx, op, slog.String("key", key), sl.Error(err))
+				continue
+			}
+			if err := s.storage.SetBlobSize(ctx, key, size); err != nil {
+				return e.Wrap(op, err)
+			}
+			filled++
+		}
+
+		if len(keys) < blobSizesPageSize {
+			break
+		}
+		after = keys[len(keys)-1]
+	}
+
+	if filled != 0 {
+		s.logger.InfoContext(ctx, "blob sizes backfilled", slog.Int("count", filled))
+	}
+	return nil
+}
+
+// blobSize returns the size of the value in blob storage by reading it.
+func (s *Service) blobSize(ctx context.Context, key string) (int64, error) {
+	rc, err := s.blobStorage.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	return io.Copy(io.Discard, rc)
+}
+
+// limitHashReader reads from r, calculates SHA-256 checksum of the read data
+// and returns ErrVaultItemValueTooBig if more than limit bytes are read.
+type limitHashReader struct {
+	r        io.Reader
+	hash     hash.Hash
+	size     int64
+	limit    int64
+	exceeded bool
+}
+
+func newLimitHashReader(r io.Reader, limit int64) *limitHashReader {
+	return &limitHashReader{
+		r:     r,
+		hash:  sha256.New(),
+		limit: limit,
+	}
+}
+
+func (r *limitHashReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.size += int64(n)
+	if r.size > r.limit {
+		r.exceeded = true
+		return 0, ErrVaultItemValueTooBig
+	}
+	r.hash.Write(p[:n])
+
+	return n, err
+}
+
+// Checksum returns hex encoded SHA-256 checksum of the read data.
+func (r *limitHashReader) Checksum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// checksumReadCloser reads from rc and returns ErrVaultItemValueCorrupted
+// instead of io.EOF if SHA-256 checksum of the read data is not equal to the expected one.
+type checksumReadCloser struct {
+	rc       io.ReadCloser
+	hash     hash.Hash
+	checksum string
+}
+
+func newChecksumReadCloser(rc io.ReadCloser, checksum string) *checksumReadCloser {
+	return &checksumReadCloser{
+		rc:       rc,
+		hash:     sha256.New(),
+		checksum: checksum,
+	}
+}
+
+func (r *checksumReadCloser) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(r.hash.Sum(nil)) != r.checksum {
+		return n, ErrVaultItemValueCorrupted
+	}
+
+	return n, err
+}
+
+func (r *checksumReadCloser) Close() error {
+	return r.rc.Close()
+}
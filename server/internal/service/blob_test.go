@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	scfg "github.com/Karzoug/goph_keeper/server/internal/config/service"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/rtask"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// blobSizesStorage keeps the sizes of the values by blob keys, -1 means the size is not set,
+// the other methods of Storage are not implemented.
+type blobSizesStorage struct {
+	Storage
+	sizes map[string]int64
+}
+
+func (s *blobSizesStorage) ListUnsizedBlobKeys(_ context.Context, after string, limit int) ([]string, error) {
+	keys := make([]string, 0)
+	for key, size := range s.sizes {
+		if size < 0 && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys, nil
+}
+
+func (s *blobSizesStorage) SetBlobSize(_ context.Context, key string, size int64) error {
+	if s.sizes[key] < 0 {
+		s.sizes[key] = size
+	}
+	return nil
+}
+
+// memBlobStorage keeps the values in memory, the other methods of BlobStorage are not implemented.
+type memBlobStorage struct {
+	BlobStorage
+	values map[string][]byte
+}
+
+func (s memBlobStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return nil, storage.ErrRecordNotFound
+	}
+	return io.NopCloser(bytes.NewReader(value)), nil
+}
+
+func TestBackfillBlobSizes(t *testing.T) {
+	st := &blobSizesStorage{sizes: make(map[string]int64)}
+	blobs := memBlobStorage{values: make(map[string][]byte)}
+
+	// more than one page of the keys
+	n := blobSizesPageSize + 10
+	for i := 0; i < n; i++ {
+		key := strconv.Itoa(i)
+		st.sizes[key] = -1
+		blobs.values[key] = bytes.Repeat([]byte{1}, i)
+	}
+	st.sizes["sized"] = 5
+	// the value is lost, so its size is left unset
+	st.sizes["lost"] = -1
+
+	s, err := New(scfg.Config{}, st, blobs, rtask.Client{}, nil,
+		WithSLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	require.NoError(t, err)
+
+	require.NoError(t, s.BackfillBlobSizes(context.Background()))
+
+	for i := 0; i < n; i++ {
+		assert.Equal(t, int64(i), st.sizes[strconv.Itoa(i)])
+	}
+	assert.Equal(t, int64(5), st.sizes["sized"], "the set size is not changed")
+	assert.Equal(t, int64(-1), st.sizes["lost"])
+}
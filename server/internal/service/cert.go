@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/session"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+)
+
+// BindCertificate binds the client certificate with the given fingerprint to the user account,
+// after that the user can log in only with this certificate.
+// The current auth hash is required to confirm the binding.
+// All sessions of the user except the session of the given token are revoked:
+// they could be started with other certificates.
+func (s *Service) BindCertificate(ctx context.Context, email string, hash []byte, fingerprint, tokenString string) error {
+	const op = "service: bind certificate"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if len(fingerprint) == 0 {
+		return ErrCertificateRequired
+	}
+
+	if _, err := s.getUser(ctx, email, hash); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	if err := s.storage.SetUserCertFingerprint(ctx, email, fingerprint); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return e.Wrap(op, s.revokeOtherSessions(ctx, email, tokenString))
+}
+
+// UnbindCertificate removes the client certificate binding from the user account.
+// The current auth hash is required to confirm it.
+func (s *Service) UnbindCertificate(ctx context.Context, email string, hash []byte) error {
+	const op = "service: unbind certificate"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUser(ctx, email, hash)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	if len(u.CertFingerprint) == 0 {
+		return nil
+	}
+
+	if err := s.storage.SetUserCertFingerprint(ctx, email, ""); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// verifyCertificate checks that the device uses the client certificate bound to the user,
+// any certificate (or none) is allowed if the user has no bound certificate.
+func verifyCertificate(u user.User, device session.Device) error {
+	if len(u.CertFingerprint) == 0 {
+		return nil
+	}
+	if u.CertFingerprint != device.CertFingerprint {
+		return ErrCertificateMismatch
+	}
+	return nil
+}
@@ -3,14 +3,36 @@ package service
 import "errors"
 
 var (
-	ErrUserAlreadyExists        = errors.New("user already exists")
-	ErrUserNotExists            = errors.New("user not exists")
-	ErrUserEmailNotVerified     = errors.New("user email not verified")
-	ErrUserInvalidHash          = errors.New("user hash not valid")
-	ErrInvalidEmailFormat       = errors.New("invalid email format")
-	ErrInvalidHashFormat        = errors.New("invalid hash format")
-	ErrInvalidTokenFormat       = errors.New("user token invalid format")
-	ErrUserNeedAuthentication   = errors.New("user need authentication")
-	ErrVaultItemVersionConflict = errors.New("vault item: conflict version")
-	ErrVaultItemValueTooBig     = errors.New("vault item: big value")
+	ErrUserAlreadyExists          = errors.New("user already exists")
+	ErrUserNotExists              = errors.New("user not exists")
+	ErrUserEmailNotVerified       = errors.New("user email not verified")
+	ErrUserEmailAlreadyVerified   = errors.New("user email already verified")
+	ErrUserInvalidHash            = errors.New("user hash not valid")
+	ErrUserDisabled               = errors.New("user disabled")
+	ErrInvalidEmailFormat         = errors.New("invalid email format")
+	ErrInvalidHashFormat          = errors.New("invalid hash format")
+	ErrInvalidTokenFormat         = errors.New("user token invalid format")
+	ErrInvalidVaultKeyFormat      = errors.New("invalid vault key format")
+	ErrUserNeedAuthentication     = errors.New("user need authentication")
+	ErrTOTPRequired               = errors.New("totp code required")
+	ErrInvalidTOTPCode            = errors.New("totp code not valid")
+	ErrTOTPAlreadyEnabled         = errors.New("totp already enabled")
+	ErrTOTPNotEnrolled            = errors.New("totp not enrolled")
+	ErrTooManyAttempts            = errors.New("too many attempts")
+	ErrVerificationCodeResent     = errors.New("verification code resent recently")
+	ErrCertificateRequired        = errors.New("client certificate required")
+	ErrCertificateMismatch        = errors.New("client certificate mismatch")
+	ErrSessionNotExists           = errors.New("session not exists")
+	ErrFullResyncRequired         = errors.New("full resync required")
+	ErrInvalidPageToken           = errors.New("invalid page token")
+	ErrVaultItemVersionConflict   = errors.New("vault item: conflict version")
+	ErrVaultItemValueTooBig       = errors.New("vault item: big value")
+	ErrVaultItemNotExists         = errors.New("vault item: not exists")
+	ErrVaultItemWrongType         = errors.New("vault item: wrong type")
+	ErrVaultItemValueCorrupted    = errors.New("vault item: corrupted value")
+	ErrVaultItemRevisionNotExists = errors.New("vault item: revision not exists")
+	ErrQuotaExceeded              = errors.New("quota exceeded")
+	ErrInvalidQuota               = errors.New("invalid quota")
+	ErrAuthCacheNotShared         = errors.New("auth cache not shared")
+	ErrNotAvailable               = errors.New("not available")
 )
@@ -21,17 +21,61 @@ import (
 func (s *Service) HandleWelcomeVerificationEmailTask(ctx context.Context, t *asynq.Task) error {
 	const op = "service: handle verification email"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	return s.sendVerificationEmail(ctx, op, t)
+}
+
+// HandleVerificationReminderEmailTask sends the reminder to verify the email
+// with the new code created by the unverified users cleanup.
+func (s *Service) HandleVerificationReminderEmailTask(ctx context.Context, t *asynq.Task) error {
+	const op = "service: handle verification reminder email"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	return s.sendVerificationEmail(ctx, op, t)
+}
+
+// sendVerificationEmail sends the email of the task type with the verification code from the mail cache.
+func (s *Service) sendVerificationEmail(ctx context.Context, op string, t *asynq.Task) error {
+	return s.sendEmail(ctx, op, t, func(email string) (any, error) {
+		code, err := s.caches.mail.Get(ctx, email)
+		if err != nil {
+			if errors.Is(err, storage.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w, %w", err, task.ErrSkipRetry)
+			}
+			return nil, err
+		}
+		return code, nil
+	})
+}
+
+// HandleAccountDeletedEmailTask sends the account deletion confirmation email,
+// the user is already deleted, so the task does not depend on the storage.
+func (s *Service) HandleAccountDeletedEmailTask(ctx context.Context, t *asynq.Task) error {
+	const op = "service: handle account deleted email"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	return s.sendEmail(ctx, op, t, func(email string) (any, error) {
+		return email, nil
+	})
+}
+
+// sendEmail sends the email of the task type to the address from the task payload,
+// valueFn returns the value of the email template for the address.
+func (s *Service) sendEmail(ctx context.Context, op string, t *asynq.Task, valueFn func(email string) (any, error)) error {
 	var p task.EmailTaskPayload
 	if err := json.Unmarshal(t.Payload(), &p); err != nil {
 		return fmt.Errorf("%s: %w, %w", op, err, task.ErrSkipRetry)
 	}
 
-	code, err := s.caches.mail.Get(ctx, p.Email)
+	value, err := valueFn(p.Email)
 	if err != nil {
-		if errors.Is(err, storage.ErrRecordNotFound) {
-			return fmt.Errorf("%s: %w, %w", op, err, task.ErrSkipRetry)
-		}
-		return err
+		return e.Wrap(op, err)
 	}
 
 	err = s.mailSender.Validate(p.Email)
@@ -39,7 +83,7 @@ func (s *Service) HandleWelcomeVerificationEmailTask(ctx context.Context, t *asy
 		return fmt.Errorf("%s: %w, %w", op, err, task.ErrSkipRetry)
 	}
 
-	m, err := s.createMail(t.Type(), p.Email, code)
+	m, err := s.createMail(t.Type(), p.Email, value)
 	if err != nil {
 		return fmt.Errorf("%s: %w, %w", op, err, task.ErrSkipRetry)
 	}
@@ -50,7 +94,8 @@ func (s *Service) HandleWelcomeVerificationEmailTask(ctx context.Context, t *asy
 		return e.Wrap(op, err)
 	}
 
-	s.logger.Debug("welcome verification mail sended to user", slog.String("email", p.Email))
+	s.logger.DebugContext(ctx, "mail sended to user",
+		slog.String("email", p.Email), slog.String("type", t.Type()))
 	return nil
 }
 
@@ -61,6 +106,10 @@ func (s *Service) createMail(typename string, email string, value any) (*rm.Mail
 	switch typename {
 	case task.TypeWelcomeVerificationEmail:
 		tpl = am.Templates[task.TypeWelcomeVerificationEmail]
+	case task.TypeVerificationReminderEmail:
+		tpl = am.Templates[task.TypeVerificationReminderEmail]
+	case task.TypeAccountDeletedEmail:
+		tpl = am.Templates[task.TypeAccountDeletedEmail]
 	default:
 		return nil, errors.New("unknown type of mail")
 	}
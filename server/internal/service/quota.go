@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// GetAccountUsage returns the storage usage of the user vault and the user quota,
+// zero quota fields mean no limit.
+func (s *Service) GetAccountUsage(ctx context.Context, email string) (user.Usage, user.Quota, error) {
+	const op = "service: get account usage"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUserByEmail(ctx, email)
+	if err != nil {
+		return user.Usage{}, user.Quota{}, e.Wrap(op, err)
+	}
+
+	usage, err := s.storage.GetUserUsage(ctx, email)
+	if err != nil {
+		return user.Usage{}, user.Quota{}, e.Wrap(op, err)
+	}
+
+	return usage, s.userQuota(u), nil
+}
+
+// SetUserQuota overrides the server default quota for the user, zero fields mean the default.
+func (s *Service) SetUserQuota(ctx context.Context, email string, quota user.Quota) error {
+	const op = "service: set user quota"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if quota.MaxBytes < 0 || quota.MaxItems < 0 {
+		return ErrInvalidQuota
+	}
+
+	if err := s.storage.SetUserQuota(ctx, email, quota); err != nil {
+		if errors.Is(err, storage.ErrNoRecordsAffected) {
+			return ErrUserNotExists
+		}
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// userQuota returns the quota of the user: the user override or the server default.
+func (s *Service) userQuota(u user.User) user.Quota {
+	q := u.Quota
+	if q.MaxBytes == 0 {
+		q.MaxBytes = s.cfg.Quota.MaxBytes
+	}
+	if q.MaxItems == 0 {
+		q.MaxItems = s.cfg.Quota.MaxItems
+	}
+	return q
+}
+
+// getUserQuota returns the quota of the user, it is checked by the storage on the vault writes.
+func (s *Service) getUserQuota(ctx context.Context, email string) (user.Quota, error) {
+	u, err := s.getUserByEmail(ctx, email)
+	if err != nil {
+		return user.Quota{}, err
+	}
+	return s.userQuota(u), nil
+}
+
+// remainingQuotaBytes returns the number of bytes left in the quota after the item is set,
+// it is negative if the quota is exceeded and math.MaxInt64 if the size is not limited.
+// ErrQuotaExceeded is returned if the number of items exceeds the quota.
+// The storage checks the quota again on the write, it is needed only to limit the value read by stream.
+func (s *Service) remainingQuotaBytes(ctx context.Context, email string, quota user.Quota, item vault.Item) (int64, error) {
+	if !quota.IsLimited() {
+		return math.MaxInt64, nil
+	}
+
+	// the current version of the item is replaced, so it is not counted
+	usage, err := s.storage.GetUserUsage(ctx, email, item.ID)
+	if err != nil {
+		return 0, err
+	}
+	if !item.IsDeleted {
+		usage.Items++
+		usage.Size += int64(len(item.Value))
+	}
+
+	if quota.MaxItems != 0 && usage.Items > quota.MaxItems {
+		return 0, ErrQuotaExceeded
+	}
+	if quota.MaxBytes == 0 {
+		return math.MaxInt64, nil
+	}
+	return quota.MaxBytes - usage.Size, nil
+}
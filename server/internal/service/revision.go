@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// ListItemRevisions returns the kept previous versions of the vault item, newest first.
+// The version of the revision is its ServerUpdatedAt, it is passed to RestoreItemRevision.
+// Large binary items have no revisions: their values are in the blob storage.
+func (s *Service) ListItemRevisions(ctx context.Context, email, id string) ([]vault.Item, error) {
+	const op = "service: list item revisions"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	items, err := s.storage.ListItemRevisions(ctx, email, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return items, nil
+}
+
+// RestoreItemRevision replaces the vault item with its revision, so the restored version
+// is synchronized to all devices as a usual change. The replaced version becomes a revision too,
+// except a large binary item: its value is deleted from the blob storage.
+// It returns the new server update time of the item.
+func (s *Service) RestoreItemRevision(ctx context.Context, email, id string, revision int64) (int64, error) {
+	const op = "service: restore item revision"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	quota, err := s.getUserQuota(ctx, email)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	t := time.Now().UnixMicro()
+	replacedBlobKey, err := s.storage.RestoreItemRevision(ctx, email, id, revision, t, quota)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRecordNotFound):
+			return 0, e.Wrap(op, ErrVaultItemRevisionNotExists)
+		case errors.Is(err, storage.ErrQuotaExceeded):
+			return 0, e.Wrap(op, ErrQuotaExceeded)
+		}
+		return 0, e.Wrap(op, err)
+	}
+
+	// value of the replaced large binary item is not needed anymore
+	if replacedBlobKey != "" {
+		s.deleteBlob(ctx, replacedBlobKey)
+	}
+
+	s.trimRevisions(ctx, email, id)
+	s.vaultUpdated(ctx, email, t)
+
+	return t, nil
+}
+
+// trimRevisions deletes the revisions of the items above the configured number,
+// the extra revisions do not break anything, so the error is only logged.
+func (s *Service) trimRevisions(ctx context.Context, email string, ids ...string) {
+	const op = "trim revisions"
+
+	if err := s.storage.TrimItemRevisions(ctx, email, s.cfg.ItemRevisions, ids...); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
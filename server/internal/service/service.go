@@ -2,25 +2,106 @@ package service
 
 import (
 	"context"
+	"io"
 	"os"
 	"time"
 
 	"log/slog"
 
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+
 	"github.com/Karzoug/goph_keeper/common/model/vault"
 	scfg "github.com/Karzoug/goph_keeper/server/internal/config/service"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/page"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
 	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/mail"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/rtask"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/memps"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage/smap"
 )
 
 type Storage interface {
 	AddUser(context.Context, user.User) error
 	GetUser(ctx context.Context, email string) (user.User, error)
-	UpdateUser(context.Context, user.User) error
-	SetVaultItem(ctx context.Context, email string, item vault.Item) error
-	ListVaultItems(ctx context.Context, email string, since int64) ([]vault.Item, error)
+	// SetUserEmailVerified marks the user email as verified.
+	SetUserEmailVerified(ctx context.Context, email string) error
+	// SetUserAuthKey replaces the user auth key and the wrapped vault key.
+	SetUserAuthKey(ctx context.Context, email string, key auth.Key, wrappedVaultKey []byte) error
+	// SetUserTOTP sets the user second factor: the secret, whether it is enabled and the hashes of the recovery codes.
+	SetUserTOTP(ctx context.Context, email string, secret totp.Secret, enabled bool, recoveryCodes []string) error
+	// SetUserCertFingerprint binds the client certificate to the user, empty fingerprint unbinds it.
+	SetUserCertFingerprint(ctx context.Context, email, fingerprint string) error
+	// SetUserDisabled disables or enables the user.
+	SetUserDisabled(ctx context.Context, email string, disabled bool) error
+	// SetUserQuota overrides the server default quota for the user.
+	SetUserQuota(ctx context.Context, email string, quota user.Quota) error
+	// UpdateTOTPRecoveryCodes replaces the user totp recovery codes only if they are still equal to the old ones,
+	// otherwise it returns ErrNoRecordsAffected.
+	UpdateTOTPRecoveryCodes(ctx context.Context, email string, old, new []string) error
+	// ListUsers returns users ordered by email starting after the given email, limit 0 means no limit.
+	// Only the account state is returned, without keys and second factor data.
+	ListUsers(ctx context.Context, after string, limit int) ([]user.User, error)
+	// ListUnverifiedUsers returns the users with not verified email created before the given time,
+	// only the account state is returned.
+	ListUnverifiedUsers(ctx context.Context, createdBefore time.Time) ([]user.User, error)
+	// GetUserUsage returns the storage usage of the user vault, the items with the excluded IDs
+	// and the item revisions are not counted.
+	GetUserUsage(ctx context.Context, email string, exclude ...string) (user.Usage, error)
+	// GetUsersUsage returns the storage usage of the users vaults by email,
+	// the users without vault items are not in the map.
+	GetUsersUsage(ctx context.Context, emails []string) (map[string]user.Usage, error)
+	// ChangePassword updates the user auth key with the wrapped vault key and replaces the vault items in one transaction,
+	// values of large binary items are passed by references mapped by item ID.
+	// It returns ErrNoRecordsAffected if any item version conflicts or any not deleted item is not passed.
+	ChangePassword(ctx context.Context, u user.User, items []vault.Item, refs map[string]blob.Ref) error
+	// DeleteUser deletes the user with all the vault items in one transaction
+	// and returns the blob storage keys of the large binary items values.
+	DeleteUser(ctx context.Context, email string) ([]string, error)
+	// DeleteUnverifiedUser deletes the user if the email is still not verified,
+	// otherwise it returns ErrRecordNotFound.
+	DeleteUnverifiedUser(ctx context.Context, email string) error
+	// SetVerificationRemindedAt sets the reminder time of the user if the email is still not verified,
+	// otherwise it returns ErrRecordNotFound.
+	SetVerificationRemindedAt(ctx context.Context, email string, t time.Time) error
+	// SetVaultItem sets the item, it returns ErrNoRecordsAffected if the item version conflicts.
+	// The usage is checked against the quota in the same transaction after the item is set,
+	// ErrQuotaExceeded is returned if the not deleted item does not fit, zero quota fields mean no limit.
+	SetVaultItem(ctx context.Context, email string, item vault.Item, quota user.Quota) error
+	// SetVaultItems sets items in one transaction and returns an error for every item:
+	// nil if the item is set or ErrNoRecordsAffected if the item version conflicts.
+	// It returns ErrQuotaExceeded and sets nothing if the set items do not fit the quota,
+	// the quota is not checked if only deleted items are set.
+	SetVaultItems(ctx context.Context, email string, items []vault.Item, quota user.Quota) ([]error, error)
+	// SetLargeVaultItem sets the item with the value in the blob storage, the quota is checked as by SetVaultItem.
+	SetLargeVaultItem(ctx context.Context, email string, item vault.Item, ref blob.Ref, quota user.Quota) error
+	GetLargeVaultItem(ctx context.Context, email, id string) (vault.Item, blob.Ref, error)
+	// ListUnsizedBlobKeys returns the blob storage keys of the values without the size ordered by key,
+	// the sizes were not kept before the quotas. The keys start after the given key, limit 0 means no limit.
+	ListUnsizedBlobKeys(ctx context.Context, after string, limit int) ([]string, error)
+	// SetBlobSize sets the size of the value by its blob storage key if it is not set yet.
+	SetBlobSize(ctx context.Context, key string, size int64) error
+	// ListVaultItems returns items changed at or after since time, ordered by update time and id.
+	// The items start after the cursor, limit 0 means no limit.
+	ListVaultItems(ctx context.Context, email string, since int64, after page.Cursor, limit int) ([]vault.Item, error)
+	// PurgeDeletedVaultItems deletes the deleted items updated before the given time with their revisions
+	// and returns the number of purged items.
+	PurgeDeletedVaultItems(ctx context.Context, before int64) (int64, error)
+	// GetTombstonesPurgedAt returns the newest update time of the purged deleted items of the user,
+	// zero if nothing was purged.
+	GetTombstonesPurgedAt(ctx context.Context, email string) (int64, error)
+	// ListItemRevisions returns the kept previous versions of the vault item, newest first.
+	// Set methods keep the replaced versions of not deleted items with values in the storage.
+	ListItemRevisions(ctx context.Context, email, id string) ([]vault.Item, error)
+	GetItemRevision(ctx context.Context, email, id string, revision int64) (vault.Item, error)
+	// RestoreItemRevision replaces the vault item with its revision, the item gets the new update time.
+	// The quota is checked as by SetVaultItem. It returns the blob key of the replaced large binary item value.
+	RestoreItemRevision(ctx context.Context, email, id string, revision, updatedAt int64, quota user.Quota) (string, error)
+	// TrimItemRevisions deletes all but the keep newest revisions of the vault items.
+	TrimItemRevisions(ctx context.Context, email string, keep int, ids ...string) error
 	Close() error
 }
 
@@ -28,47 +109,120 @@ type KvStorage interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// SetNX sets value by key only if the key does not exist and reports whether the value is set.
+	SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	// SetXX sets value by key only if the key exists and reports whether the value is set.
+	SetXX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	// Incr increments the counter by key and prolongs its expiration, it returns the new value.
+	// The missing counter starts from zero.
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	// GetDel returns value by key and deletes it atomically, so only one of concurrent callers gets the value.
+	GetDel(ctx context.Context, key string) (string, error)
+	// SAdd adds member to the set by key and prolongs the set expiration.
+	SAdd(ctx context.Context, key, member string, expiration time.Duration) error
+	// SMembers returns all members of the set by key, empty if the set does not exist.
+	SMembers(ctx context.Context, key string) ([]string, error)
+	// SRem removes members from the set by key.
+	SRem(ctx context.Context, key string, members ...string) error
+	Close() error
+}
+
+// PubSub is a publish/subscribe broker, it notifies all server instances about vault changes.
+type PubSub interface {
+	Publish(ctx context.Context, channel, message string) error
+	// Subscribe returns messages of the channel. Returned go channel is closed when ctx is done.
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
 	Close() error
 }
 
+// BlobStorage is a storage of large values (large binary vault items).
+type BlobStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+type taskClient interface {
+	Enqueue(task *asynq.Task, timeout time.Duration) error
+}
+
 type mailSender interface {
 	Send(context.Context, *mail.Mail) error
 	Validate(email string) error
 }
 
+var tracer = otel.Tracer("github.com/Karzoug/goph_keeper/server/internal/service")
+
 type Option func(*Service)
 
 type caches struct {
 	auth       KvStorage
 	mail       KvStorage
 	lastUpdate KvStorage
+	attempts   KvStorage
 }
 
 type Service struct {
 	cfg         scfg.Config
 	storage     Storage
+	blobStorage BlobStorage
 	caches      caches
-	rtaskClient rtask.Client
+	pubsub      PubSub
+	rtaskClient taskClient
 	mailSender  mailSender
 	logger      *slog.Logger
 }
 
 func New(cfg scfg.Config,
 	storage Storage,
+	blobStorage BlobStorage,
 	rtaskClient rtask.Client,
 	mailSender mailSender,
 	options ...Option) (*Service, error) {
 	s := &Service{
 		cfg:         cfg,
 		storage:     storage,
-		rtaskClient: rtaskClient,
+		blobStorage: blobStorage,
+		rtaskClient: &rtaskClient,
 		mailSender:  mailSender,
 	}
 
 	for _, opt := range options {
 		opt(s)
 	}
+	s.setDefaults()
 
+	return s, nil
+}
+
+// NewAdmin returns the service for the administrator commands, it works with the same storage
+// and caches as the server. The blob storage, task manager and mail are not used by the commands,
+// so the methods that need them return ErrNotAvailable.
+// The sessions are revoked in the auth cache, so it must be the cache of the server (WithAuthCache),
+// otherwise ErrAuthCacheNotShared is returned.
+func NewAdmin(cfg scfg.Config, storage Storage, options ...Option) (*Service, error) {
+	s := &Service{
+		cfg:         cfg,
+		storage:     storage,
+		blobStorage: unavailable{},
+		rtaskClient: unavailable{},
+		mailSender:  unavailable{},
+	}
+
+	for _, opt := range options {
+		opt(s)
+	}
+	if s.caches.auth == nil {
+		return nil, ErrAuthCacheNotShared
+	}
+	s.setDefaults()
+
+	return s, nil
+}
+
+// setDefaults sets the dependencies not passed by options: in-memory caches and pub/sub of one server instance.
+func (s *Service) setDefaults() {
 	if s.logger == nil {
 		s.logger = slog.New(
 			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
@@ -84,10 +238,15 @@ func New(cfg scfg.Config,
 	if s.caches.lastUpdate == nil {
 		s.caches.lastUpdate = smap.New(30 * time.Minute)
 	}
+	if s.caches.attempts == nil {
+		s.caches.attempts = smap.New(30 * time.Minute)
+	}
 
-	s.logger = s.logger.With("from", "service")
+	if s.pubsub == nil {
+		s.pubsub = memps.New()
+	}
 
-	return s, nil
+	s.logger = s.logger.With("from", "service")
 }
 
 func WithSLogger(logger *slog.Logger) Option {
@@ -113,3 +272,15 @@ func WithLastUpdateCache(cache KvStorage) Option {
 		s.caches.lastUpdate = cache
 	}
 }
+
+func WithAttemptsCache(cache KvStorage) Option {
+	return func(s *Service) {
+		s.caches.attempts = cache
+	}
+}
+
+func WithPubSub(pubsub PubSub) Option {
+	return func(s *Service) {
+		s.pubsub = pubsub
+	}
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth/token"
+	"github.com/Karzoug/goph_keeper/server/internal/model/session"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+// Keys of the auth cache entries, the token entries are stored by token ID:
+// the prefix separates refresh tokens from tokens, so the refresh token
+// can not be used to access the vault and vice versa.
+const (
+	refreshTokenKeyPreffix = "refresh:"
+	sessionKeyPreffix      = "session:"
+	// sessionsKeyPreffix is the prefix of the set of the user session IDs.
+	sessionsKeyPreffix = "session_ids:"
+)
+
+// AuthTokens is a pair of tokens issued on login.
+type AuthTokens struct {
+	// Token is a short-lived token to access the vault.
+	Token string
+	// RefreshToken is a long-lived one-time token to get a new pair of tokens.
+	RefreshToken string
+	// TokenLifetime is the lifetime of the Token.
+	TokenLifetime time.Duration
+}
+
+// tokenData is a value of the token or refresh token in auth cache.
+type tokenData struct {
+	Email     string `json:"email"`
+	SessionID string `json:"session_id"`
+	// CertFingerprint is the fingerprint of the client certificate of the session,
+	// the token can be used only with this certificate.
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+}
+
+// ListSessions returns active sessions of the user sorted by creation time
+// and ID of the session that the given token belongs to.
+func (s *Service) ListSessions(ctx context.Context, email, tokenString string) ([]session.Session, string, error) {
+	const op = "service: list sessions"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	currentID := s.currentSessionID(ctx, tokenString)
+
+	ids, err := s.getSessionIDs(ctx, email)
+	if err != nil {
+		return nil, "", e.Wrap(op, err)
+	}
+
+	sessions := make([]session.Session, 0, len(ids))
+	var expired []string
+	for i := 0; i < len(ids); i++ {
+		sess, err := s.getSession(ctx, ids[i])
+		if err != nil {
+			// case: session expired
+			if errors.Is(err, ErrSessionNotExists) {
+				expired = append(expired, ids[i])
+				continue
+			}
+			return nil, "", e.Wrap(op, err)
+		}
+		sessions = append(sessions, sess)
+	}
+
+	// forget expired sessions
+	if len(expired) != 0 {
+		if err := s.caches.auth.SRem(ctx, sessionsKeyPreffix+email, expired...); err != nil {
+			return nil, "", e.Wrap(op, err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+
+	return sessions, currentID, nil
+}
+
+// RevokeSession revokes the session of the user: the token and the refresh token of the session.
+func (s *Service) RevokeSession(ctx context.Context, email, id string) error {
+	const op = "service: revoke session"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	sess, err := s.getSession(ctx, id)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	// the user can revoke only own session
+	if sess.Email != email {
+		return e.Wrap(op, ErrSessionNotExists)
+	}
+
+	// the session is taken atomically, so the tokens renewed by the concurrent refresh are revoked too:
+	// the refresh renews only the session that still exists
+	sess, err = s.takeSession(ctx, id)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	for _, key := range []string{
+		sess.TokenID,
+		refreshTokenKeyPreffix + sess.RefreshTokenID,
+	} {
+		if err := s.caches.auth.Delete(ctx, key); err != nil &&
+			!errors.Is(err, storage.ErrNoRecordsAffected) {
+			return e.Wrap(op, err)
+		}
+	}
+
+	return e.Wrap(op, s.caches.auth.SRem(ctx, sessionsKeyPreffix+email, id))
+}
+
+// revokeOtherSessions revokes all sessions of the user except the session that the given token belongs to.
+func (s *Service) revokeOtherSessions(ctx context.Context, email, tokenString string) error {
+	currentID := s.currentSessionID(ctx, tokenString)
+
+	ids, err := s.getSessionIDs(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(ids); i++ {
+		if ids[i] == currentID {
+			continue
+		}
+		if err := s.RevokeSession(ctx, email, ids[i]); err != nil &&
+			!errors.Is(err, ErrSessionNotExists) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setUserToAuthCache starts a new user session on the device and returns its tokens.
+func (s *Service) setUserToAuthCache(ctx context.Context, email string, device session.Device) (AuthTokens, error) {
+	sess := session.New(email, device)
+
+	tokens, err := s.setSessionTokens(ctx, &sess, false)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	return tokens, s.addSessionID(ctx, email, sess.ID)
+}
+
+// setSessionTokens issues a new pair of tokens of the session and saves them with the session to auth cache.
+// The existing session is renewed only if it is not revoked meanwhile, otherwise the new tokens are deleted
+// and ErrSessionNotExists is returned.
+func (s *Service) setSessionTokens(ctx context.Context, sess *session.Session, renew bool) (AuthTokens, error) {
+	eTime := time.Now().Add(s.cfg.Token.TokenLifetime)
+	t := token.New(eTime, s.cfg.Token.SecretKey)
+
+	reTime := time.Now().Add(s.cfg.Token.RefreshTokenLifetime)
+	rt := token.New(reTime, s.cfg.Token.SecretKey)
+
+	sess.TokenID = t.ID()
+	sess.RefreshTokenID = rt.ID()
+
+	data, err := json.Marshal(tokenData{
+		Email:           sess.Email,
+		SessionID:       sess.ID,
+		CertFingerprint: sess.Device.CertFingerprint,
+	})
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	sessData, err := json.Marshal(sess)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	if err := s.caches.auth.Set(ctx, t.ID(), string(data), time.Until(eTime)); err != nil {
+		return AuthTokens{}, err
+	}
+	if err := s.caches.auth.Set(ctx, refreshTokenKeyPreffix+rt.ID(), string(data), time.Until(reTime)); err != nil {
+		return AuthTokens{}, err
+	}
+
+	// the session lives as long as its refresh token, it is written after the tokens,
+	// so the session revoked after the write deletes them too
+	if !renew {
+		if err := s.caches.auth.Set(ctx, sessionKeyPreffix+sess.ID, string(sessData), time.Until(reTime)); err != nil {
+			return AuthTokens{}, err
+		}
+	} else {
+		ok, err := s.caches.auth.SetXX(ctx, sessionKeyPreffix+sess.ID, string(sessData), time.Until(reTime))
+		if err != nil {
+			return AuthTokens{}, err
+		}
+		if !ok {
+			for _, key := range []string{t.ID(), refreshTokenKeyPreffix + rt.ID()} {
+				if err := s.caches.auth.Delete(ctx, key); err != nil &&
+					!errors.Is(err, storage.ErrNoRecordsAffected) {
+					return AuthTokens{}, err
+				}
+			}
+			return AuthTokens{}, ErrSessionNotExists
+		}
+	}
+
+	return AuthTokens{
+		Token:         t.String(),
+		RefreshToken:  rt.String(),
+		TokenLifetime: s.cfg.Token.TokenLifetime,
+	}, nil
+}
+
+// currentSessionID returns ID of the session that the given token belongs to
+// or empty string if the token is not valid.
+func (s *Service) currentSessionID(ctx context.Context, tokenString string) string {
+	t, err := token.FromString(tokenString, s.cfg.Token.SecretKey)
+	if err != nil {
+		return ""
+	}
+	data, err := s.getTokenData(ctx, t.ID())
+	if err != nil {
+		return ""
+	}
+	return data.SessionID
+}
+
+// getTokenData returns data of the token by key from auth cache.
+func (s *Service) getTokenData(ctx context.Context, key string) (tokenData, error) {
+	value, err := s.caches.auth.Get(ctx, key)
+	return parseTokenData(value, err)
+}
+
+// takeTokenData returns data of the single-use token by key from auth cache and deletes it atomically,
+// so the token can not be used by concurrent requests.
+func (s *Service) takeTokenData(ctx context.Context, key string) (tokenData, error) {
+	value, err := s.caches.auth.GetDel(ctx, key)
+	return parseTokenData(value, err)
+}
+
+// parseTokenData parses data of the token got from auth cache.
+func parseTokenData(value string, err error) (tokenData, error) {
+	var data tokenData
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return data, ErrUserNeedAuthentication
+		}
+		return data, err
+	}
+
+	// tokens issued by previous versions contain email only
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return data, ErrUserNeedAuthentication
+	}
+
+	return data, nil
+}
+
+// getSession returns session by ID from auth cache.
+func (s *Service) getSession(ctx context.Context, id string) (session.Session, error) {
+	value, err := s.caches.auth.Get(ctx, sessionKeyPreffix+id)
+	return parseSession(value, err)
+}
+
+// takeSession returns the session by ID from auth cache and deletes it atomically.
+func (s *Service) takeSession(ctx context.Context, id string) (session.Session, error) {
+	value, err := s.caches.auth.GetDel(ctx, sessionKeyPreffix+id)
+	return parseSession(value, err)
+}
+
+// parseSession parses the session got from auth cache.
+func parseSession(value string, err error) (session.Session, error) {
+	var sess session.Session
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return sess, ErrSessionNotExists
+		}
+		return sess, err
+	}
+
+	if err := json.Unmarshal([]byte(value), &sess); err != nil {
+		return sess, err
+	}
+
+	return sess, nil
+}
+
+// getSessionIDs returns IDs of the user sessions from auth cache, some of them can be expired.
+func (s *Service) getSessionIDs(ctx context.Context, email string) ([]string, error) {
+	return s.caches.auth.SMembers(ctx, sessionsKeyPreffix+email)
+}
+
+// addSessionID adds ID of the created or refreshed session to the user sessions set,
+// so the set lives as long as the last used session.
+func (s *Service) addSessionID(ctx context.Context, email, id string) error {
+	return s.caches.auth.SAdd(ctx, sessionsKeyPreffix+email, id, s.cfg.Token.RefreshTokenLifetime)
+}
@@ -1,30 +1,101 @@
 package task
 
 import (
+	"context"
+
 	"github.com/goccy/go-json"
 
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // A list of task types.
 const (
-	TypeWelcomeVerificationEmail = "email:welcome_verification"
+	TypeWelcomeVerificationEmail  = "email:welcome_verification"
+	TypeVerificationReminderEmail = "email:verification_reminder"
+	TypeAccountDeletedEmail       = "email:account_deleted"
+	TypePurgeTombstones           = "vault:purge_tombstones"
+	TypeCleanupUnverifiedUsers    = "user:cleanup_unverified"
 )
 
 // ErrSkipRetry is used as a return value from handler to indicate that
 // the task should not be retried and should be archived instead.
 var ErrSkipRetry = asynq.SkipRetry
 
+// TracePayload is embedded into the payload of all tasks to propagate the trace context
+// of the request that created the task to the task handler.
+type TracePayload struct {
+	Trace propagation.MapCarrier `json:",omitempty"`
+}
+
 // EmailTaskPayload is the payload of all email tasks.
 type EmailTaskPayload struct {
+	TracePayload
 	Email string
 }
 
 // NewVerificationEmailTask creates a new verification email task.
-func NewWelcomeVerificationEmailTask(email, code string) (*asynq.Task, error) {
-	payload, err := json.Marshal(EmailTaskPayload{Email: email})
+func NewWelcomeVerificationEmailTask(ctx context.Context, email, code string) (*asynq.Task, error) {
+	payload, err := json.Marshal(EmailTaskPayload{
+		TracePayload: newTracePayload(ctx),
+		Email:        email,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return asynq.NewTask(TypeWelcomeVerificationEmail, payload), nil
 }
+
+// NewVerificationReminderEmailTask creates a new task to remind the user to verify the email,
+// the new verification code is taken from the mail cache as for the welcome email.
+func NewVerificationReminderEmailTask(ctx context.Context, email string) (*asynq.Task, error) {
+	payload, err := json.Marshal(EmailTaskPayload{
+		TracePayload: newTracePayload(ctx),
+		Email:        email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeVerificationReminderEmail, payload), nil
+}
+
+// NewAccountDeletedEmailTask creates a new task to confirm the account deletion by email.
+func NewAccountDeletedEmailTask(ctx context.Context, email string) (*asynq.Task, error) {
+	payload, err := json.Marshal(EmailTaskPayload{
+		TracePayload: newTracePayload(ctx),
+		Email:        email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeAccountDeletedEmail, payload), nil
+}
+
+// NewPurgeTombstonesTask creates a new task to purge the deleted vault items older than the retention,
+// it is scheduled periodically, so it has no payload.
+func NewPurgeTombstonesTask() *asynq.Task {
+	return asynq.NewTask(TypePurgeTombstones, nil)
+}
+
+// NewCleanupUnverifiedUsersTask creates a new task to remind and delete the users that never verified the email,
+// it is scheduled periodically, so it has no payload.
+func NewCleanupUnverifiedUsersTask() *asynq.Task {
+	return asynq.NewTask(TypeCleanupUnverifiedUsers, nil)
+}
+
+// ExtractTraceContext returns a copy of ctx with the trace context from the task payload.
+// If the payload has no trace context, ctx is returned unchanged.
+func ExtractTraceContext(ctx context.Context, t *asynq.Task) context.Context {
+	var p TracePayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil || len(p.Trace) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, p.Trace)
+}
+
+func newTracePayload(ctx context.Context) TracePayload {
+	carrier := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return TracePayload{Trace: carrier}
+}
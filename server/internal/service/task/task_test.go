@@ -0,0 +1,60 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want trace.SpanContext
+	}{
+		{
+			name: "positive: trace context is propagated",
+			ctx:  trace.ContextWithSpanContext(context.Background(), sc),
+			want: sc.WithRemote(true),
+		},
+		{
+			name: "negative: no trace context",
+			ctx:  context.Background(),
+			want: trace.SpanContext{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsk, err := NewAccountDeletedEmailTask(tt.ctx, "test@example.com")
+			require.NoError(t, err)
+
+			got := trace.SpanContextFromContext(ExtractTraceContext(context.Background(), tsk))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("negative: task without payload", func(t *testing.T) {
+		ctx := context.Background()
+		got := ExtractTraceContext(ctx, NewPurgeTombstonesTask())
+		assert.Equal(t, ctx, got)
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+)
+
+// HandlePurgeTombstonesTask deletes the deleted vault items older than the retention:
+// all the devices synchronized within the retention already know about the deletion.
+func (s *Service) HandlePurgeTombstonesTask(ctx context.Context, t *asynq.Task) error {
+	const op = "service: handle purge tombstones"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if s.cfg.TombstoneRetention <= 0 {
+		return nil
+	}
+
+	count, err := s.storage.PurgeDeletedVaultItems(ctx, s.tombstonesPurgedBefore())
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted vault items purged", slog.Int64("count", count))
+	return nil
+}
+
+// isFullResyncRequired reports whether the deleted items changed after since are purged already,
+// so the client with the vault synchronized at since time must synchronize the whole vault again.
+// The purge watermark of the user is the newest update time of the purged items.
+func (s *Service) isFullResyncRequired(ctx context.Context, email string, since int64) (bool, error) {
+	if since == 0 {
+		return false, nil
+	}
+
+	purgedAt, err := s.storage.GetTombstonesPurgedAt(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return since < purgedAt, nil
+}
+
+// tombstonesPurgedBefore returns the time (in microseconds, as the vault item update time)
+// the deleted items updated before are purged.
+func (s *Service) tombstonesPurgedBefore() int64 {
+	return time.Now().Add(-s.cfg.TombstoneRetention).UnixMicro()
+}
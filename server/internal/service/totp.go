@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"log/slog"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/model/totp"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+)
+
+const (
+	// totpIssuer is the name of the service shown in the authenticator app.
+	totpIssuer = "GophKeeper"
+	// totpRecoveryCodesCount is the number of recovery codes issued on the enrollment confirmation.
+	totpRecoveryCodesCount = 10
+	// totpUsedStepTTL is longer than the time the code is accepted (with skew),
+	// so the used code can not be replayed.
+	totpUsedStepTTL = 2 * time.Minute
+	// totpUsedStepKeyPreffix is the prefix of the auth cache entry with the last used time step of the user code.
+	totpUsedStepKeyPreffix = "totp:"
+	// totpClaimedStepKeyPreffix is the prefix of the auth cache entry that marks the time step of the user code as used,
+	// the entry is set only if it does not exist, so only one of concurrent logins uses the code.
+	totpClaimedStepKeyPreffix = "totp_step:"
+)
+
+// EnrollTOTP generates a new second factor secret of the user and returns it
+// with the key URI for the authenticator app. The second factor is not enabled
+// until the enrollment is confirmed by ConfirmTOTP.
+func (s *Service) EnrollTOTP(ctx context.Context, email string) (totp.Secret, string, error) {
+	const op = "service: enroll totp"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.storage.GetUser(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return nil, "", ErrUserNotExists
+		}
+		return nil, "", e.Wrap(op, err)
+	}
+	if u.IsTOTPEnabled {
+		return nil, "", ErrTOTPAlreadyEnabled
+	}
+
+	u.TOTPSecret, err = totp.NewSecret()
+	if err != nil {
+		return nil, "", e.Wrap(op, err)
+	}
+	if err := s.storage.SetUserTOTP(ctx, email, u.TOTPSecret, false, nil); err != nil {
+		return nil, "", e.Wrap(op, err)
+	}
+
+	return u.TOTPSecret, u.TOTPSecret.URI(totpIssuer, email), nil
+}
+
+// ConfirmTOTP enables the second factor if the code matches the enrolled secret
+// and returns single-use recovery codes, only their hashes are stored.
+func (s *Service) ConfirmTOTP(ctx context.Context, email, code string) ([]string, error) {
+	const op = "service: confirm totp"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.storage.GetUser(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return nil, ErrUserNotExists
+		}
+		return nil, e.Wrap(op, err)
+	}
+	if u.IsTOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if len(u.TOTPSecret) == 0 {
+		return nil, ErrTOTPNotEnrolled
+	}
+
+	step, ok := u.TOTPSecret.Verify(code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes, hashes, err := totp.NewRecoveryCodes(totpRecoveryCodesCount)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := s.storage.SetUserTOTP(ctx, email, u.TOTPSecret, true, hashes); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	s.setTOTPUsedStep(ctx, email, step)
+
+	s.logger.DebugContext(ctx, "user totp enabled", slog.String("email", email))
+
+	return codes, nil
+}
+
+// verifySecondFactor verifies the code of the user authenticator app or the recovery code
+// if the user has enabled the second factor. The used recovery code is removed.
+func (s *Service) verifySecondFactor(ctx context.Context, u *user.User, code string) error {
+	const op = "verify second factor"
+
+	if !u.IsTOTPEnabled {
+		return nil
+	}
+	if len(code) == 0 {
+		return ErrTOTPRequired
+	}
+
+	if step, ok := u.TOTPSecret.Verify(code, time.Now()); ok {
+		// the code is valid for a while, but it can be used only once
+		if step <= s.getTOTPUsedStep(ctx, u.Email) {
+			return ErrInvalidTOTPCode
+		}
+		claimed, err := s.caches.auth.SetNX(ctx,
+			totpClaimedStepKeyPreffix+u.Email+":"+strconv.FormatInt(step, 10), "", totpUsedStepTTL)
+		if err != nil {
+			return e.Wrap(op, err)
+		}
+		if !claimed {
+			return ErrInvalidTOTPCode
+		}
+		s.setTOTPUsedStep(ctx, u.Email, step)
+		return nil
+	}
+
+	hash := totp.HashRecoveryCode(code)
+	for i := 0; i < len(u.TOTPRecoveryCodes); i++ {
+		if u.TOTPRecoveryCodes[i] != hash {
+			continue
+		}
+		codes := make([]string, 0, len(u.TOTPRecoveryCodes)-1)
+		codes = append(codes, u.TOTPRecoveryCodes[:i]...)
+		codes = append(codes, u.TOTPRecoveryCodes[i+1:]...)
+		// the codes are replaced only if they are not changed since they were read,
+		// so the code can not be used by concurrent logins twice
+		if err := s.storage.UpdateTOTPRecoveryCodes(ctx, u.Email, u.TOTPRecoveryCodes, codes); err != nil {
+			if errors.Is(err, storage.ErrNoRecordsAffected) {
+				return ErrInvalidTOTPCode
+			}
+			return e.Wrap(op, err)
+		}
+		u.TOTPRecoveryCodes = codes
+		s.logger.DebugContext(ctx, "user totp recovery code used", slog.String("email", u.Email))
+		return nil
+	}
+
+	return ErrInvalidTOTPCode
+}
+
+// getTOTPUsedStep returns the last used time step of the user code or zero if unknown.
+func (s *Service) getTOTPUsedStep(ctx context.Context, email string) int64 {
+	const op = "get totp used step"
+
+	value, err := s.caches.auth.Get(ctx, totpUsedStepKeyPreffix+email)
+	if err != nil {
+		if !errors.Is(err, storage.ErrRecordNotFound) {
+			s.logger.WarnContext(ctx, op, sl.Error(err))
+		}
+		return 0
+	}
+
+	step, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return step
+}
+
+func (s *Service) setTOTPUsedStep(ctx context.Context, email string, step int64) {
+	const op = "set totp used step"
+
+	if err := s.caches.auth.Set(ctx, totpUsedStepKeyPreffix+email, strconv.FormatInt(step, 10), totpUsedStepTTL); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/model/user"
+	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
+	"github.com/Karzoug/goph_keeper/server/internal/service/task"
+)
+
+// HandleCleanupUnverifiedUsersTask reminds and deletes the users that never verified the email,
+// so the email can be registered again. The reminder with a new code is sent once when the first code
+// has expired, the user is deleted when the last sent code has expired more than the grace period ago.
+// The users disabled by the administrator and the users with a valid code (requested again) are skipped.
+func (s *Service) HandleCleanupUnverifiedUsersTask(ctx context.Context, t *asynq.Task) error {
+	const op = "service: handle cleanup unverified users"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	cfg := s.cfg.UnverifiedUsers
+	if !cfg.Remind && !cfg.Delete {
+		return nil
+	}
+
+	now := time.Now()
+	users, err := s.storage.ListUnverifiedUsers(ctx, now.Add(-s.cfg.Email.CodeLifetime))
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	var reminded, deleted int
+	for _, u := range users {
+		if u.IsDisabled {
+			continue
+		}
+		_, err := s.caches.mail.Get(ctx, u.Email)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, storage.ErrRecordNotFound) {
+			return e.Wrap(op, err)
+		}
+
+		switch {
+		case cfg.Remind && u.VerificationRemindedAt.IsZero():
+			// the failure of one user does not stop the cleanup of the others
+			if err := s.remindUnverifiedUser(ctx, u.Email, now); err != nil {
+				s.logger.WarnContext(ctx, op, slog.String("email", u.Email), sl.Error(err))
+				continue
+			}
+			reminded++
+		case cfg.Delete && lastCodeSentAt(u).Add(s.cfg.Email.CodeLifetime+cfg.GracePeriod).Before(now):
+			if err := s.storage.DeleteUnverifiedUser(ctx, u.Email); err != nil {
+				// the email has been verified just now
+				if !errors.Is(err, storage.ErrRecordNotFound) {
+					s.logger.WarnContext(ctx, op, slog.String("email", u.Email), sl.Error(err))
+				}
+				continue
+			}
+			s.deleteEmailCode(ctx, u.Email)
+			deleted++
+		}
+	}
+
+	s.logger.InfoContext(ctx, "unverified users cleaned up",
+		slog.Int("reminded", reminded), slog.Int("deleted", deleted))
+	return nil
+}
+
+// remindUnverifiedUser creates a new verification code and sends the reminder email with it.
+// The reminder is marked only while the email is not verified, so the user verified meanwhile is skipped.
+func (s *Service) remindUnverifiedUser(ctx context.Context, email string, now time.Time) error {
+	if err := s.storage.SetVerificationRemindedAt(ctx, email, now); err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	code, err := generateNumericCode(s.cfg.Email.CodeLength)
+	if err != nil {
+		return err
+	}
+	s.deleteEmailCode(ctx, email)
+	if err := s.caches.mail.Set(ctx, email, code, s.cfg.Email.CodeLifetime); err != nil {
+		return err
+	}
+
+	tsk, err := task.NewVerificationReminderEmailTask(ctx, email)
+	if err != nil {
+		return err
+	}
+	return s.rtaskClient.Enqueue(tsk, emailSendingTimeout)
+}
+
+// lastCodeSentAt returns the time the last verification code was sent to the user by the server itself:
+// the reminder time or the registration time.
+func lastCodeSentAt(u user.User) time.Time {
+	if u.VerificationRemindedAt.After(u.CreatedAt) {
+		return u.VerificationRemindedAt
+	}
+	return u.CreatedAt
+}
@@ -4,23 +4,46 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"io"
+	"sync"
 	"time"
 
 	"log/slog"
 
+	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
+	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/model/auth"
 	"github.com/Karzoug/goph_keeper/server/internal/model/auth/token"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/session"
 	"github.com/Karzoug/goph_keeper/server/internal/model/user"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 	"github.com/Karzoug/goph_keeper/server/internal/service/task"
 )
 
-const emailSendingTimeout = 3 * time.Second
+const (
+	emailSendingTimeout = 3 * time.Second
+	// emailResendKeyPreffix is the prefix of the mail cache entry that exists while the verification code
+	// can not be resent yet.
+	emailResendKeyPreffix = "resend:"
+	// maxWrappedVaultKeySize is the maximum size of the wrapped vault key,
+	// the server does not know its format, so only the size is checked.
+	maxWrappedVaultKeySize = 1024
+)
 
-// Register registers a new user.
-func (s *Service) Register(ctx context.Context, email string, hash []byte) error {
+// Register registers a new user. The recovery key data is optional,
+// but it can be set only together with the vault key.
+func (s *Service) Register(ctx context.Context,
+	email string,
+	hash, wrappedVaultKey []byte,
+	recoveryHash, recoveryWrappedVaultKey []byte,
+) error {
 	const op = "service: register user"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	u, err := user.New(email, hash)
 	if err != nil {
 		switch {
@@ -33,6 +56,24 @@ func (s *Service) Register(ctx context.Context, email string, hash []byte) error
 		}
 	}
 
+	if len(wrappedVaultKey) > maxWrappedVaultKeySize {
+		return ErrInvalidVaultKeyFormat
+	}
+	u.WrappedVaultKey = wrappedVaultKey
+
+	if len(recoveryHash) != 0 || len(recoveryWrappedVaultKey) != 0 {
+		if len(wrappedVaultKey) == 0 ||
+			len(recoveryWrappedVaultKey) == 0 ||
+			len(recoveryWrappedVaultKey) > maxWrappedVaultKeySize {
+			return ErrInvalidVaultKeyFormat
+		}
+		u.RecoveryKey, err = auth.NewKey(recoveryHash)
+		if err != nil {
+			return ErrInvalidHashFormat
+		}
+		u.RecoveryWrappedVaultKey = recoveryWrappedVaultKey
+	}
+
 	code, err := generateNumericCode(s.cfg.Email.CodeLength)
 	if err != nil {
 		return e.Wrap(op, err)
@@ -53,7 +94,7 @@ func (s *Service) Register(ctx context.Context, email string, hash []byte) error
 		}
 	}
 
-	tsk, err := task.NewWelcomeVerificationEmailTask(u.Email, code)
+	tsk, err := task.NewWelcomeVerificationEmailTask(ctx, u.Email, code)
 	if err != nil {
 		return e.Wrap(op, err)
 	}
@@ -62,70 +103,162 @@ func (s *Service) Register(ctx context.Context, email string, hash []byte) error
 		return e.Wrap(op, err)
 	}
 
-	s.logger.Debug("user successfully added to storage", slog.String("email", u.Email))
+	s.logger.DebugContext(ctx, "user successfully added to storage", slog.String("email", u.Email))
 
 	return nil
 }
 
-// Login logs in a user.
-func (s *Service) Login(ctx context.Context, email string, hash []byte) (string, error) {
+// Login logs in a user and starts a new session on the device.
+// The totp code is required only if the user has enabled the second factor.
+// It returns the session tokens and the wrapped vault key of the user.
+func (s *Service) Login(ctx context.Context, email string, hash []byte, totpCode string, device session.Device) (AuthTokens, []byte, error) {
 	const op = "service: login user"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	u, err := s.getUser(ctx, email, hash)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
 
+	if u.IsDisabled {
+		return AuthTokens{}, nil, ErrUserDisabled
+	}
 	if !u.IsEmailVerified {
-		return "", ErrUserEmailNotVerified
+		return AuthTokens{}, nil, ErrUserEmailNotVerified
 	}
 
-	tokenString, err := s.setUserToAuthCache(ctx, u)
+	if err := verifyCertificate(u, device); err != nil {
+		return AuthTokens{}, nil, e.Wrap(op, err)
+	}
+
+	if err := s.verifySecondFactor(ctx, &u, totpCode); err != nil {
+		return AuthTokens{}, nil, e.Wrap(op, err)
+	}
+
+	tokens, err := s.setUserToAuthCache(ctx, u.Email, device)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
 
-	return tokenString, nil
+	return tokens, u.WrappedVaultKey, nil
 }
 
-// LoginWithEmailCode logs in a user if user needs verification.
-func (s *Service) LoginWithEmailCode(ctx context.Context, email string, hash []byte, code string) (string, error) {
+// LoginWithEmailCode logs in a user if user needs verification and starts a new session on the device.
+// It returns the session tokens and the wrapped vault key of the user.
+func (s *Service) LoginWithEmailCode(ctx context.Context, email string, hash []byte, code string, device session.Device) (AuthTokens, []byte, error) {
 	const op = "service: login user with email code"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	u, err := s.getUser(ctx, email, hash)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		return AuthTokens{}, nil, e.Wrap(op, err)
+	}
+	if u.IsDisabled {
+		return AuthTokens{}, nil, ErrUserDisabled
+	}
+
+	if err := verifyCertificate(u, device); err != nil {
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
 
 	ccode, err := s.caches.mail.Get(ctx, email)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		// case: the code is expired or invalidated after too many wrong codes
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return AuthTokens{}, nil, ErrUserEmailNotVerified
+		}
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
 
 	if ccode != code {
-		return "", ErrUserEmailNotVerified
+		s.failEmailCode(ctx, email)
+		return AuthTokens{}, nil, ErrUserEmailNotVerified
+	}
+
+	err = s.storage.SetUserEmailVerified(ctx, u.Email)
+	if err != nil {
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
 
-	u.IsEmailVerified = true
+	s.deleteEmailCode(ctx, email)
 
-	err = s.storage.UpdateUser(ctx, u)
+	tokens, err := s.setUserToAuthCache(ctx, u.Email, device)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		return AuthTokens{}, nil, e.Wrap(op, err)
 	}
+	return tokens, u.WrappedVaultKey, nil
+}
 
-	_ = s.caches.mail.Delete(ctx, email)
+// ResendVerificationCode creates a new email verification code of the not verified user and sends it again,
+// the previous code is invalidated. It returns ErrVerificationCodeResent if the last code was sent recently.
+func (s *Service) ResendVerificationCode(ctx context.Context, email string, hash []byte) error {
+	const op = "service: resend verification code"
 
-	tokenString, err := s.setUserToAuthCache(ctx, u)
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUser(ctx, email, hash)
 	if err != nil {
-		return "", e.Wrap(op, err)
+		return e.Wrap(op, err)
+	}
+	if u.IsDisabled {
+		return ErrUserDisabled
+	}
+	if u.IsEmailVerified {
+		return ErrUserEmailAlreadyVerified
 	}
-	return tokenString, nil
+
+	_, err = s.caches.mail.Get(ctx, emailResendKeyPreffix+u.Email)
+	if err == nil {
+		return ErrVerificationCodeResent
+	}
+	if !errors.Is(err, storage.ErrRecordNotFound) {
+		return e.Wrap(op, err)
+	}
+
+	code, err := generateNumericCode(s.cfg.Email.CodeLength)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	// the task handler reads the code from the cache, so the code is set before the email is enqueued
+	s.deleteEmailCode(ctx, u.Email)
+	if err := s.caches.mail.Set(ctx, u.Email, code, s.cfg.Email.CodeLifetime); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	tsk, err := task.NewWelcomeVerificationEmailTask(ctx, u.Email, code)
+	if err != nil {
+		s.deleteEmailCode(ctx, u.Email)
+		return e.Wrap(op, err)
+	}
+	if err := s.rtaskClient.Enqueue(tsk, emailSendingTimeout); err != nil {
+		// the code is never sent, the cooldown is not set, so the resending can be retried at once
+		s.deleteEmailCode(ctx, u.Email)
+		return e.Wrap(op, err)
+	}
+
+	if err := s.caches.mail.Set(ctx, emailResendKeyPreffix+u.Email, "1", s.cfg.Email.ResendInterval); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	s.logger.DebugContext(ctx, "verification code resent", slog.String("email", u.Email))
+
+	return nil
 }
 
 // AuthUser verifies user's token and returns the email if success.
-func (s *Service) AuthUser(ctx context.Context, tokenString string) (string, error) {
+// The token is valid only with the client certificate of its session (or without certificate as well).
+func (s *Service) AuthUser(ctx context.Context, tokenString, certFingerprint string) (string, error) {
 	const op = "service: auth user"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	token, err := token.FromString(tokenString, s.cfg.Token.SecretKey)
 	if err != nil {
 		return "", e.Wrap(op, ErrInvalidTokenFormat)
@@ -134,15 +267,357 @@ func (s *Service) AuthUser(ctx context.Context, tokenString string) (string, err
 		return "", e.Wrap(op, ErrUserNeedAuthentication)
 	}
 
-	email, err := s.caches.auth.Get(ctx, token.ID())
+	data, err := s.getTokenData(ctx, token.ID())
+	if err != nil {
+		return "", e.Wrap(op, err)
+	}
+	if data.CertFingerprint != certFingerprint {
+		return "", e.Wrap(op, ErrUserNeedAuthentication)
+	}
+
+	return data.Email, nil
+}
+
+// RefreshToken verifies user's refresh token and returns a new pair of tokens of the same session.
+// The refresh token is rotated: it is revoked together with the token issued with it.
+// Like the token, it is valid only with the client certificate of its session.
+// The session of the disabled user is revoked and ErrUserDisabled is returned.
+func (s *Service) RefreshToken(ctx context.Context, refreshTokenString, certFingerprint string) (AuthTokens, error) {
+	const op = "service: refresh token"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	t, err := token.FromString(refreshTokenString, s.cfg.Token.SecretKey)
+	if err != nil {
+		return AuthTokens{}, e.Wrap(op, ErrInvalidTokenFormat)
+	}
+	if t.IsExpired() {
+		return AuthTokens{}, e.Wrap(op, ErrUserNeedAuthentication)
+	}
+
+	// the refresh token is single-use: it is taken from the cache atomically,
+	// so the concurrent request with the same token gets ErrUserNeedAuthentication
+	data, err := s.takeTokenData(ctx, refreshTokenKeyPreffix+t.ID())
+	if err != nil {
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+	if data.CertFingerprint != certFingerprint {
+		return AuthTokens{}, e.Wrap(op, ErrUserNeedAuthentication)
+	}
+	sess, err := s.getSession(ctx, data.SessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return AuthTokens{}, e.Wrap(op, ErrUserNeedAuthentication)
+		}
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+
+	// the user could be disabled after the sessions were revoked by the administrator
+	u, err := s.getUserByEmail(ctx, sess.Email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotExists) {
+			return AuthTokens{}, e.Wrap(op, ErrUserNeedAuthentication)
+		}
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+	if u.IsDisabled {
+		if err := s.RevokeSession(ctx, sess.Email, sess.ID); err != nil &&
+			!errors.Is(err, ErrSessionNotExists) {
+			return AuthTokens{}, e.Wrap(op, err)
+		}
+		return AuthTokens{}, e.Wrap(op, ErrUserDisabled)
+	}
+
+	if err := s.caches.auth.Delete(ctx, sess.TokenID); err != nil &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) {
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+
+	// the session revoked after it was got is not brought back
+	tokens, err := s.setSessionTokens(ctx, &sess, true)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return AuthTokens{}, e.Wrap(op, ErrUserNeedAuthentication)
+		}
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+	if err := s.addSessionID(ctx, sess.Email, sess.ID); err != nil {
+		return AuthTokens{}, e.Wrap(op, err)
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes the session of the user's token: the token and the refresh token of the session.
+func (s *Service) Logout(ctx context.Context, email, tokenString string) error {
+	const op = "service: logout user"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	t, err := token.FromString(tokenString, s.cfg.Token.SecretKey)
+	if err != nil {
+		return e.Wrap(op, ErrInvalidTokenFormat)
+	}
+
+	data, err := s.getTokenData(ctx, t.ID())
+	if err != nil {
+		// case: already revoked or expired token
+		if errors.Is(err, ErrUserNeedAuthentication) {
+			return nil
+		}
+		return e.Wrap(op, err)
+	}
+
+	if err := s.RevokeSession(ctx, email, data.SessionID); err != nil &&
+		!errors.Is(err, ErrSessionNotExists) {
+		return e.Wrap(op, err)
+	}
+
+	s.logger.DebugContext(ctx, "user logged out", slog.String("email", email))
+
+	return nil
+}
+
+// NextVaultItemFunc returns the next vault item and the reader of its value if it is a large binary item
+// (the value of other items is in the item itself). It returns io.EOF if there are no more items.
+type NextVaultItemFunc func() (vault.Item, io.Reader, error)
+
+// ChangePassword replaces the user auth key and the wrapped vault key, then revokes
+// all user sessions except the current one.
+// If the user already has the vault key and no items are passed, the vault stays untouched
+// (the vault key is just rewrapped by client). Otherwise all not deleted vault items
+// re-encrypted by client with the new vault key are replaced in the same storage transaction.
+// It returns the new server update time of the items or zero if the vault is not changed.
+func (s *Service) ChangePassword(ctx context.Context,
+	email, tokenString string,
+	hash, newHash, newWrappedVaultKey []byte,
+	next NextVaultItemFunc,
+) (int64, error) {
+	const op = "service: change password"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	u, err := s.getUser(ctx, email, hash)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	newKey, err := auth.NewKey(newHash)
+	if err != nil {
+		return 0, e.Wrap(op, ErrInvalidHashFormat)
+	}
+	hasVaultKey := len(u.WrappedVaultKey) != 0
+	// the vault key can not be lost: the items are encrypted with it
+	if len(newWrappedVaultKey) > maxWrappedVaultKeySize ||
+		(hasVaultKey && len(newWrappedVaultKey) == 0) {
+		return 0, e.Wrap(op, ErrInvalidVaultKeyFormat)
+	}
+
+	t := time.Now().UnixMicro()
+
+	var (
+		items []vault.Item
+		refs  = make(map[string]blob.Ref)
+	)
+	// the new values of large binary items are saved to blob storage under new keys,
+	// so the current values stay untouched until the vault is replaced
+	deleteNewBlobs := func() {
+		for _, ref := range refs {
+			s.deleteBlob(ctx, ref.Key)
+		}
+	}
+
+	for {
+		item, r, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			deleteNewBlobs()
+			return 0, e.Wrap(op, err)
+		}
+		// deleted items have no value to re-encrypt
+		if item.IsDeleted {
+			continue
+		}
+		item.ClientUpdatedAt = t
+
+		if item.Type == vault.BinaryLarge {
+			if r == nil {
+				deleteNewBlobs()
+				return 0, e.Wrap(op, ErrVaultItemWrongType)
+			}
+			item.Value = nil
+
+			key := blobKey(email, item.ID, t)
+			lr := newLimitHashReader(r, int64(s.cfg.StorageMaxSizeLargeItemValue))
+			if err := s.blobStorage.Put(ctx, key, lr); err != nil {
+				deleteNewBlobs()
+				if lr.exceeded {
+					return 0, e.Wrap(op, ErrVaultItemValueTooBig)
+				}
+				return 0, e.Wrap(op, err)
+			}
+			refs[item.ID] = blob.Ref{
+				Key:      key,
+				Checksum: lr.Checksum(),
+				Size:     lr.size,
+			}
+		} else if len(item.Value) > int(s.cfg.StorageMaxSizeItemValue) {
+			deleteNewBlobs()
+			return 0, e.Wrap(op, ErrVaultItemValueTooBig)
+		}
+
+		items = append(items, item)
+	}
+
+	u.AuthKey = newKey
+	u.WrappedVaultKey = newWrappedVaultKey
+
+	if hasVaultKey && len(items) == 0 {
+		if err := s.storage.SetUserAuthKey(ctx, email, u.AuthKey, u.WrappedVaultKey); err != nil {
+			return 0, e.Wrap(op, err)
+		}
+		t = 0
+	} else {
+		if err := s.storage.ChangePassword(ctx, u, items, refs); err != nil {
+			deleteNewBlobs()
+			if errors.Is(err, storage.ErrNoRecordsAffected) {
+				return 0, e.Wrap(op, ErrVaultItemVersionConflict)
+			}
+			return 0, e.Wrap(op, err)
+		}
+
+		// values encrypted with the old key are not needed anymore
+		for _, item := range items {
+			if item.Type == vault.BinaryLarge && item.ServerUpdatedAt != 0 {
+				s.deleteBlob(ctx, blobKey(email, item.ID, item.ServerUpdatedAt))
+			}
+		}
+
+		s.vaultUpdated(ctx, email, t)
+	}
+
+	// other devices keep the old key, they must log in with the new password
+	if err := s.revokeOtherSessions(ctx, email, tokenString); err != nil {
+		return 0, e.Wrap(op, err)
+	}
+
+	s.logger.DebugContext(ctx, "user password changed", slog.String("email", email))
+
+	return t, nil
+}
+
+// RecoverAccount resets the password of the user by the recovery hash.
+// If newHash is empty, the recovery hash is only verified. Otherwise the auth key and
+// the wrapped vault key are replaced and all user sessions are revoked.
+// It returns the vault key wrapped by the recovery key.
+func (s *Service) RecoverAccount(ctx context.Context,
+	email string,
+	recoveryHash, newHash, newWrappedVaultKey []byte,
+) ([]byte, error) {
+	const op = "service: recover account"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if len(recoveryHash) == 0 {
+		return nil, e.Wrap(op, ErrInvalidHashFormat)
+	}
+
+	u, err := s.storage.GetUser(ctx, email)
+	if err != nil && !errors.Is(err, storage.ErrRecordNotFound) {
+		return nil, e.Wrap(op, err)
+	}
+	// the hash is checked first and the same error is returned if the user does not exist
+	// or has no recovery key, so the recovery can not be used to find out registered emails
+	if !verifyRecoveryHash(u.RecoveryKey, recoveryHash) {
+		return nil, e.Wrap(op, ErrUserInvalidHash)
+	}
+	if u.IsDisabled {
+		return nil, e.Wrap(op, ErrUserDisabled)
+	}
+	if !u.IsEmailVerified {
+		return nil, e.Wrap(op, ErrUserEmailNotVerified)
+	}
+
+	// case: the client needs the vault key to rewrap it by the new password-derived key
+	if len(newHash) == 0 {
+		return u.RecoveryWrappedVaultKey, nil
+	}
+
+	newKey, err := auth.NewKey(newHash)
+	if err != nil {
+		return nil, e.Wrap(op, ErrInvalidHashFormat)
+	}
+	if len(newWrappedVaultKey) == 0 || len(newWrappedVaultKey) > maxWrappedVaultKeySize {
+		return nil, e.Wrap(op, ErrInvalidVaultKeyFormat)
+	}
+
+	if err := s.storage.SetUserAuthKey(ctx, email, newKey, newWrappedVaultKey); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// the sessions are started with the lost password
+	if err := s.revokeOtherSessions(ctx, email, ""); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	s.logger.DebugContext(ctx, "user account recovered", slog.String("email", email))
+
+	return u.RecoveryWrappedVaultKey, nil
+}
+
+// DeleteAccount deletes the user with all the vault items after the auth hash is verified,
+// then revokes all user sessions and sends the deletion confirmation email.
+func (s *Service) DeleteAccount(ctx context.Context, email string, hash []byte) error {
+	const op = "service: delete account"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if _, err := s.getUser(ctx, email, hash); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	keys, err := s.storage.DeleteUser(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrRecordNotFound) {
-			return "", e.Wrap(op, ErrUserNeedAuthentication)
+			return e.Wrap(op, ErrUserNotExists)
 		}
-		return "", e.Wrap(op, err)
+		return e.Wrap(op, err)
+	}
+	for _, key := range keys {
+		s.deleteBlob(ctx, key)
 	}
 
-	return email, nil
+	// the user is deleted already, so the rest is cleanup of the user traces:
+	// it does not fail the deletion
+	if err := s.caches.mail.Delete(ctx, email); err != nil &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+	if err := s.caches.lastUpdate.Delete(ctx, email); err != nil &&
+		!errors.Is(err, storage.ErrNoRecordsAffected) {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+	// no current session: all sessions are revoked
+	if err := s.revokeOtherSessions(ctx, email, ""); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+
+	tsk, err := task.NewAccountDeletedEmailTask(ctx, email)
+	if err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	} else if err := s.rtaskClient.Enqueue(tsk, emailSendingTimeout); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+
+	s.logger.DebugContext(ctx, "user account deleted", slog.String("email", email))
+
+	return nil
 }
 
 // getUser returns user by email and auth hash.
@@ -174,17 +649,21 @@ func (s *Service) getUser(ctx context.Context, email string, authHash []byte) (u
 	return u, nil
 }
 
-// setUserToAuthCache adds user email to auth cache and returns token.
-func (s *Service) setUserToAuthCache(ctx context.Context, u user.User) (string, error) {
-	eTime := time.Now().Add(s.cfg.Token.TokenLifetime)
-
-	t := token.New(eTime, s.cfg.Token.SecretKey)
-	err := s.caches.auth.Set(ctx, t.ID(), u.Email, time.Until(eTime))
-	if err != nil {
-		return "", err
+// dummyRecoveryKey is verified instead of the missing recovery key
+// to spend the same time as for the existing one.
+var dummyRecoveryKey = sync.OnceValue(func() auth.Key {
+	key, _ := auth.NewKey([]byte("dummy recovery hash"))
+	return key
+})
+
+// verifyRecoveryHash returns true if the hash matches the recovery key,
+// it returns false for the empty key after the same work as for the existing one.
+func verifyRecoveryHash(key auth.Key, hash []byte) bool {
+	if len(key) == 0 {
+		dummyRecoveryKey().Verify(hash)
+		return false
 	}
-
-	return t.String(), nil
+	return key.Verify(hash)
 }
 
 func generateNumericCode(n int) (string, error) {
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"strconv"
 	"time"
 
 	"github.com/Karzoug/goph_keeper/common/model/vault"
 	"github.com/Karzoug/goph_keeper/pkg/e"
 	"github.com/Karzoug/goph_keeper/pkg/logger/slog/sl"
+	"github.com/Karzoug/goph_keeper/server/internal/model/blob"
+	"github.com/Karzoug/goph_keeper/server/internal/model/page"
 	"github.com/Karzoug/goph_keeper/server/internal/repository/storage"
 )
 
@@ -17,36 +21,326 @@ const lastUpdateCacheTTL = 30 * time.Minute
 func (s *Service) SetVaultItem(ctx context.Context, email string, item vault.Item) (int64, error) {
 	const op = "service: set vault item"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	if len(item.Value) > int(s.cfg.StorageMaxSizeItemValue) {
 		return 0, e.Wrap(op, ErrVaultItemValueTooBig)
 	}
+	// large binary items must be uploaded by stream, only deletion is allowed here
+	if item.Type == vault.BinaryLarge && !item.IsDeleted {
+		return 0, e.Wrap(op, ErrVaultItemValueTooBig)
+	}
+
+	quota, err := s.getUserQuota(ctx, email)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
 
 	item.ClientUpdatedAt = time.Now().UnixMicro()
 
-	err := s.storage.SetVaultItem(ctx, email, item)
+	if err := s.storage.SetVaultItem(ctx, email, item, quota); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNoRecordsAffected):
+			return 0, e.Wrap(op, ErrVaultItemVersionConflict)
+		case errors.Is(err, storage.ErrQuotaExceeded):
+			return 0, e.Wrap(op, ErrQuotaExceeded)
+		}
+		return 0, e.Wrap(op, err)
+	}
+
+	// value of the deleted large binary item is not needed anymore
+	if item.Type == vault.BinaryLarge && item.ServerUpdatedAt != 0 {
+		s.deleteBlob(ctx, blobKey(email, item.ID, item.ServerUpdatedAt))
+	}
+
+	s.trimRevisions(ctx, email, item.ID)
+	s.vaultUpdated(ctx, email, item.ClientUpdatedAt)
+
+	return item.ClientUpdatedAt, nil
+}
+
+// SetVaultItemResult is a result of setting one of the vault items by SetVaultItems.
+type SetVaultItemResult struct {
+	// ServerUpdatedAt is the new server update time of the item, it is set only if Err is nil.
+	ServerUpdatedAt int64
+	// Err is nil if the item is set, ErrVaultItemVersionConflict, ErrVaultItemValueTooBig
+	// or ErrQuotaExceeded otherwise.
+	Err error
+}
+
+// SetVaultItems sets vault items in one transaction and returns results in the same order as items.
+// Items with conflict version or too big value are skipped, the rest are set.
+// If the items do not fit the user quota, only deleted items are set.
+func (s *Service) SetVaultItems(ctx context.Context, email string, items []vault.Item) ([]SetVaultItemResult, error) {
+	const op = "service: set vault items"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	results := make([]SetVaultItemResult, len(items))
+	validItems := make([]vault.Item, 0, len(items))
+	validIdx := make([]int, 0, len(items))
+
+	t := time.Now().UnixMicro()
+	for i, item := range items {
+		// large binary items must be uploaded by stream, only deletion is allowed here
+		if len(item.Value) > int(s.cfg.StorageMaxSizeItemValue) ||
+			(item.Type == vault.BinaryLarge && !item.IsDeleted) {
+			results[i].Err = ErrVaultItemValueTooBig
+			continue
+		}
+		item.ClientUpdatedAt = t
+		validItems = append(validItems, item)
+		validIdx = append(validIdx, i)
+	}
+
+	if len(validItems) == 0 {
+		return results, nil
+	}
+
+	quota, err := s.getUserQuota(ctx, email)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	errs, err := s.storage.SetVaultItems(ctx, email, validItems, quota)
+	if errors.Is(err, storage.ErrQuotaExceeded) {
+		// deletion frees the space, so it is allowed anyway
+		deletedItems := validItems[:0]
+		deletedIdx := validIdx[:0]
+		for i, item := range validItems {
+			if !item.IsDeleted {
+				results[validIdx[i]].Err = ErrQuotaExceeded
+				continue
+			}
+			deletedItems = append(deletedItems, item)
+			deletedIdx = append(deletedIdx, validIdx[i])
+		}
+		validItems, validIdx = deletedItems, deletedIdx
+
+		if len(validItems) == 0 {
+			return results, nil
+		}
+		errs, err = s.storage.SetVaultItems(ctx, email, validItems, quota)
+	}
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	accepted := make([]string, 0, len(validItems))
+	for i, err := range errs {
+		idx := validIdx[i]
+		switch {
+		case err == nil:
+			results[idx].ServerUpdatedAt = t
+			accepted = append(accepted, validItems[i].ID)
+			// value of the deleted large binary item is not needed anymore
+			if item := validItems[i]; item.Type == vault.BinaryLarge && item.ServerUpdatedAt != 0 {
+				s.deleteBlob(ctx, blobKey(email, item.ID, item.ServerUpdatedAt))
+			}
+		case errors.Is(err, storage.ErrNoRecordsAffected):
+			results[idx].Err = ErrVaultItemVersionConflict
+		default:
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	if len(accepted) != 0 {
+		s.trimRevisions(ctx, email, accepted...)
+		s.vaultUpdated(ctx, email, t)
+	}
+
+	return results, nil
+}
+
+// SetLargeVaultItem sets large binary vault item with the value read from r.
+// The value is saved to blob storage, the vault storage keeps only a reference to it.
+func (s *Service) SetLargeVaultItem(ctx context.Context, email string, item vault.Item, r io.Reader) (int64, error) {
+	const op = "service: set large vault item"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if item.Type != vault.BinaryLarge {
+		return 0, e.Wrap(op, ErrVaultItemWrongType)
+	}
+	item.ClientUpdatedAt = time.Now().UnixMicro()
+	item.Value = nil
+
+	quota, err := s.getUserQuota(ctx, email)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	// the value size is unknown until it is read, so it is limited by the quota left
+	quotaLeft, err := s.remainingQuotaBytes(ctx, email, quota, item)
+	if err != nil {
+		return 0, e.Wrap(op, err)
+	}
+	if quotaLeft < 0 {
+		return 0, e.Wrap(op, ErrQuotaExceeded)
+	}
+	limit := int64(s.cfg.StorageMaxSizeLargeItemValue)
+	limitedByQuota := quotaLeft < limit
+	if limitedByQuota {
+		limit = quotaLeft
+	}
+
+	// every version of the value has its own key, so the current version
+	// stays untouched until the item is updated in the vault storage
+	key := blobKey(email, item.ID, item.ClientUpdatedAt)
+	lr := newLimitHashReader(r, limit)
+	if err := s.blobStorage.Put(ctx, key, lr); err != nil {
+		if lr.exceeded {
+			if limitedByQuota {
+				return 0, e.Wrap(op, ErrQuotaExceeded)
+			}
+			return 0, e.Wrap(op, ErrVaultItemValueTooBig)
+		}
+		return 0, e.Wrap(op, err)
+	}
+
+	// the quota is checked again with the value size: the vault could be changed while the value was read
+	err = s.storage.SetLargeVaultItem(ctx, email, item, blob.Ref{
+		Key:      key,
+		Checksum: lr.Checksum(),
+		Size:     lr.size,
+	}, quota)
 	if err != nil {
-		if errors.Is(err, storage.ErrNoRecordsAffected) {
+		s.deleteBlob(ctx, key)
+		switch {
+		case errors.Is(err, storage.ErrNoRecordsAffected):
 			return 0, e.Wrap(op, ErrVaultItemVersionConflict)
+		case errors.Is(err, storage.ErrQuotaExceeded):
+			return 0, e.Wrap(op, ErrQuotaExceeded)
 		}
 		return 0, e.Wrap(op, err)
 	}
 
-	if err := s.caches.lastUpdate.Set(ctx, email, strconv.FormatInt(item.ClientUpdatedAt, 10), lastUpdateCacheTTL); err != nil {
-		s.logger.Warn(op, e.Wrap(op, err))
+	if item.ServerUpdatedAt != 0 {
+		s.deleteBlob(ctx, blobKey(email, item.ID, item.ServerUpdatedAt))
 	}
 
+	s.vaultUpdated(ctx, email, item.ClientUpdatedAt)
+
 	return item.ClientUpdatedAt, nil
 }
 
-func (s *Service) ListVaultItems(ctx context.Context, email string, since int64) ([]vault.Item, error) {
+// GetLargeVaultItem returns large binary vault item (without value) and the reader of its value.
+// The reader returns ErrVaultItemValueCorrupted at the end if the value checksum does not match.
+// The caller must close the reader.
+func (s *Service) GetLargeVaultItem(ctx context.Context, email, id string) (vault.Item, io.ReadCloser, error) {
+	const op = "service: get large vault item"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	item, ref, err := s.storage.GetLargeVaultItem(ctx, email, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return vault.Item{}, nil, e.Wrap(op, ErrVaultItemNotExists)
+		}
+		return vault.Item{}, nil, e.Wrap(op, err)
+	}
+	if item.Type != vault.BinaryLarge {
+		return vault.Item{}, nil, e.Wrap(op, ErrVaultItemWrongType)
+	}
+	// deleted item has no value
+	if item.IsDeleted || len(ref.Key) == 0 {
+		return item, io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	rc, err := s.blobStorage.Get(ctx, ref.Key)
+	if err != nil {
+		return vault.Item{}, nil, e.Wrap(op, err)
+	}
+
+	return item, newChecksumReadCloser(rc, ref.Checksum), nil
+}
+
+// WatchVaultItems returns the server update times of the user vault changes.
+// Returned channel is closed when ctx is done.
+func (s *Service) WatchVaultItems(ctx context.Context, email string) (<-chan int64, error) {
+	const op = "service: watch vault items"
+
+	// the span lasts as long as the watch, so it is ended by the watching goroutine
+	ctx, span := tracer.Start(ctx, op)
+
+	msgs, err := s.pubsub.Subscribe(ctx, vaultUpdatesChannel(email))
+	if err != nil {
+		span.End()
+		return nil, e.Wrap(op, err)
+	}
+
+	ch := make(chan int64)
+	go func() {
+		defer span.End()
+		defer close(ch)
+
+		for msg := range msgs {
+			t, err := strconv.ParseInt(msg, 10, 64)
+			if err != nil {
+				s.logger.WarnContext(ctx, op, sl.Error(err))
+				continue
+			}
+			select {
+			case ch <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// vaultUpdated is called after the user vault is changed:
+// it updates the cache and notifies watchers.
+func (s *Service) vaultUpdated(ctx context.Context, email string, t int64) {
+	const op = "vault updated"
+
+	s.setLastUpdate(ctx, email, t)
+
+	if err := s.pubsub.Publish(ctx, vaultUpdatesChannel(email), strconv.FormatInt(t, 10)); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
+
+// vaultUpdatesChannel returns the name of the channel with the user vault changes.
+func vaultUpdatesChannel(email string) string {
+	return "vault_updates:" + email
+}
+
+// setLastUpdate sets the last update time of the user vault in cache.
+func (s *Service) setLastUpdate(ctx context.Context, email string, t int64) {
+	const op = "set last update"
+
+	if err := s.caches.lastUpdate.Set(ctx, email, strconv.FormatInt(t, 10), lastUpdateCacheTTL); err != nil {
+		s.logger.WarnContext(ctx, op, sl.Error(err))
+	}
+}
+
+// ListVaultItems returns items changed at or after since time in chronological order.
+// If pageSize is 0, all items are returned, otherwise the page starts after pageToken
+// and the token of the next page is returned if there are more items.
+// ErrFullResyncRequired is returned if the deleted items changed after since are purged already.
+func (s *Service) ListVaultItems(ctx context.Context, email string, since int64, pageSize int, pageToken string) ([]vault.Item, string, error) {
 	const op = "service: list vault items"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	cursor, err := page.ParseToken(pageToken)
+	if err != nil {
+		return nil, "", e.Wrap(op, ErrInvalidPageToken)
+	}
+
 	// first try to find in cache if there is since date
 	if since != 0 {
 		str, err := s.caches.lastUpdate.Get(ctx, email)
 		if err != nil {
 			if !errors.Is(err, storage.ErrRecordNotFound) {
-				s.logger.Error(op, sl.Error(err))
+				s.logger.ErrorContext(ctx, op, sl.Error(err))
 			}
 		} else {
 			t, err := strconv.ParseInt(str, 10, 64)
@@ -54,29 +348,62 @@ func (s *Service) ListVaultItems(ctx context.Context, email string, since int64)
 				// if time in cache (on server) is older or equal than given since date
 				// then return empty slice of items
 				if since >= t {
-					return make([]vault.Item, 0), nil
+					return make([]vault.Item, 0), "", nil
 				}
 			}
 		}
 	}
 
-	items, err := s.storage.ListVaultItems(ctx, email, since)
+	// the vault is not changed since then, so nothing is missed, otherwise the deleted items could be purged
+	resync, err := s.isFullResyncRequired(ctx, email, since)
 	if err != nil {
-		return nil, e.Wrap(op, err)
+		return nil, "", e.Wrap(op, err)
+	}
+	if resync {
+		return nil, "", e.Wrap(op, ErrFullResyncRequired)
 	}
 
-	var oTime int64
-	for i := 0; i < len(items); i++ {
-		if items[i].ServerUpdatedAt > oTime {
-			oTime = items[i].ServerUpdatedAt
-		}
+	var limit int
+	if pageSize > 0 {
+		pageSize = min(pageSize, s.cfg.ListVaultItemsMaxPageSize)
+		// one more item to find out if there is the next page
+		limit = pageSize + 1
 	}
 
-	if oTime == 0 {
-		return items, nil
+	items, err := s.storage.ListVaultItems(ctx, email, since, cursor, limit)
+	if err != nil {
+		return nil, "", e.Wrap(op, err)
 	}
-	if err := s.caches.lastUpdate.Set(ctx, email, strconv.FormatInt(oTime, 10), lastUpdateCacheTTL); err != nil {
-		s.logger.Warn(op, e.Wrap(op, err))
+
+	var nextPageToken string
+	if pageSize > 0 {
+		hasMore := len(items) > pageSize
+		if hasMore {
+			items = items[:pageSize]
+		}
+		// the page must fit in the message, but at least one item is returned anyway
+		var size int
+		for i := 0; i < len(items); i++ {
+			size += len(items[i].Value)
+			if i > 0 && size > s.cfg.ListVaultItemsMaxPageBytes {
+				items = items[:i]
+				hasMore = true
+				break
+			}
+		}
+		if hasMore {
+			last := items[len(items)-1]
+			nextPageToken = page.Cursor{
+				UpdatedAt: last.ServerUpdatedAt,
+				ID:        last.ID,
+			}.Token()
+		}
 	}
-	return items, nil
+
+	// items are in chronological order, so the last item of the last page is the last update
+	if len(nextPageToken) == 0 && len(items) != 0 {
+		s.setLastUpdate(ctx, email, items[len(items)-1].ServerUpdatedAt)
+	}
+
+	return items, nextPageToken, nil
 }
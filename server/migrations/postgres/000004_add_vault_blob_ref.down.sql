@@ -0,0 +1,4 @@
+ALTER TABLE vaults
+DROP COLUMN blob_checksum;
+ALTER TABLE vaults
+DROP COLUMN blob_key;
\ No newline at end of file
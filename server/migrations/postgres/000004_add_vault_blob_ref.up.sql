@@ -0,0 +1,4 @@
+ALTER TABLE vaults
+ADD blob_key TEXT;
+ALTER TABLE vaults
+ADD blob_checksum TEXT;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS vaults_email_updated_at_id_idx;
\ No newline at end of file
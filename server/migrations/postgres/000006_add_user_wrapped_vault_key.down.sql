@@ -0,0 +1,2 @@
+ALTER TABLE users
+DROP COLUMN wrapped_vault_key;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD wrapped_vault_key bytea;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+DROP COLUMN recovery_wrapped_vault_key,
+DROP COLUMN recovery_key;
\ No newline at end of file
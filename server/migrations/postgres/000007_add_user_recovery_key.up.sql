@@ -0,0 +1,3 @@
+ALTER TABLE users
+ADD recovery_key bytea,
+ADD recovery_wrapped_vault_key bytea;
\ No newline at end of file
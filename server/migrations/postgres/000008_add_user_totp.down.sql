@@ -0,0 +1,4 @@
+ALTER TABLE users
+DROP COLUMN totp_recovery_codes,
+DROP COLUMN is_totp_enabled,
+DROP COLUMN totp_secret;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE users
+ADD totp_secret bytea,
+ADD is_totp_enabled BOOLEAN NOT NULL DEFAULT false,
+ADD totp_recovery_codes TEXT;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+DROP COLUMN cert_fingerprint;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD is_disabled BOOLEAN NOT NULL DEFAULT false;
\ No newline at end of file
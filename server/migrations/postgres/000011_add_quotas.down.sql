@@ -0,0 +1,5 @@
+ALTER TABLE vaults
+DROP COLUMN blob_size;
+ALTER TABLE users
+DROP COLUMN quota_max_items,
+DROP COLUMN quota_max_bytes;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE users
+ADD quota_max_bytes BIGINT,
+ADD quota_max_items BIGINT;
+ALTER TABLE vaults
+ADD blob_size BIGINT;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE vault_revisions;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS vaults_email_updated_at_id_idx ON vaults (email, updated_at, id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD wrapped_vault_key BLOB;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE users
+DROP COLUMN recovery_wrapped_vault_key;
+ALTER TABLE users
+DROP COLUMN recovery_key;
\ No newline at end of file
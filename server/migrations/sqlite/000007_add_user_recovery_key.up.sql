@@ -0,0 +1,4 @@
+ALTER TABLE users
+ADD recovery_key BLOB;
+ALTER TABLE users
+ADD recovery_wrapped_vault_key BLOB;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE users
+ADD totp_secret BLOB;
+ALTER TABLE users
+ADD is_totp_enabled INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users
+ADD totp_recovery_codes TEXT;
\ No newline at end of file
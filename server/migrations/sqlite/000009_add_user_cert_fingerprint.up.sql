@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD cert_fingerprint TEXT;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD is_disabled INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE vaults
+DROP COLUMN blob_size;
+ALTER TABLE users
+DROP COLUMN quota_max_items;
+ALTER TABLE users
+DROP COLUMN quota_max_bytes;
\ No newline at end of file
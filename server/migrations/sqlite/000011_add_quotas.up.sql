@@ -0,0 +1,6 @@
+ALTER TABLE users
+ADD quota_max_bytes INTEGER;
+ALTER TABLE users
+ADD quota_max_items INTEGER;
+ALTER TABLE vaults
+ADD blob_size INTEGER;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS vault_revisions (
+	id TEXT NOT NULL,
+	email TEXT NOT NULL REFERENCES users (email),
+	name TEXT NOT NULL,
+	type INTEGER,
+	value BLOB,
+	updated_at INTEGER NOT NULL,
+	PRIMARY KEY(email,id,updated_at));
\ No newline at end of file
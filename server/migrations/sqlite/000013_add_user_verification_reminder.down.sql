@@ -0,0 +1,2 @@
+ALTER TABLE users
+DROP COLUMN verification_reminded_at;
\ No newline at end of file
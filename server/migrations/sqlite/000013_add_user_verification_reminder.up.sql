@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD verification_reminded_at DATETIME;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+DROP COLUMN tombstones_purged_at;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD tombstones_purged_at INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -3,10 +3,18 @@ package main
 // Basic imports
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 default
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/pioz/faker"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/Karzoug/goph_keeper/common/grpc"
 )
@@ -19,6 +27,35 @@ func (suite *AuthSuite) TestAuth() {
 	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
 	defer cancel()
 
+	suite.Run("health check", func() {
+		resp, err := healthpb.NewHealthClient(suite.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		suite.Require().NoError(err, "gRPC health check error", err)
+		suite.Assert().Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	suite.Run("request id", func() {
+		// the server generates the request ID
+		var header, trailer metadata.MD
+		_, err := suite.grpcClient.Register(ctx, &pb.RegisterRequest{
+			Email: "",
+			Hash:  suite.authHash,
+		}, grpc.Header(&header), grpc.Trailer(&trailer))
+		suite.Assert().ErrorIs(err, pb.ErrInvalidEmailFormat)
+		suite.Require().Len(trailer.Get("x-request-id"), 1, "Request ID not in error response")
+		suite.Assert().NotEmpty(trailer.Get("x-request-id")[0])
+
+		// the server uses the request ID of the client
+		requestID := fmt.Sprintf("test-%d", time.Now().UnixNano())
+		_, err = suite.grpcClient.Register(
+			metadata.AppendToOutgoingContext(ctx, "x-request-id", requestID),
+			&pb.RegisterRequest{
+				Email: "",
+				Hash:  suite.authHash,
+			}, grpc.Header(&header), grpc.Trailer(&trailer))
+		suite.Assert().ErrorIs(err, pb.ErrInvalidEmailFormat)
+		suite.Assert().Equal([]string{requestID}, trailer.Get("x-request-id"))
+	})
+
 	suite.Run("register: bad arguments", func() {
 		authHash := make([]byte, 32)
 		_, err := rand.Read(authHash)
@@ -97,9 +134,349 @@ func (suite *AuthSuite) TestAuth() {
 		suite.Assert().ErrorIs(err, pb.ErrInvalidHashFormat)
 	})
 
+	suite.Run("login: too many attempts", func() {
+		email := faker.SafeEmail()
+		_, err := suite.grpcClient.Register(ctx, &pb.RegisterRequest{
+			Email: email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err)
+
+		badHash := make([]byte, 32)
+		_, err = rand.Read(badHash)
+		suite.Require().NoError(err)
+
+		// not registered emails are not counted
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: faker.SafeEmail(),
+			Hash:  suite.authHash,
+		})
+		suite.Require().ErrorIs(err, pb.ErrUserNotExists)
+
+		// default limit of failed attempts per email
+		for i := 0; i < 5; i++ {
+			_, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+				Email: email,
+				Hash:  badHash,
+			})
+			suite.Require().ErrorIs(err, pb.ErrUserInvalidHash)
+		}
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrTooManyAttempts)
+
+		// other emails from the same address are not locked out yet
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: faker.FreeEmail(),
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNotExists)
+	})
+
+	suite.Run("resend verification code", func() {
+		_, err := suite.grpcClient.ResendVerificationCode(ctx, &pb.ResendVerificationCodeRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserEmailAlreadyVerified)
+
+		email := faker.SafeEmail()
+		_, err = suite.grpcClient.Register(ctx, &pb.RegisterRequest{
+			Email: email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC user register error", err)
+
+		_, err = suite.grpcClient.ResendVerificationCode(ctx, &pb.ResendVerificationCodeRequest{
+			Email: email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().NoError(err, "gRPC resend verification code error", err)
+
+		// the code was sent recently
+		_, err = suite.grpcClient.ResendVerificationCode(ctx, &pb.ResendVerificationCodeRequest{
+			Email: email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrVerificationCodeResent)
+	})
+
 	suite.Run("get items: bad auth", func() {
 		_, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
 		suite.Assert().ErrorIs(err, pb.ErrEmptyAuthData)
+
+		// streams are authenticated by the interceptor too
+		stream, err := suite.grpcClient.WatchVaultItems(ctx, &pb.WatchVaultItemsRequest{})
+		suite.Require().NoError(err, "Watch vault items error")
+		_, err = stream.Recv()
+		suite.Assert().ErrorIs(err, pb.ErrEmptyAuthData)
+
+		stream, err = suite.grpcClient.WatchVaultItems(
+			metadata.AppendToOutgoingContext(ctx, "token", "bad token"),
+			&pb.WatchVaultItemsRequest{})
+		suite.Require().NoError(err, "Watch vault items error")
+		_, err = stream.Recv()
+		suite.Assert().ErrorIs(err, pb.ErrInvalidTokenFormat)
+	})
+
+	suite.Run("refresh token", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+		suite.Require().NotEqual(0, len(resp.RefreshToken), "Refresh token not found in response")
+		suite.Assert().Less(int64(0), resp.TokenLifetime, "Token lifetime not found in response")
+
+		refreshResp, err := suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Require().NoError(err, "gRPC refresh token error", err)
+		suite.Assert().NotEqual(resp.Token, refreshResp.Token)
+		suite.Assert().NotEqual(resp.RefreshToken, refreshResp.RefreshToken)
+
+		// new token works
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, refreshResp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().NoError(err, "List items with refreshed token error", err)
+
+		// old token and old refresh token are revoked
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, resp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+
+		// refresh token can not be used to access the vault
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, refreshResp.RefreshToken), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+
+		// bad refresh token
+		_, err = suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: "bad token",
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidTokenFormat)
+	})
+
+	suite.Run("logout", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		ctx := newContextWithAuthData(ctx, resp.Token)
+		_, err = suite.grpcClient.Logout(ctx, &pb.LogoutRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Require().NoError(err, "gRPC logout error", err)
+
+		_, err = suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+	})
+
+	suite.Run("sessions", func() {
+		md := metadata.New(map[string]string{"device-name": "test device", "client-version": "v0.0.1"})
+		resp, err := suite.grpcClient.Login(metadata.NewOutgoingContext(ctx, md), &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+		otherResp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		ctx := newContextWithAuthData(ctx, resp.Token)
+		listResp, err := suite.grpcClient.ListSessions(ctx, &pb.ListSessionsRequest{})
+		suite.Require().NoError(err, "gRPC list sessions error", err)
+
+		var current, other *pb.Session
+		for _, sess := range listResp.Sessions {
+			if sess.Current {
+				current = sess
+			} else if other == nil || sess.CreatedAt > other.CreatedAt {
+				other = sess
+			}
+		}
+		suite.Require().NotNil(current, "Current session not found")
+		suite.Require().NotNil(other, "Other session not found")
+		suite.Assert().Equal("test device", current.DeviceName)
+		suite.Assert().Equal("v0.0.1", current.ClientVersion)
+		suite.Assert().NotEmpty(current.Ip)
+
+		// revoke other session
+		_, err = suite.grpcClient.RevokeSession(ctx, &pb.RevokeSessionRequest{Id: other.Id})
+		suite.Require().NoError(err, "gRPC revoke session error", err)
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, otherResp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: otherResp.RefreshToken,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+
+		_, err = suite.grpcClient.RevokeSession(ctx, &pb.RevokeSessionRequest{Id: other.Id})
+		suite.Assert().ErrorIs(err, pb.ErrSessionNotExists)
+
+		listResp, err = suite.grpcClient.ListSessions(ctx, &pb.ListSessionsRequest{})
+		suite.Require().NoError(err, "gRPC list sessions error", err)
+		for _, sess := range listResp.Sessions {
+			suite.Assert().NotEqual(other.Id, sess.Id, "Revoked session found")
+		}
+	})
+
+	suite.Run("recover account", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		badHash := make([]byte, 32)
+		_, err = rand.Read(badHash)
+		suite.Require().NoError(err)
+		_, err = suite.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+			Email:        suite.email,
+			RecoveryHash: badHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+
+		// the not registered email is not distinguished from the wrong recovery key
+		_, err = suite.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+			Email:        "not-registered-" + suite.email,
+			RecoveryHash: suite.recoveryHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+
+		// verification only
+		recoverResp, err := suite.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+			Email:        suite.email,
+			RecoveryHash: suite.recoveryHash,
+		})
+		suite.Require().NoError(err, "gRPC recover account error", err)
+		suite.Assert().Equal(suite.recoveryWrappedVaultKey, recoverResp.RecoveryWrappedVaultKey)
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, resp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().NoError(err, "Session must not be revoked on verification", err)
+
+		newHash := make([]byte, 32)
+		_, err = rand.Read(newHash)
+		suite.Require().NoError(err)
+		newWrappedVaultKey := make([]byte, 72)
+		_, err = rand.Read(newWrappedVaultKey)
+		suite.Require().NoError(err)
+
+		_, err = suite.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+			Email:        suite.email,
+			RecoveryHash: suite.recoveryHash,
+			NewHash:      newHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidVaultKeyFormat)
+
+		_, err = suite.grpcClient.RecoverAccount(ctx, &pb.RecoverAccountRequest{
+			Email:              suite.email,
+			RecoveryHash:       suite.recoveryHash,
+			NewHash:            newHash,
+			NewWrappedVaultKey: newWrappedVaultKey,
+		})
+		suite.Require().NoError(err, "gRPC recover account error", err)
+
+		// all sessions are revoked
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, resp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+		loginResp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  newHash,
+		})
+		suite.Require().NoError(err, "gRPC login with new hash error", err)
+		suite.Assert().Equal(newWrappedVaultKey, loginResp.WrappedVaultKey)
+
+		suite.authHash = newHash
+		suite.wrappedVaultKey = newWrappedVaultKey
+	})
+
+	var totpRecoveryCodes []string
+	suite.Run("two-factor authentication", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		ctx := newContextWithAuthData(ctx, resp.Token)
+
+		_, err = suite.grpcClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{Code: "123456"})
+		suite.Assert().ErrorIs(err, pb.ErrTOTPNotEnrolled)
+
+		enrollResp, err := suite.grpcClient.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+		suite.Require().NoError(err, "gRPC enroll totp error", err)
+		suite.Assert().Contains(enrollResp.Uri, enrollResp.Secret)
+		secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(enrollResp.Secret)
+		suite.Require().NoError(err, "Secret is not base32 encoded")
+
+		now := time.Now()
+		_, err = suite.grpcClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{
+			Code: totpCode(secret, now.Add(-time.Hour)),
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidTOTPCode)
+
+		confirmResp, err := suite.grpcClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{
+			Code: totpCode(secret, now),
+		})
+		suite.Require().NoError(err, "gRPC confirm totp error", err)
+		suite.Require().NotEmpty(confirmResp.RecoveryCodes, "Recovery codes not found in response")
+		totpRecoveryCodes = confirmResp.RecoveryCodes
+
+		_, err = suite.grpcClient.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrTOTPAlreadyEnabled)
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrTOTPRequired)
+
+		// code used on the confirmation can not be replayed
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpCode(secret, now),
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidTOTPCode)
+
+		// the next time step code is accepted because of the allowed clock drift
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpCode(secret, now.Add(30*time.Second)),
+		})
+		suite.Assert().NoError(err, "gRPC user login with totp code error", err)
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpRecoveryCodes[0],
+		})
+		suite.Assert().NoError(err, "gRPC user login with recovery code error", err)
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpRecoveryCodes[0],
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidTOTPCode)
 	})
 
 	suite.Run("restart server with token lifetime equals 2 sec", func() {
@@ -117,8 +494,9 @@ func (suite *AuthSuite) TestAuth() {
 	suite.Run("token lifetime work check", func() {
 		// login with the verification code, got token
 		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
-			Email: suite.email,
-			Hash:  suite.authHash,
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpRecoveryCodes[1],
 		})
 		suite.Require().NoError(err, "gRPC existed user login error", err)
 		suite.Assert().NotEqual(0, len(resp.Token), "Token not found in response")
@@ -131,4 +509,78 @@ func (suite *AuthSuite) TestAuth() {
 		_, err = suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
 		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
 	})
+	suite.Run("certificate: not required", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpRecoveryCodes[2],
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		ctx := newContextWithAuthData(ctx, resp.Token)
+
+		// the server runs without mutual TLS, so there is no certificate to bind
+		_, err = suite.grpcClient.BindCertificate(ctx, &pb.BindCertificateRequest{
+			Hash: suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrClientCertificateRequired)
+
+		badHash := make([]byte, 32)
+		_, err = rand.Read(badHash)
+		suite.Require().NoError(err)
+		_, err = suite.grpcClient.UnbindCertificate(ctx, &pb.UnbindCertificateRequest{
+			Hash: badHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+	})
+	suite.Run("delete account", func() {
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:    suite.email,
+			Hash:     suite.authHash,
+			TotpCode: totpRecoveryCodes[3],
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		ctx := newContextWithAuthData(ctx, resp.Token)
+
+		badHash := make([]byte, 32)
+		_, err = rand.Read(badHash)
+		suite.Require().NoError(err)
+		_, err = suite.grpcClient.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+			Hash: badHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+
+		_, err = suite.grpcClient.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+			Hash: suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC delete account error", err)
+
+		_, err = suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNotExists)
+	})
+}
+
+// totpCode returns the RFC 6238 code of the time step that t belongs to, as authenticator apps do.
+func totpCode(secret []byte, t time.Time) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(t.Unix()/30))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", code%1_000_000)
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+)
+
+type CertificateSuite struct {
+	commonTestSuite
+
+	dir string
+	// certA is used by the suite client, certB is another certificate signed by the same CA
+	certA, certB tls.Certificate
+}
+
+// SetupSuite runs the server with mutual TLS: the client certificates are signed by the generated CA.
+func (suite *CertificateSuite) SetupSuite() {
+	dir, err := os.MkdirTemp("", "goph_keeper_certs")
+	suite.Require().NoError(err)
+	suite.dir = dir
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	suite.Require().NoError(err)
+	caCert, err := x509.ParseCertificate(caDER)
+	suite.Require().NoError(err)
+
+	newClientCert := func(serial int64) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		suite.Require().NoError(err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "test client"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		suite.Require().NoError(err)
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+	suite.certA = newClientCert(2)
+	suite.certB = newClientCert(3)
+
+	// the gateway presents the server certificate, so it is trusted too
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	if serverPEM, err := os.ReadFile(os.Getenv("GOPHKEEPER_GRPC_CERT_FILE_NAME")); err == nil {
+		caPEM = append(caPEM, serverPEM...)
+	}
+	caFile := filepath.Join(dir, "ca.pem")
+	suite.Require().NoError(os.WriteFile(caFile, caPEM, 0o600))
+
+	suite.serverEnvs = []string{"GOPHKEEPER_GRPC_CLIENT_CA_FILE_NAME=" + caFile}
+	suite.clientCert = &suite.certA
+	suite.commonTestSuite.SetupSuite()
+}
+
+func (suite *CertificateSuite) TearDownSuite() {
+	suite.commonTestSuite.TearDownSuite()
+	os.RemoveAll(suite.dir)
+}
+
+func (suite *CertificateSuite) TestCertificate() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	connB, err := suite.dial(ctx, &suite.certB)
+	suite.Require().NoError(err, "gRPC dial error")
+	defer connB.Close()
+	clientB := pb.NewGophKeeperServiceClient(connB)
+
+	suite.Run("bind certificate", func() {
+		// the session started before the binding
+		other, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+
+		resp, err := suite.grpcClient.BindCertificate(newContextWithAuthData(ctx, suite.token), &pb.BindCertificateRequest{
+			Hash: suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC bind certificate error", err)
+		sum := sha256.Sum256(suite.certA.Certificate[0])
+		suite.Assert().Equal(hex.EncodeToString(sum[:]), resp.Fingerprint)
+
+		// other sessions are revoked, the current one is kept
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, other.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, suite.token), &pb.ListVaultItemsRequest{})
+		suite.Assert().NoError(err, "gRPC list vault items error", err)
+	})
+
+	suite.Run("login with another certificate", func() {
+		_, err := clientB.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrClientCertificateMismatch)
+
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC login with bound certificate error", err)
+
+		// the tokens are valid only with the certificate of the session
+		_, err = clientB.ListVaultItems(newContextWithAuthData(ctx, resp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+		_, err = clientB.RefreshToken(ctx, &pb.RefreshTokenRequest{
+			RefreshToken: resp.RefreshToken,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+	})
+
+	suite.Run("unbind certificate", func() {
+		_, err := suite.grpcClient.UnbindCertificate(newContextWithAuthData(ctx, suite.token), &pb.UnbindCertificateRequest{
+			Hash: suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC unbind certificate error", err)
+
+		_, err = clientB.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().NoError(err, "gRPC login with another certificate error", err)
+	})
+}
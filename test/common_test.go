@@ -28,18 +28,28 @@ type commonTestSuite struct {
 
 	host        string
 	port        string
+	gatewayPort string
 	mailpitHost string
 	mailpitPort string
 	redisHost   string
 	redisPort   string
 	binaryPath  string
 	envs        []string
+	// serverEnvs and clientCert are set by the suites that need a special server configuration
+	// (e.g. mutual TLS) before the common setup
+	serverEnvs []string
+	clientCert *tls.Certificate
 
 	conn       *grpc.ClientConn
 	grpcClient pb.GophKeeperServiceClient
 	authHash   []byte
-	email      string
-	token      string
+	// wrappedVaultKey is opaque for the server, so it is random here
+	wrappedVaultKey []byte
+	recoveryHash    []byte
+	// recoveryWrappedVaultKey is random too
+	recoveryWrappedVaultKey []byte
+	email                   string
+	token                   string
 
 	serverProcess *fork.BackgroundProcess
 }
@@ -68,16 +78,11 @@ func (suite *commonTestSuite) SetupSuite() {
 
 	suite.envs = os.Environ()
 	suite.envs = append(suite.envs, "GOPHKEEPER_SERVICE_TOKEN_SECRET_KEY="+faker.StringWithSize(20))
+	suite.envs = append(suite.envs, "GOPHKEEPER_GRPC_GATEWAY_PORT="+suite.gatewayPort)
+	suite.envs = append(suite.envs, suite.serverEnvs...)
 	suite.serverUp(ctx, suite.envs)
 
-	config := &tls.Config{
-		ServerName:         suite.host,
-		InsecureSkipVerify: true,
-		MinVersion:         tls.VersionTLS13,
-	}
-
-	addr := suite.host + ":" + suite.port
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)), grpc.WithBlock())
+	conn, err := suite.dial(ctx, suite.clientCert)
 	if err != nil {
 		suite.Require().Error(err, "gRPC dial error")
 		return
@@ -90,6 +95,21 @@ func (suite *commonTestSuite) SetupSuite() {
 	suite.registerAndLogin(ctx)
 }
 
+// dial connects to the server with the client certificate, cert can be nil.
+func (suite *commonTestSuite) dial(ctx context.Context, cert *tls.Certificate) (*grpc.ClientConn, error) {
+	config := &tls.Config{
+		ServerName:         suite.host,
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS13,
+	}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+
+	addr := suite.host + ":" + suite.port
+	return grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(credentials.NewTLS(config)), grpc.WithBlock())
+}
+
 func (suite *commonTestSuite) serverUp(ctx context.Context, envs []string) {
 	p := fork.NewBackgroundProcess(context.Background(),
 		suite.binaryPath,
@@ -172,6 +192,9 @@ func (suite *commonTestSuite) getEnvs() error {
 	if suite.port, ok = os.LookupEnv("TEST_GRPC_PORT"); !ok {
 		return errors.New("TEST_GRPC_PORT not set")
 	}
+	if suite.gatewayPort, ok = os.LookupEnv("TEST_GATEWAY_PORT"); !ok {
+		return errors.New("TEST_GATEWAY_PORT not set")
+	}
 	if suite.mailpitHost, ok = os.LookupEnv("TEST_MAIL_HOST"); !ok {
 		return errors.New("TEST_MAIL_HOST not set")
 	}
@@ -198,12 +221,26 @@ func (suite *commonTestSuite) registerAndLogin(ctx context.Context) {
 	suite.Require().NoError(err, "Generate random auth hash error")
 	suite.T().Logf("Generate random auth hash: len %d", len(suite.authHash))
 
+	suite.wrappedVaultKey = make([]byte, 72)
+	_, err = rand.Read(suite.wrappedVaultKey)
+	suite.Require().NoError(err, "Generate random wrapped vault key error")
+
+	suite.recoveryHash = make([]byte, 32)
+	_, err = rand.Read(suite.recoveryHash)
+	suite.Require().NoError(err, "Generate random recovery hash error")
+	suite.recoveryWrappedVaultKey = make([]byte, 72)
+	_, err = rand.Read(suite.recoveryWrappedVaultKey)
+	suite.Require().NoError(err, "Generate random recovery wrapped vault key error")
+
 	suite.email = faker.SafeEmail()
 	suite.T().Logf("Generate random email: %s", suite.email)
 
 	_, err = suite.grpcClient.Register(ctx, &pb.RegisterRequest{
-		Email: suite.email,
-		Hash:  suite.authHash,
+		Email:                   suite.email,
+		Hash:                    suite.authHash,
+		WrappedVaultKey:         suite.wrappedVaultKey,
+		RecoveryHash:            suite.recoveryHash,
+		RecoveryWrappedVaultKey: suite.recoveryWrappedVaultKey,
 	})
 	suite.Require().NoError(err, "gRPC user register error", err)
 
@@ -214,46 +251,10 @@ func (suite *commonTestSuite) registerAndLogin(ctx context.Context) {
 	})
 	suite.Require().ErrorIs(err, pb.ErrUserEmailNotVerified)
 
-	getIdLastMail := func(toEmail string) ([]byte, error) {
-		resp, err := http.Get(fmt.Sprintf("http://%s/api/v1/search?query=to:\"%s\"&limit=1", suite.mailpitHost+":"+suite.mailpitPort, toEmail))
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, errors.New("code not found")
-		}
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		return body, nil
-	}
-
-	getBodyLastMail := func(id string) ([]byte, error) {
-		resp, err := http.Get(fmt.Sprintf("http://%s/api/v1/message/%s", suite.mailpitHost+":"+suite.mailpitPort, id))
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, errors.New("code not found")
-		}
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		return body, nil
-	}
-
 	// waiting for the mail
 	var body []byte
 	for attempt := 0; attempt < 20; attempt++ {
-		body, err = getIdLastMail(suite.email)
+		body, err = suite.searchMails(suite.email)
 		if err != nil {
 			time.Sleep(500 * time.Millisecond)
 			continue
@@ -270,7 +271,7 @@ func (suite *commonTestSuite) registerAndLogin(ctx context.Context) {
 	id, err := jsonparser.GetString(body, "messages", "[0]", "ID")
 	suite.Require().NoError(err, "Wrong response format from mailpit")
 
-	body, err = getBodyLastMail(id)
+	body, err = suite.getMail(id)
 	suite.Require().NoError(err, "Not found mail by id in mailpit")
 
 	text, err := jsonparser.GetString(body, "Text")
@@ -292,6 +293,45 @@ func (suite *commonTestSuite) registerAndLogin(ctx context.Context) {
 	})
 	suite.Require().NoError(err, "gRPC user login with mail verification code error", err)
 	suite.Assert().NotEqual(0, len(resp.Token), "Token not found in response")
+	suite.Assert().Equal(suite.wrappedVaultKey, resp.WrappedVaultKey, "Wrapped vault key not equal to registered one")
 
 	suite.token = resp.Token
 }
+
+// searchMails returns the mailpit search result with the last mail to the email and the number of the mails.
+func (suite *commonTestSuite) searchMails(toEmail string) ([]byte, error) {
+	resp, err := http.Get(fmt.Sprintf("http://%s/api/v1/search?query=to:\"%s\"&limit=1", suite.mailpitHost+":"+suite.mailpitPort, toEmail))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("code not found")
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// getMail returns the mail from mailpit by id.
+func (suite *commonTestSuite) getMail(id string) ([]byte, error) {
+	resp, err := http.Get(fmt.Sprintf("http://%s/api/v1/message/%s", suite.mailpitHost+":"+suite.mailpitPort, id))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("code not found")
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pioz/faker"
+	"github.com/rs/xid"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+)
+
+type GatewaySuite struct {
+	commonTestSuite
+}
+
+func (suite *GatewaySuite) TestGateway() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName:         suite.host,
+				InsecureSkipVerify: true,
+				MinVersion:         tls.VersionTLS13,
+			},
+		},
+	}
+	baseURL := "https://" + suite.host + ":" + suite.gatewayPort
+
+	// do sends the JSON request to the gateway and decodes the JSON response into resp if it is not nil
+	do := func(method, path, token string, req, resp any) int {
+		var body bytes.Buffer
+		if req != nil {
+			suite.Require().NoError(json.NewEncoder(&body).Encode(req))
+		}
+		r, err := http.NewRequestWithContext(ctx, method, baseURL+path, &body)
+		suite.Require().NoError(err)
+		r.Header.Set("Content-Type", "application/json")
+		if len(token) != 0 {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := httpClient.Do(r)
+		suite.Require().NoError(err, "gateway request error")
+		defer res.Body.Close()
+
+		if resp != nil && res.StatusCode == http.StatusOK {
+			suite.Require().NoError(json.NewDecoder(res.Body).Decode(resp))
+		}
+		return res.StatusCode
+	}
+
+	suite.Run("register", func() {
+		authHash := make([]byte, 32)
+		_, err := rand.Read(authHash)
+		suite.Require().NoError(err)
+		email := faker.SafeEmail()
+
+		// bytes are encoded as base64 strings
+		code := do(http.MethodPost, "/v1/register", "", map[string]any{
+			"email": email,
+			"hash":  authHash,
+		}, nil)
+		suite.Assert().Equal(http.StatusOK, code)
+
+		code = do(http.MethodPost, "/v1/register", "", map[string]any{
+			"email": email,
+			"hash":  authHash,
+		}, nil)
+		suite.Assert().Equal(http.StatusConflict, code, "the same email must be rejected")
+
+		// the email is not verified yet
+		code = do(http.MethodPost, "/v1/login", "", map[string]any{
+			"email": email,
+			"hash":  authHash,
+		}, nil)
+		suite.Assert().Equal(http.StatusUnauthorized, code)
+	})
+
+	suite.Run("login and list items", func() {
+		itemID := xid.New().String()
+		_, err := suite.grpcClient.SetVaultItem(newContextWithAuthData(ctx, suite.token), &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    itemID,
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: []byte(faker.String()),
+			},
+		})
+		suite.Require().NoError(err, "gRPC add vault item error", err)
+
+		var login struct {
+			Token string `json:"token"`
+		}
+		code := do(http.MethodPost, "/v1/login", "", map[string]any{
+			"email": suite.email,
+			"hash":  suite.authHash,
+		}, &login)
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().NotEmpty(login.Token)
+
+		var list struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		}
+		code = do(http.MethodGet, "/v1/vault/items", login.Token, nil, &list)
+		suite.Require().Equal(http.StatusOK, code)
+		ids := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			ids = append(ids, item.ID)
+		}
+		suite.Assert().Contains(ids, itemID)
+
+		code = do(http.MethodGet, "/v1/vault/items", "", nil, nil)
+		suite.Assert().Equal(http.StatusBadRequest, code, "the token is required")
+	})
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/pioz/faker"
+	"github.com/rs/xid"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+)
+
+const largeVaultItemChunkSize = 32 * 1024
+
+type LargeVaultSuite struct {
+	commonTestSuite
+}
+
+func (suite *LargeVaultSuite) TestLargeVault() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	itemID := xid.New().String()
+	value := make([]byte, 5*1024*1024+100)
+	_, err := rand.Read(value)
+	suite.Require().NoError(err)
+
+	var setServerUpdatedAt int64
+
+	suite.Run("upload large vault item: bad auth", func() {
+		stream, err := suite.grpcClient.UploadVaultItem(ctx)
+		suite.Require().NoError(err)
+
+		_, err = stream.CloseAndRecv()
+		suite.Assert().ErrorIs(err, pb.ErrEmptyAuthData)
+	})
+
+	suite.Run("set large vault item by unary method", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		_, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    itemID,
+				Name:  faker.String(),
+				Itype: pb.IType(vault.BinaryLarge),
+				Value: value[:1024],
+			},
+		})
+		suite.Assert().ErrorIs(err, pb.ErrVaultItemValueTooBig)
+	})
+
+	suite.Run("upload large vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		stream, err := suite.grpcClient.UploadVaultItem(ctx)
+		suite.Require().NoError(err)
+
+		err = stream.Send(&pb.UploadVaultItemRequest{
+			Data: &pb.UploadVaultItemRequest_Item{
+				Item: &pb.VaultItem{
+					Id:    itemID,
+					Name:  faker.String(),
+					Itype: pb.IType(vault.BinaryLarge),
+				},
+			},
+		})
+		suite.Require().NoError(err)
+
+		for i := 0; i < len(value); i += largeVaultItemChunkSize {
+			err = stream.Send(&pb.UploadVaultItemRequest{
+				Data: &pb.UploadVaultItemRequest_Chunk{
+					Chunk: value[i:min(i+largeVaultItemChunkSize, len(value))],
+				},
+			})
+			suite.Require().NoError(err)
+		}
+
+		resp, err := stream.CloseAndRecv()
+		suite.Require().NoError(err, "gRPC upload vault item error", err)
+		setServerUpdatedAt = resp.ServerUpdatedAt
+
+		respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: setServerUpdatedAt - 1,
+		})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Require().Len(respList.Items, 1, "returned wrong number of vault items")
+		suite.Assert().Len(respList.Items[0].Value, 0, "value of large vault item must not be listed")
+	})
+
+	suite.Run("download large vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		stream, err := suite.grpcClient.DownloadVaultItem(ctx, &pb.DownloadVaultItemRequest{
+			Id: itemID,
+		})
+		suite.Require().NoError(err)
+
+		resp, err := stream.Recv()
+		suite.Require().NoError(err, "gRPC download vault item error", err)
+		suite.Require().NotNil(resp.GetItem(), "first message must contain item")
+		suite.Assert().Equal(setServerUpdatedAt, resp.GetItem().ServerUpdatedAt)
+
+		got := bytes.NewBuffer(nil)
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			suite.Require().NoError(err, "gRPC download vault item error", err)
+			got.Write(resp.GetChunk())
+		}
+		suite.Assert().Equal(value, got.Bytes(), "downloaded value not equal to uploaded one")
+	})
+
+	suite.Run("download not existing vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		stream, err := suite.grpcClient.DownloadVaultItem(ctx, &pb.DownloadVaultItemRequest{
+			Id: xid.New().String(),
+		})
+		suite.Require().NoError(err)
+
+		_, err = stream.Recv()
+		suite.Assert().ErrorIs(err, pb.ErrVaultItemNotExists)
+	})
+
+	suite.Run("delete large vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		_, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:              itemID,
+				Itype:           pb.IType(vault.BinaryLarge),
+				ServerUpdatedAt: setServerUpdatedAt,
+				IsDeleted:       true,
+			},
+		})
+		suite.Assert().NoError(err)
+	})
+}
+
+func (suite *LargeVaultSuite) TestChangePassword() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	ctx = newContextWithAuthData(ctx, suite.token)
+
+	smallItemID := xid.New().String()
+	largeItemID := xid.New().String()
+	largeValue := make([]byte, 1024*1024+100)
+	_, err := rand.Read(largeValue)
+	suite.Require().NoError(err)
+
+	var smallServerUpdatedAt, largeServerUpdatedAt int64
+
+	suite.Run("prepare vault", func() {
+		resp, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    smallItemID,
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: []byte(faker.String()),
+			},
+		})
+		suite.Require().NoError(err, "gRPC set vault item error", err)
+		smallServerUpdatedAt = resp.ServerUpdatedAt
+
+		stream, err := suite.grpcClient.UploadVaultItem(ctx)
+		suite.Require().NoError(err)
+		err = stream.Send(&pb.UploadVaultItemRequest{
+			Data: &pb.UploadVaultItemRequest_Item{
+				Item: &pb.VaultItem{
+					Id:    largeItemID,
+					Name:  faker.String(),
+					Itype: pb.IType(vault.BinaryLarge),
+				},
+			},
+		})
+		suite.Require().NoError(err)
+		err = stream.Send(&pb.UploadVaultItemRequest{
+			Data: &pb.UploadVaultItemRequest_Chunk{
+				Chunk: largeValue,
+			},
+		})
+		suite.Require().NoError(err)
+		uploadResp, err := stream.CloseAndRecv()
+		suite.Require().NoError(err, "gRPC upload vault item error", err)
+		largeServerUpdatedAt = uploadResp.ServerUpdatedAt
+	})
+
+	newHash := make([]byte, 32)
+	_, err = rand.Read(newHash)
+	suite.Require().NoError(err)
+	newWrappedVaultKey := make([]byte, 72)
+	_, err = rand.Read(newWrappedVaultKey)
+	suite.Require().NoError(err)
+	newSmallValue := []byte(faker.String())
+	newLargeValue := make([]byte, len(largeValue))
+	_, err = rand.Read(newLargeValue)
+	suite.Require().NoError(err)
+
+	changePassword := func(hash []byte, smallServerUpdatedAt int64) (*pb.ChangePasswordResponse, error) {
+		stream, err := suite.grpcClient.ChangePassword(ctx)
+		suite.Require().NoError(err)
+
+		err = stream.Send(&pb.ChangePasswordRequest{
+			Data: &pb.ChangePasswordRequest_Hashes{
+				Hashes: &pb.ChangePasswordHashes{
+					Hash:               hash,
+					NewHash:            newHash,
+					NewWrappedVaultKey: newWrappedVaultKey,
+				},
+			},
+		})
+		if err == nil {
+			err = stream.Send(&pb.ChangePasswordRequest{
+				Data: &pb.ChangePasswordRequest_Item{
+					Item: &pb.VaultItem{
+						Id:              largeItemID,
+						Name:            faker.String(),
+						Itype:           pb.IType(vault.BinaryLarge),
+						ServerUpdatedAt: largeServerUpdatedAt,
+					},
+				},
+			})
+		}
+		for i := 0; i < len(newLargeValue) && err == nil; i += largeVaultItemChunkSize {
+			err = stream.Send(&pb.ChangePasswordRequest{
+				Data: &pb.ChangePasswordRequest_Chunk{
+					Chunk: newLargeValue[i:min(i+largeVaultItemChunkSize, len(newLargeValue))],
+				},
+			})
+		}
+		if err == nil {
+			_ = stream.Send(&pb.ChangePasswordRequest{
+				Data: &pb.ChangePasswordRequest_Item{
+					Item: &pb.VaultItem{
+						Id:              smallItemID,
+						Name:            faker.String(),
+						Itype:           pb.IType(vault.Text),
+						Value:           newSmallValue,
+						ServerUpdatedAt: smallServerUpdatedAt,
+					},
+				},
+			})
+		}
+
+		return stream.CloseAndRecv()
+	}
+
+	// the session on another device
+	otherResp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+		Email: suite.email,
+		Hash:  suite.authHash,
+	})
+	suite.Require().NoError(err, "gRPC login error", err)
+
+	suite.Run("change password: bad arguments", func() {
+		_, err := changePassword(newHash, smallServerUpdatedAt)
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+
+		_, err = changePassword(suite.authHash, smallServerUpdatedAt-1)
+		suite.Assert().ErrorIs(err, pb.ErrVaultItemConflictVersion)
+	})
+
+	suite.Run("change password", func() {
+		resp, err := changePassword(suite.authHash, smallServerUpdatedAt)
+		suite.Require().NoError(err, "gRPC change password error", err)
+		suite.Assert().Greater(resp.ServerUpdatedAt, largeServerUpdatedAt)
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrUserInvalidHash)
+		loginResp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  newHash,
+		})
+		suite.Require().NoError(err, "gRPC login with new hash error", err)
+		suite.Assert().Equal(newWrappedVaultKey, loginResp.WrappedVaultKey)
+		suite.authHash = newHash
+		suite.wrappedVaultKey = newWrappedVaultKey
+
+		// other sessions are revoked, the current one is kept
+		_, err = suite.grpcClient.ListVaultItems(newContextWithAuthData(ctx, otherResp.Token), &pb.ListVaultItemsRequest{})
+		suite.Assert().ErrorIs(err, pb.ErrUserNeedAuthentication)
+
+		listResp, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: resp.ServerUpdatedAt - 1,
+		})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Require().Len(listResp.Items, 2, "returned wrong number of vault items")
+		for _, item := range listResp.Items {
+			suite.Assert().Equal(resp.ServerUpdatedAt, item.ServerUpdatedAt)
+			if item.Id == smallItemID {
+				suite.Assert().Equal(newSmallValue, item.Value)
+			}
+		}
+
+		stream, err := suite.grpcClient.DownloadVaultItem(ctx, &pb.DownloadVaultItemRequest{
+			Id: largeItemID,
+		})
+		suite.Require().NoError(err)
+		_, err = stream.Recv()
+		suite.Require().NoError(err, "gRPC download vault item error", err)
+		got := bytes.NewBuffer(nil)
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			suite.Require().NoError(err, "gRPC download vault item error", err)
+			got.Write(resp.GetChunk())
+		}
+		suite.Assert().Equal(newLargeValue, got.Bytes(), "downloaded value not equal to the new one")
+	})
+	suite.Run("change password: rewrap vault key", func() {
+		rewrap := func(newHash, newWrappedVaultKey []byte) (*pb.ChangePasswordResponse, error) {
+			stream, err := suite.grpcClient.ChangePassword(ctx)
+			suite.Require().NoError(err)
+
+			err = stream.Send(&pb.ChangePasswordRequest{
+				Data: &pb.ChangePasswordRequest_Hashes{
+					Hashes: &pb.ChangePasswordHashes{
+						Hash:               suite.authHash,
+						NewHash:            newHash,
+						NewWrappedVaultKey: newWrappedVaultKey,
+					},
+				},
+			})
+			suite.Require().NoError(err)
+
+			return stream.CloseAndRecv()
+		}
+
+		newHash := make([]byte, 32)
+		_, err := rand.Read(newHash)
+		suite.Require().NoError(err)
+		newWrappedVaultKey := make([]byte, 72)
+		_, err = rand.Read(newWrappedVaultKey)
+		suite.Require().NoError(err)
+
+		// the vault key can not be lost
+		_, err = rewrap(newHash, nil)
+		suite.Assert().ErrorIs(err, pb.ErrInvalidVaultKeyFormat)
+
+		resp, err := rewrap(newHash, newWrappedVaultKey)
+		suite.Require().NoError(err, "gRPC change password error", err)
+		suite.Assert().Equal(int64(0), resp.ServerUpdatedAt, "vault must not be changed")
+
+		loginResp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  newHash,
+		})
+		suite.Require().NoError(err, "gRPC login with new hash error", err)
+		suite.Assert().Equal(newWrappedVaultKey, loginResp.WrappedVaultKey)
+		suite.authHash = newHash
+		suite.wrappedVaultKey = newWrappedVaultKey
+	})
+}
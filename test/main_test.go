@@ -39,6 +39,30 @@ func TestSyncVault(t *testing.T) {
 	suite.Run(t, new(SyncVaultSuite))
 }
 
+func TestLargeVault(t *testing.T) {
+	suite.Run(t, new(LargeVaultSuite))
+}
+
+func TestGateway(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+func TestCertificate(t *testing.T) {
+	suite.Run(t, new(CertificateSuite))
+}
+
+func TestQuota(t *testing.T) {
+	suite.Run(t, new(QuotaSuite))
+}
+
+func TestTombstone(t *testing.T) {
+	suite.Run(t, new(TombstoneSuite))
+}
+
+func TestUnverified(t *testing.T) {
+	suite.Run(t, new(UnverifiedSuite))
+}
+
 func newContextWithAuthData(ctx context.Context, token string) context.Context {
 	md := metadata.New(map[string]string{"token": token})
 	return metadata.NewOutgoingContext(ctx, md)
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pioz/faker"
+	"github.com/rs/xid"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+)
+
+const (
+	quotaMaxItems = 3
+	quotaMaxBytes = 1024
+)
+
+type QuotaSuite struct {
+	commonTestSuite
+}
+
+// SetupSuite runs the server with the default quota of the user vault.
+func (suite *QuotaSuite) SetupSuite() {
+	suite.serverEnvs = []string{
+		"GOPHKEEPER_SERVICE_QUOTA_MAX_ITEMS=" + strconv.Itoa(quotaMaxItems),
+		"GOPHKEEPER_SERVICE_QUOTA_MAX_BYTES=" + strconv.Itoa(quotaMaxBytes),
+	}
+	suite.commonTestSuite.SetupSuite()
+}
+
+func (suite *QuotaSuite) TestQuota() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	// items are the vault items of the user by ID with their server update times
+	items := make(map[string]int64)
+	newItem := func() *pb.VaultItem {
+		return &pb.VaultItem{
+			Id:    xid.New().String(),
+			Name:  faker.String(),
+			Itype: pb.IType(vault.Text),
+			Value: []byte(faker.StringWithSize(10)),
+		}
+	}
+	deletedItem := func(id string) *pb.VaultItem {
+		return &pb.VaultItem{
+			Id:              id,
+			Name:            faker.String(),
+			Itype:           pb.IType(vault.Text),
+			IsDeleted:       true,
+			ServerUpdatedAt: items[id],
+		}
+	}
+	anyItemID := func() string {
+		for id := range items {
+			return id
+		}
+		return ""
+	}
+
+	suite.Run("quota exceeded by items", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		for i := 0; i < quotaMaxItems; i++ {
+			item := newItem()
+			resp, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{Item: item})
+			suite.Require().NoError(err, "gRPC add vault item error", err)
+			items[item.Id] = resp.ServerUpdatedAt
+		}
+
+		_, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{Item: newItem()})
+		suite.Assert().ErrorIs(err, pb.ErrQuotaExceeded)
+
+		usage, err := suite.grpcClient.GetAccountUsage(ctx, &pb.GetAccountUsageRequest{})
+		suite.Require().NoError(err, "gRPC get account usage error", err)
+		suite.Assert().Equal(int64(quotaMaxItems), usage.Items, "the item over quota must not be set")
+	})
+
+	suite.Run("quota exceeded by size", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		id := anyItemID()
+		_, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:              id,
+				Name:            faker.String(),
+				Itype:           pb.IType(vault.Text),
+				Value:           []byte(faker.StringWithSize(quotaMaxBytes + 1)),
+				ServerUpdatedAt: items[id],
+			},
+		})
+		suite.Assert().ErrorIs(err, pb.ErrQuotaExceeded)
+	})
+
+	suite.Run("set items over quota", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		// one item is deleted, but two new items do not fit anyway
+		id := anyItemID()
+		reqItems := []*pb.VaultItem{newItem(), deletedItem(id), newItem()}
+		resp, err := suite.grpcClient.SetVaultItems(ctx, &pb.SetVaultItemsRequest{
+			Items: reqItems,
+		})
+		suite.Require().NoError(err, "gRPC set vault items error", err)
+		suite.Require().Len(resp.Results, len(reqItems), "returned wrong number of results")
+
+		for i, status := range []pb.SetVaultItemStatus{
+			pb.SetVaultItemStatus_QUOTA_EXCEEDED,
+			pb.SetVaultItemStatus_ACCEPTED,
+			pb.SetVaultItemStatus_QUOTA_EXCEEDED,
+		} {
+			suite.Assert().Equal(status, resp.Results[i].Status, "returned wrong status of vault item")
+		}
+		delete(items, id)
+	})
+
+	suite.Run("restart server with lower quota", func() {
+		suite.serverDown()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		envs := make([]string, len(suite.envs)+1)
+		copy(envs, suite.envs)
+		envs = append(envs, "GOPHKEEPER_SERVICE_QUOTA_MAX_ITEMS=1")
+		suite.serverUp(ctx, envs)
+
+		resp, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: suite.email,
+			Hash:  suite.authHash,
+		})
+		suite.Require().NoError(err, "gRPC existed user login error", err)
+		suite.token = resp.Token
+	})
+
+	suite.Run("delete over quota", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		// the vault already exceeds the quota: new items are rejected, but deletion frees the space
+		suite.Require().Greater(len(items), 1)
+		_, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{Item: newItem()})
+		suite.Assert().ErrorIs(err, pb.ErrQuotaExceeded)
+
+		id := anyItemID()
+		_, err = suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{Item: deletedItem(id)})
+		suite.Require().NoError(err, "gRPC delete vault item over quota error", err)
+		delete(items, id)
+
+		reqItems := make([]*pb.VaultItem, 0, len(items))
+		for id := range items {
+			reqItems = append(reqItems, deletedItem(id))
+		}
+		resp, err := suite.grpcClient.SetVaultItems(ctx, &pb.SetVaultItemsRequest{
+			Items: reqItems,
+		})
+		suite.Require().NoError(err, "gRPC set vault items error", err)
+		for _, res := range resp.Results {
+			suite.Assert().Equal(pb.SetVaultItemStatus_ACCEPTED, res.Status, "deletion must be accepted over quota")
+		}
+
+		usage, err := suite.grpcClient.GetAccountUsage(ctx, &pb.GetAccountUsageRequest{})
+		suite.Require().NoError(err, "gRPC get account usage error", err)
+		suite.Assert().Equal(int64(0), usage.Items)
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/pioz/faker"
+	"github.com/rs/xid"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+	"github.com/Karzoug/goph_keeper/common/model/vault"
+)
+
+type TombstoneSuite struct {
+	commonTestSuite
+}
+
+// SetupSuite runs the server purging the deleted items every second.
+func (suite *TombstoneSuite) SetupSuite() {
+	suite.serverEnvs = []string{
+		"GOPHKEEPER_SERVICE_TOMBSTONE_RETENTION=1s",
+		"GOPHKEEPER_RTASK_PURGE_TOMBSTONES_SCHEDULE=@every 1s",
+	}
+	suite.commonTestSuite.SetupSuite()
+}
+
+func (suite *TombstoneSuite) TestPurgeTombstones() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	var (
+		keptID, deletedID string
+		keptAt, deletedAt int64
+	)
+
+	suite.Run("delete vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		keptID = xid.New().String()
+		respSet, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    keptID,
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: []byte(faker.String()),
+			},
+		})
+		suite.Require().NoError(err, "gRPC add vault item error", err)
+		keptAt = respSet.ServerUpdatedAt
+
+		deletedID = xid.New().String()
+		respSet, err = suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    deletedID,
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: []byte(faker.String()),
+			},
+		})
+		suite.Require().NoError(err, "gRPC add vault item error", err)
+
+		respSet, err = suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:              deletedID,
+				Name:            faker.String(),
+				Itype:           pb.IType(vault.Text),
+				IsDeleted:       true,
+				ServerUpdatedAt: respSet.ServerUpdatedAt,
+			},
+		})
+		suite.Require().NoError(err, "gRPC delete vault item error", err)
+		deletedAt = respSet.ServerUpdatedAt
+	})
+
+	suite.Run("deleted vault item is purged", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		// the purge task runs every second, the deleted item is purged after the retention
+		suite.Require().Eventually(func() bool {
+			respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
+			suite.Require().NoError(err, "gRPC list vault items error", err)
+			for _, item := range respList.Items {
+				if item.Id == deletedID {
+					return false
+				}
+			}
+			return true
+		}, 30*time.Second, 500*time.Millisecond, "deleted vault item is not purged")
+
+		respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Require().Len(respList.Items, 1, "returned wrong number of vault items")
+		suite.Assert().Equal(keptID, respList.Items[0].Id, "not deleted vault item must be kept")
+	})
+
+	suite.Run("full resync required", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		// the client synchronized before the deletion missed the purged item
+		_, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: keptAt,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrFullResyncRequired)
+
+		// the client synchronized after the deletion missed nothing
+		respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: deletedAt,
+		})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Assert().Len(respList.Items, 0, "returned wrong number of vault items")
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/pioz/faker"
+
+	pb "github.com/Karzoug/goph_keeper/common/grpc"
+)
+
+const unverifiedCodeLifetime = 10 * time.Second
+
+type UnverifiedSuite struct {
+	commonTestSuite
+}
+
+// SetupSuite runs the server cleaning up the unverified users every second
+// with the short verification code lifetime and grace period.
+func (suite *UnverifiedSuite) SetupSuite() {
+	suite.serverEnvs = []string{
+		"GOPHKEEPER_SERVICE_EMAIL_CODE_LIFETIME=" + unverifiedCodeLifetime.String(),
+		"GOPHKEEPER_SERVICE_UNVERIFIED_USERS_GRACE_PERIOD=1s",
+		"GOPHKEEPER_RTASK_CLEANUP_UNVERIFIED_USERS_SCHEDULE=@every 1s",
+	}
+	suite.commonTestSuite.SetupSuite()
+}
+
+func (suite *UnverifiedSuite) TestCleanupUnverifiedUsers() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	register := func() (string, []byte) {
+		email := faker.SafeEmail()
+		hash := make([]byte, 32)
+		_, err := rand.Read(hash)
+		suite.Require().NoError(err, "Generate random auth hash error")
+
+		_, err = suite.grpcClient.Register(ctx, &pb.RegisterRequest{
+			Email: email,
+			Hash:  hash,
+		})
+		suite.Require().NoError(err, "gRPC user register error", err)
+		return email, hash
+	}
+
+	remindedEmail, remindedHash := register()
+	deletedEmail, deletedHash := register()
+
+	suite.Run("reminder with new code", func() {
+		// the reminder is the second mail after the welcome one, it is sent when the first code has expired
+		var body []byte
+		suite.Require().Eventually(func() bool {
+			var err error
+			body, err = suite.searchMails(remindedEmail)
+			if err != nil {
+				return false
+			}
+			count, err := jsonparser.GetInt(body, "messages_count")
+			return err == nil && count == 2
+		}, 3*unverifiedCodeLifetime, 500*time.Millisecond, "reminder mail is not sent")
+
+		id, err := jsonparser.GetString(body, "messages", "[0]", "ID")
+		suite.Require().NoError(err, "Wrong response format from mailpit")
+		body, err = suite.getMail(id)
+		suite.Require().NoError(err, "Not found mail by id in mailpit")
+		text, err := jsonparser.GetString(body, "Text")
+		suite.Require().NoError(err, "Wrong response format from mailpit")
+
+		substr := `activate your account: `
+		pos := strings.Index(text, substr)
+		suite.Require().NotEqual(-1, pos, "Verification code not found in reminder mail")
+		code := text[pos+len(substr) : pos+len(substr)+emailCodeLength]
+
+		_, err = suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email:     remindedEmail,
+			Hash:      remindedHash,
+			EmailCode: code,
+		})
+		suite.Require().NoError(err, "gRPC user login with reminder code error", err)
+	})
+
+	suite.Run("unverified user deleted", func() {
+		// the user is deleted when the reminder code has expired too and the grace period has passed
+		suite.Require().Eventually(func() bool {
+			_, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+				Email: deletedEmail,
+				Hash:  deletedHash,
+			})
+			return errors.Is(err, pb.ErrUserNotExists)
+		}, 4*unverifiedCodeLifetime, time.Second, "unverified user is not deleted")
+
+		// the email is free to register again
+		_, err := suite.grpcClient.Register(ctx, &pb.RegisterRequest{
+			Email: deletedEmail,
+			Hash:  deletedHash,
+		})
+		suite.Require().NoError(err, "gRPC user register again error", err)
+	})
+
+	suite.Run("verified user kept", func() {
+		_, err := suite.grpcClient.Login(ctx, &pb.LoginRequest{
+			Email: remindedEmail,
+			Hash:  remindedHash,
+		})
+		suite.Require().NoError(err, "gRPC verified user login error", err)
+	})
+}
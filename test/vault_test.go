@@ -108,3 +108,303 @@ func (suite *VaultSuite) TestVault() {
 		suite.Assert().NoError(err)
 	})
 }
+
+func (suite *VaultSuite) TestVaultBatch() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	ctx = newContextWithAuthData(ctx, suite.token)
+
+	conflictItemId := xid.New().String()
+	respSet, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+		Item: &pb.VaultItem{
+			Id:    conflictItemId,
+			Name:  faker.String(),
+			Itype: pb.IType(vault.Text),
+			Value: []byte(faker.String()),
+		},
+	})
+	suite.Require().NoError(err, "gRPC add vault item error", err)
+
+	suite.Run("set vault items with per item results", func() {
+		now := time.Now().UnixMicro()
+
+		items := []*pb.VaultItem{
+			{
+				Id:    xid.New().String(),
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: []byte(faker.String()),
+			},
+			{
+				Id:              conflictItemId,
+				Name:            faker.String(),
+				Itype:           pb.IType(vault.Text),
+				Value:           []byte(faker.String()),
+				ServerUpdatedAt: respSet.ServerUpdatedAt - 10, // client has old version
+			},
+			{
+				Id:    xid.New().String(),
+				Name:  faker.String(),
+				Itype: pb.IType(vault.BinaryLarge), // large item must be uploaded by stream
+			},
+			{
+				Id:    xid.New().String(),
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Password),
+				Value: []byte(faker.String()),
+			},
+		}
+
+		resp, err := suite.grpcClient.SetVaultItems(ctx, &pb.SetVaultItemsRequest{
+			Items: items,
+		})
+		suite.Require().NoError(err, "gRPC set vault items error", err)
+		suite.Require().Len(resp.Results, len(items), "returned wrong number of results")
+
+		for i, status := range []pb.SetVaultItemStatus{
+			pb.SetVaultItemStatus_ACCEPTED,
+			pb.SetVaultItemStatus_CONFLICT_VERSION,
+			pb.SetVaultItemStatus_VALUE_TOO_BIG,
+			pb.SetVaultItemStatus_ACCEPTED,
+		} {
+			suite.Assert().Equal(items[i].Id, resp.Results[i].Id, "returned results in wrong order")
+			suite.Assert().Equal(status, resp.Results[i].Status, "returned wrong status of vault item")
+		}
+		suite.Assert().LessOrEqual(now, resp.Results[0].ServerUpdatedAt,
+			"returned server update time must be equal or greater than time of request")
+
+		respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: now - 1, // -1 to avoid case server update time equal time of request
+		})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Assert().Len(respList.Items, 2, "returned wrong number of vault items")
+	})
+}
+
+func (suite *VaultSuite) TestWatchVault() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	suite.Run("bad auth", func() {
+		stream, err := suite.grpcClient.WatchVaultItems(ctx, &pb.WatchVaultItemsRequest{})
+		suite.Require().NoError(err, "gRPC watch vault items error", err)
+
+		_, err = stream.Recv()
+		suite.Assert().ErrorIs(err, pb.ErrEmptyAuthData)
+	})
+
+	suite.Run("receive event on vault change", func() {
+		ctx, cancel := context.WithCancel(newContextWithAuthData(ctx, suite.token))
+		defer cancel()
+
+		stream, err := suite.grpcClient.WatchVaultItems(ctx, &pb.WatchVaultItemsRequest{})
+		suite.Require().NoError(err, "gRPC watch vault items error", err)
+
+		events := make(chan *pb.WatchVaultItemsResponse)
+		go func() {
+			defer close(events)
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					return
+				}
+				select {
+				case events <- resp:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// the subscription is created asynchronously after the stream is opened,
+		// so the change is repeated until the event is received
+		setTimes := make(map[int64]bool)
+		var resp *pb.WatchVaultItemsResponse
+		suite.Eventually(func() bool {
+			respSet, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+				Item: &pb.VaultItem{
+					Id:    xid.New().String(),
+					Name:  faker.String(),
+					Itype: pb.IType(vault.Text),
+					Value: []byte(faker.String()),
+				},
+			})
+			if err != nil {
+				return false
+			}
+			setTimes[respSet.ServerUpdatedAt] = true
+
+			select {
+			case resp = <-events:
+				return true
+			case <-time.After(200 * time.Millisecond):
+				return false
+			}
+		}, 5*time.Second, 10*time.Millisecond, "no event on vault change")
+
+		suite.Require().NotNil(resp, "gRPC watch vault items receive error")
+		suite.Assert().True(setTimes[resp.ServerUpdatedAt], "returned wrong server update time")
+	})
+}
+
+func (suite *VaultSuite) TestListVaultPages() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	ctx = newContextWithAuthData(ctx, suite.token)
+
+	now := time.Now().UnixMicro()
+
+	// items of one batch have the same server update time,
+	// so the pages must be split by id too
+	items := make([]*pb.VaultItem, 5)
+	for i := 0; i < len(items); i++ {
+		items[i] = &pb.VaultItem{
+			Id:    xid.New().String(),
+			Name:  faker.String(),
+			Itype: pb.IType(vault.Text),
+			Value: []byte(faker.String()),
+		}
+	}
+	_, err := suite.grpcClient.SetVaultItems(ctx, &pb.SetVaultItemsRequest{
+		Items: items,
+	})
+	suite.Require().NoError(err, "gRPC set vault items error", err)
+
+	suite.Run("list vault items by pages", func() {
+		var (
+			pageToken string
+			pages     int
+		)
+		ids := make(map[string]struct{})
+		for {
+			resp, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+				Since:     now - 1, // -1 to avoid case server update time equal time of request
+				PageSize:  2,
+				PageToken: pageToken,
+			})
+			suite.Require().NoError(err, "gRPC list vault items error", err)
+			suite.Require().LessOrEqual(len(resp.Items), 2, "returned page is bigger than requested")
+			pages++
+
+			for _, item := range resp.Items {
+				suite.Assert().NotContains(ids, item.Id, "returned the same item twice")
+				ids[item.Id] = struct{}{}
+			}
+
+			if len(resp.NextPageToken) == 0 {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+		suite.Assert().Len(ids, len(items), "returned wrong number of vault items")
+		suite.Assert().Equal(3, pages, "returned wrong number of pages")
+	})
+
+	suite.Run("list vault items with invalid page token", func() {
+		_, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			PageSize:  2,
+			PageToken: "invalid token",
+		})
+		suite.Assert().ErrorIs(err, pb.ErrInvalidPageToken)
+	})
+}
+
+func (suite *VaultSuite) TestAccountUsage() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	suite.Run("usage counts set vault item", func() {
+		ctx := newContextWithAuthData(ctx, suite.token)
+
+		before, err := suite.grpcClient.GetAccountUsage(ctx, &pb.GetAccountUsageRequest{})
+		suite.Require().NoError(err, "gRPC get account usage error", err)
+
+		value := []byte(faker.ArticleWithParagraphCount(faker.IntInRange(1, 10)))
+		_, err = suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+			Item: &pb.VaultItem{
+				Id:    xid.New().String(),
+				Name:  faker.String(),
+				Itype: pb.IType(vault.Text),
+				Value: value,
+			},
+		})
+		suite.Require().NoError(err, "gRPC add vault item error", err)
+
+		after, err := suite.grpcClient.GetAccountUsage(ctx, &pb.GetAccountUsageRequest{})
+		suite.Require().NoError(err, "gRPC get account usage error", err)
+		suite.Assert().Equal(before.Items+1, after.Items, "returned wrong number of vault items")
+		suite.Assert().Equal(before.Size+int64(len(value)), after.Size, "returned wrong size of vault items")
+	})
+}
+
+func (suite *VaultSuite) TestItemRevisions() {
+	ctx, cancel := context.WithTimeout(context.Background(), testsTimeout)
+	defer cancel()
+
+	ctx = newContextWithAuthData(ctx, suite.token)
+
+	itemId := xid.New().String()
+	firstValue := []byte(faker.String())
+	respSet, err := suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+		Item: &pb.VaultItem{
+			Id:    itemId,
+			Name:  faker.String(),
+			Itype: pb.IType(vault.Text),
+			Value: firstValue,
+		},
+	})
+	suite.Require().NoError(err, "gRPC add vault item error", err)
+	firstServerUpdatedAt := respSet.ServerUpdatedAt
+
+	_, err = suite.grpcClient.SetVaultItem(ctx, &pb.SetVaultItemRequest{
+		Item: &pb.VaultItem{
+			Id:              itemId,
+			Name:            faker.String(),
+			Itype:           pb.IType(vault.Text),
+			Value:           []byte(faker.String()),
+			ServerUpdatedAt: firstServerUpdatedAt,
+		},
+	})
+	suite.Require().NoError(err, "gRPC update vault item error", err)
+
+	suite.Run("list item revisions", func() {
+		resp, err := suite.grpcClient.ListItemRevisions(ctx, &pb.ListItemRevisionsRequest{
+			Id: itemId,
+		})
+		suite.Require().NoError(err, "gRPC list item revisions error", err)
+		suite.Require().Len(resp.Revisions, 1, "returned wrong number of revisions")
+		suite.Assert().Equal(firstServerUpdatedAt, resp.Revisions[0].ServerUpdatedAt, "returned wrong revision version")
+		suite.Assert().Equal(firstValue, resp.Revisions[0].Value, "returned wrong revision value")
+	})
+
+	suite.Run("restore item revision", func() {
+		resp, err := suite.grpcClient.RestoreItemRevision(ctx, &pb.RestoreItemRevisionRequest{
+			Id:       itemId,
+			Revision: firstServerUpdatedAt,
+		})
+		suite.Require().NoError(err, "gRPC restore item revision error", err)
+
+		respList, err := suite.grpcClient.ListVaultItems(ctx, &pb.ListVaultItemsRequest{
+			Since: resp.ServerUpdatedAt - 1,
+		})
+		suite.Require().NoError(err, "gRPC list vault items error", err)
+		suite.Require().Len(respList.Items, 1, "returned wrong number of vault items")
+		suite.Assert().Equal(firstValue, respList.Items[0].Value, "restored wrong value")
+
+		respRevisions, err := suite.grpcClient.ListItemRevisions(ctx, &pb.ListItemRevisionsRequest{
+			Id: itemId,
+		})
+		suite.Require().NoError(err, "gRPC list item revisions error", err)
+		suite.Assert().Len(respRevisions.Revisions, 2, "replaced version must be kept as revision")
+	})
+
+	suite.Run("restore not existing revision", func() {
+		_, err := suite.grpcClient.RestoreItemRevision(ctx, &pb.RestoreItemRevisionRequest{
+			Id:       itemId,
+			Revision: 1,
+		})
+		suite.Assert().ErrorIs(err, pb.ErrVaultItemRevisionNotExists)
+	})
+}